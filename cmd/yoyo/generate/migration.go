@@ -16,7 +16,9 @@ import (
 )
 
 type FileOpener func(string) (*os.File, error)
-type DatabaseValidator func(database schema.Database) error
+// DatabaseValidator returns warnings and an error for the parts of a schema.Database its dialect changes or doesn't
+// support
+type DatabaseValidator func(database schema.Database) (warnings []string, err error)
 
 func Migrations(
 	now func() time.Time,
@@ -30,7 +32,11 @@ func Migrations(
 			return fmt.Errorf("unable to load config: %w", err)
 		}
 
-		if err := validate(config.Schema); err != nil {
+		warnings, err := validate(config.Schema)
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(w, "warning: %s\n", warning)
+		}
+		if err != nil {
 			return err
 		}
 
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"io"
+
+	"github.com/dotvezz/lime"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
+)
+
+// newJSONSchemaPrinter returns a command which prints the JSON Schema of yoyo.yml, or of included schema files with the
+// `include` argument
+func newJSONSchemaPrinter() lime.Func {
+	return func(args []string, w io.Writer) error {
+		if len(args) > 0 && args[0] == "include" {
+			return yoyo.WriteJSONSchema(w, yoyo.IncludeJSONSchema())
+		}
+		return yoyo.WriteJSONSchema(w, yoyo.JSONSchema())
+	}
+}
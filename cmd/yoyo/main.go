@@ -13,6 +13,7 @@ import (
 	"github.com/dotvezz/lime/options"
 	"github.com/yoyo-project/yoyo/cmd/yoyo/generate"
 	"github.com/yoyo-project/yoyo/cmd/yoyo/usecases"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
 )
 
 func main() {
@@ -39,9 +40,25 @@ func main() {
 			Keyword: "reverse",
 			Func:    newReverser(ucs.ReadDatabase),
 		},
+		lime.Command{
+			Keyword: "schema",
+			Commands: []lime.Command{
+				{
+					Keyword: "json-schema",
+					Func:    newJSONSchemaPrinter(),
+				},
+			},
+		},
+		lime.Command{
+			Keyword: "validate",
+			Func:    newValidator(yoyo.LoadConfig, validation.ValidateDatabase),
+		},
 	)
-	err := c.Run()
+	// lime mistakes a single argument from os.Args for no command, so single keyword commands like validate are passed
+	// explicitly
+	err := c.Run(os.Args[1:]...)
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
 	}
 }
@@ -39,7 +39,7 @@ func Init() (ucs UseCases) {
 	ucs.LoadMigrationGenerator = migration.InitGeneratorLoader(ucs.LoadReverseAdapter, ucs.LoadMigrationAdapter, migration.NewGenerator)
 
 	ucs.LoadRepositoryAdapter = repository.LoadAdapter
-	ucs.LoadRepositoryGenerator = repository.InitGeneratorLoader(repository.NewGenerator, ucs.LoadRepositoryAdapter, file.FindPackagePath)
+	ucs.LoadRepositoryGenerator = repository.InitGeneratorLoader(repository.NewGenerator, ucs.LoadRepositoryAdapter, file.FindPackagePath, file.CreateWithDirs)
 
 	return ucs
 }
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dotvezz/lime"
+	"github.com/yoyo-project/yoyo/cmd/yoyo/generate"
+	"github.com/yoyo-project/yoyo/internal/schema"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
+)
+
+// newValidator returns a command which checks the schema and whether its dialect supports it, without connecting to
+// a database. Every problem found is returned, one per line, including the dialect's problems with the parts of an
+// invalid schema which could be read.
+func newValidator(loadConfig func() (yoyo.Config, error), validate generate.DatabaseValidator) lime.Func {
+	return func(args []string, w io.Writer) error {
+		config, schemaErr := loadConfig()
+		var es schema.ValidationErrors
+		if schemaErr != nil && !errors.As(schemaErr, &es) {
+			return fmt.Errorf("unable to load config: %w", schemaErr)
+		}
+
+		warnings, err := validate(config.Schema)
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(w, "warning: %s\n", warning)
+		}
+		if err = errors.Join(schemaErr, err); err != nil {
+			return fmt.Errorf("schema is invalid:\n%w", err)
+		}
+
+		_, _ = fmt.Fprintln(w, "schema is valid")
+		return nil
+	}
+}
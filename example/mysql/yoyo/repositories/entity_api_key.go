@@ -0,0 +1,136 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	
+	yoyouuid "github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/uuid"
+)
+
+type ApiKey struct { 
+	Id yoyouuid.UUID `json:"id" db:"id"`
+	Label string `json:"label" db:"label"`
+	ReplacedBy yoyouuid.NullUUID `json:"replaced_by" db:"replaced_by"`
+
+	// Reference Fields
+	PersonId uint32 `json:"person_id" db:"fk_person_id"`
+
+	// For tracking persistence
+	persisted *ApiKey
+}
+
+// HasChanged is intended to help understand if the entity's current values are represented in the database.
+// A few examples are provided below:
+//   - For a ApiKey which was created outside of a ApiKeyRepository, HasChanged will return false
+//     even if it was used as the input for ApiKeyRepository.Save.
+//   - For an ApiKey returned from ApiKeyRepository.Save, HasChanged will return true. However,
+//     changing the value of any field on that ApiKey will cause its value to diverge from the last-known
+//     persisted value. In that case, its HasChanged method will return false.
+//
+// The method only tracks changes made to the ApiKey, and does NOT track changes on the database itself.
+func (e *ApiKey) HasChanged() bool {
+	return e.persisted != nil &&
+		e.Id == e.persisted.Id &&
+		e.Label == e.persisted.Label &&
+		e.ReplacedBy == e.persisted.ReplacedBy &&
+		e.PersonId == e.persisted.PersonId
+}
+
+// afterFetch calls the AfterFetch method of the ApiKey if it implements AfterFetcher, followed by the given hook
+func (e *ApiKey) afterFetch(hook func(*ApiKey) error) error {
+	if f, ok := interface{}(e).(AfterFetcher); ok {
+		if err := f.AfterFetch(); err != nil {
+			return err
+		}
+	}
+	if hook != nil {
+		return hook(e)
+	}
+	return nil
+}
+
+func (e *ApiKey) CopyValuesFrom(input ApiKey) {
+    e.Id = input.Id
+    e.Label = input.Label
+    e.ReplacedBy = input.ReplacedBy
+    e.PersonId = input.PersonId
+}
+
+type ApiKeys struct {
+	// If we're not in a transaction, then ApiKey saves memory by wrapping a *sql.Rows to scan from the connection
+	// buffer on-demand.
+	// This uses less application memory but more connections to the DBMS.
+	rs *sql.Rows
+
+	// If we are in a transaction, then ApiKey reads the entire result set to memory to clear the buffer and allow
+	// other queries to run on the goroutine.
+	// This uses more application memory but fewer connections to the DBMS.
+	i  int
+	es []ApiKey
+
+	// afterFetch is the registered AfterFetch hook, called for each scanned ApiKey when not in a transaction
+	afterFetch func(*ApiKey) error
+
+	// done observes the query once all of its rows have been read when not in a transaction
+	done func(rowsAffected int64, err error)
+}
+
+// Next is intended to feel familiar to the Next method of sql.Rows. In fact, when not in a transaction,
+// it uses the sql.Rows Next method internally.
+func (es *ApiKeys) Next() bool {
+	if es.rs != nil {
+		// not in a transaction
+		if es.rs.Next() {
+			return true
+		}
+		if es.done != nil {
+			es.done(0, es.rs.Err())
+			es.done = nil
+		}
+		return false
+	} else {
+		// in a transaction
+		es.i++
+		return es.i < len(es.es)
+	}
+}
+
+// Scan is intended to feel familiar to the Scan method of sql.Rows. In fact, when not in a transaction,
+// it uses the sql.Rows Scan method internally.
+func (es *ApiKeys) Scan(e *ApiKey) (err error) {
+	if e == nil {
+		return fmt.Errorf("in ApiKeys.Scan: passed a nil entity")
+	}
+
+	// scan from the rows if NOT IN a transaction
+	if es.rs != nil {
+		return es.scan(e)
+	}
+
+	// load an entity from memory if IN a transaction
+	return es.load(e)
+}
+
+// scan wraps the Scan method of sql.Rows, only used when not in a connection to minimize memory usage
+func (es *ApiKeys) scan(e *ApiKey) (err error) {
+	err = es.rs.Scan(&e.Id, &e.Label, &e.ReplacedBy, &e.PersonId)
+	if err != nil {
+		return err
+	}
+	persisted := *e
+	e.persisted = &persisted
+	return e.afterFetch(es.afterFetch)
+}
+
+// load pulls a result from memory, only used if in a transaction to avoid connection contention
+func (es *ApiKeys) load(e *ApiKey) (err error) {
+	if es.i >= len(es.es) || es.i < 0 {
+		return fmt.Errorf("in ApiKeys.point: out of range")
+	}
+	*e = es.es[es.i]
+	persisted := *e
+	e.persisted = &persisted
+	return nil
+}
\ No newline at end of file
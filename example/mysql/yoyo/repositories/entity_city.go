@@ -6,11 +6,21 @@ import (
 	"database/sql"
 	"fmt"
 	
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/geo"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/nullable"
+	"time"
 )
 
+// A city where people live
 type City struct { 
-	Id uint32
-	Name string
+	Id uint32 `json:"id" db:"id"`
+	// The city's name, as its residents write it
+	Name string `json:"name" db:"name"`
+	Metadata nullable.JSON `json:"metadata" db:"metadata"`
+	Location geo.Shape[geo.Point] `json:"location" db:"location"`
+	Version uint32 `json:"version" db:"version"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 	// For tracking persistence
 	persisted *City
 }
@@ -27,12 +37,35 @@ type City struct {
 func (e *City) HasChanged() bool {
 	return e.persisted != nil &&
 		e.Id == e.persisted.Id &&
-		e.Name == e.persisted.Name
+		e.Name == e.persisted.Name &&
+		equal(e.Metadata, e.persisted.Metadata) &&
+		e.Location.Equal(e.persisted.Location) &&
+		e.Version == e.persisted.Version &&
+		e.CreatedAt == e.persisted.CreatedAt &&
+		e.UpdatedAt == e.persisted.UpdatedAt
+}
+
+// afterFetch calls the AfterFetch method of the City if it implements AfterFetcher, followed by the given hook
+func (e *City) afterFetch(hook func(*City) error) error {
+	if f, ok := interface{}(e).(AfterFetcher); ok {
+		if err := f.AfterFetch(); err != nil {
+			return err
+		}
+	}
+	if hook != nil {
+		return hook(e)
+	}
+	return nil
 }
 
 func (e *City) CopyValuesFrom(input City) {
     e.Id = input.Id
     e.Name = input.Name
+    e.Metadata = input.Metadata
+    e.Location = input.Location
+    e.Version = input.Version
+    e.CreatedAt = input.CreatedAt
+    e.UpdatedAt = input.UpdatedAt
 }
 
 type Citys struct {
@@ -46,6 +79,12 @@ type Citys struct {
 	// This uses more application memory but fewer connections to the DBMS.
 	i  int
 	es []City
+
+	// afterFetch is the registered AfterFetch hook, called for each scanned City when not in a transaction
+	afterFetch func(*City) error
+
+	// done observes the query once all of its rows have been read when not in a transaction
+	done func(rowsAffected int64, err error)
 }
 
 // Next is intended to feel familiar to the Next method of sql.Rows. In fact, when not in a transaction,
@@ -53,7 +92,14 @@ type Citys struct {
 func (es *Citys) Next() bool {
 	if es.rs != nil {
 		// not in a transaction
-		return es.rs.Next()
+		if es.rs.Next() {
+			return true
+		}
+		if es.done != nil {
+			es.done(0, es.rs.Err())
+			es.done = nil
+		}
+		return false
 	} else {
 		// in a transaction
 		es.i++
@@ -79,10 +125,13 @@ func (es *Citys) Scan(e *City) (err error) {
 
 // scan wraps the Scan method of sql.Rows, only used when not in a connection to minimize memory usage
 func (es *Citys) scan(e *City) (err error) {
-	err = es.rs.Scan(&e.Id, &e.Name)
+	err = es.rs.Scan(&e.Id, &e.Name, &e.Metadata, &e.Location, &e.Version, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return err
+	}
 	persisted := *e
 	e.persisted = &persisted
-	return err
+	return e.afterFetch(es.afterFetch)
 }
 
 // load pulls a result from memory, only used if in a transaction to avoid connection contention
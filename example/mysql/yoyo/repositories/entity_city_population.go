@@ -0,0 +1,130 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	
+)
+
+// The number of people living in each city
+type CityPopulation struct { 
+	CityId uint32 `json:"city_id" db:"city_id"`
+	CityName string `json:"city_name" db:"city_name"`
+	Population int64 `json:"population" db:"population"`
+	// For tracking persistence
+	persisted *CityPopulation
+}
+
+// HasChanged is intended to help understand if the entity's current values are represented in the database.
+// A few examples are provided below:
+//   - For a CityPopulation which was created outside of a CityPopulationRepository, HasChanged will return false
+//     even if it was used as the input for CityPopulationRepository.Save.
+//   - For an CityPopulation returned from CityPopulationRepository.Save, HasChanged will return true. However,
+//     changing the value of any field on that CityPopulation will cause its value to diverge from the last-known
+//     persisted value. In that case, its HasChanged method will return false.
+//
+// The method only tracks changes made to the CityPopulation, and does NOT track changes on the database itself.
+func (e *CityPopulation) HasChanged() bool {
+	return e.persisted != nil &&
+		e.CityId == e.persisted.CityId &&
+		e.CityName == e.persisted.CityName &&
+		e.Population == e.persisted.Population
+}
+
+// afterFetch calls the AfterFetch method of the CityPopulation if it implements AfterFetcher, followed by the given hook
+func (e *CityPopulation) afterFetch(hook func(*CityPopulation) error) error {
+	if f, ok := interface{}(e).(AfterFetcher); ok {
+		if err := f.AfterFetch(); err != nil {
+			return err
+		}
+	}
+	if hook != nil {
+		return hook(e)
+	}
+	return nil
+}
+
+func (e *CityPopulation) CopyValuesFrom(input CityPopulation) {
+    e.CityId = input.CityId
+    e.CityName = input.CityName
+    e.Population = input.Population
+}
+
+type CityPopulations struct {
+	// If we're not in a transaction, then CityPopulation saves memory by wrapping a *sql.Rows to scan from the connection
+	// buffer on-demand.
+	// This uses less application memory but more connections to the DBMS.
+	rs *sql.Rows
+
+	// If we are in a transaction, then CityPopulation reads the entire result set to memory to clear the buffer and allow
+	// other queries to run on the goroutine.
+	// This uses more application memory but fewer connections to the DBMS.
+	i  int
+	es []CityPopulation
+
+	// afterFetch is the registered AfterFetch hook, called for each scanned CityPopulation when not in a transaction
+	afterFetch func(*CityPopulation) error
+
+	// done observes the query once all of its rows have been read when not in a transaction
+	done func(rowsAffected int64, err error)
+}
+
+// Next is intended to feel familiar to the Next method of sql.Rows. In fact, when not in a transaction,
+// it uses the sql.Rows Next method internally.
+func (es *CityPopulations) Next() bool {
+	if es.rs != nil {
+		// not in a transaction
+		if es.rs.Next() {
+			return true
+		}
+		if es.done != nil {
+			es.done(0, es.rs.Err())
+			es.done = nil
+		}
+		return false
+	} else {
+		// in a transaction
+		es.i++
+		return es.i < len(es.es)
+	}
+}
+
+// Scan is intended to feel familiar to the Scan method of sql.Rows. In fact, when not in a transaction,
+// it uses the sql.Rows Scan method internally.
+func (es *CityPopulations) Scan(e *CityPopulation) (err error) {
+	if e == nil {
+		return fmt.Errorf("in CityPopulations.Scan: passed a nil entity")
+	}
+
+	// scan from the rows if NOT IN a transaction
+	if es.rs != nil {
+		return es.scan(e)
+	}
+
+	// load an entity from memory if IN a transaction
+	return es.load(e)
+}
+
+// scan wraps the Scan method of sql.Rows, only used when not in a connection to minimize memory usage
+func (es *CityPopulations) scan(e *CityPopulation) (err error) {
+	err = es.rs.Scan(&e.CityId, &e.CityName, &e.Population)
+	if err != nil {
+		return err
+	}
+	persisted := *e
+	e.persisted = &persisted
+	return e.afterFetch(es.afterFetch)
+}
+
+// load pulls a result from memory, only used if in a transaction to avoid connection contention
+func (es *CityPopulations) load(e *CityPopulation) (err error) {
+	if es.i >= len(es.es) || es.i < 0 {
+		return fmt.Errorf("in CityPopulations.point: out of range")
+	}
+	*e = es.es[es.i]
+	persisted := *e
+	e.persisted = &persisted
+	return nil
+}
\ No newline at end of file
@@ -9,8 +9,8 @@ import (
 )
 
 type NoPkTable struct { 
-	Col int32
-	Col2 int32
+	Col int32 `json:"col" db:"col"`
+	Col2 int32 `json:"col2" db:"col2"`
 	// For tracking persistence
 	persisted *NoPkTable
 }
@@ -30,6 +30,19 @@ func (e *NoPkTable) HasChanged() bool {
 		e.Col2 == e.persisted.Col2
 }
 
+// afterFetch calls the AfterFetch method of the NoPkTable if it implements AfterFetcher, followed by the given hook
+func (e *NoPkTable) afterFetch(hook func(*NoPkTable) error) error {
+	if f, ok := interface{}(e).(AfterFetcher); ok {
+		if err := f.AfterFetch(); err != nil {
+			return err
+		}
+	}
+	if hook != nil {
+		return hook(e)
+	}
+	return nil
+}
+
 func (e *NoPkTable) CopyValuesFrom(input NoPkTable) {
     e.Col = input.Col
     e.Col2 = input.Col2
@@ -46,6 +59,12 @@ type NoPkTables struct {
 	// This uses more application memory but fewer connections to the DBMS.
 	i  int
 	es []NoPkTable
+
+	// afterFetch is the registered AfterFetch hook, called for each scanned NoPkTable when not in a transaction
+	afterFetch func(*NoPkTable) error
+
+	// done observes the query once all of its rows have been read when not in a transaction
+	done func(rowsAffected int64, err error)
 }
 
 // Next is intended to feel familiar to the Next method of sql.Rows. In fact, when not in a transaction,
@@ -53,7 +72,14 @@ type NoPkTables struct {
 func (es *NoPkTables) Next() bool {
 	if es.rs != nil {
 		// not in a transaction
-		return es.rs.Next()
+		if es.rs.Next() {
+			return true
+		}
+		if es.done != nil {
+			es.done(0, es.rs.Err())
+			es.done = nil
+		}
+		return false
 	} else {
 		// in a transaction
 		es.i++
@@ -80,9 +106,12 @@ func (es *NoPkTables) Scan(e *NoPkTable) (err error) {
 // scan wraps the Scan method of sql.Rows, only used when not in a connection to minimize memory usage
 func (es *NoPkTables) scan(e *NoPkTable) (err error) {
 	err = es.rs.Scan(&e.Col, &e.Col2)
+	if err != nil {
+		return err
+	}
 	persisted := *e
 	e.persisted = &persisted
-	return err
+	return e.afterFetch(es.afterFetch)
 }
 
 // load pulls a result from memory, only used if in a transaction to avoid connection contention
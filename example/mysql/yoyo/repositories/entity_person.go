@@ -6,19 +6,24 @@ import (
 	"database/sql"
 	"fmt"
 	
+	"encoding/json"
 	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/nullable"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/person"
 )
 
 type Person struct { 
-	Id uint32
-	SomeBinary []byte
-	Name string
-	Nickname string
-	FavoriteColor nullable.String
-	Age float64
+	Id uint32 `json:"id" db:"id"`
+	SomeBinary []byte `json:"-" db:"someBinary"`
+	Name string `json:"name" db:"name"`
+	Nickname string `json:"alias" db:"nickname"`
+	FavoriteColor person.NullFavoriteColorEnum `json:"favorite_color" db:"favorite_color"`
+	Age float64 `json:"age" db:"age"`
+	Preferences json.RawMessage `json:"preferences" db:"preferences"`
+	DisplayName string `json:"display_name" db:"display_name"`
+	DeletedAt nullable.Time `json:"deleted_at" db:"deleted_at"`
 
 	// Reference Fields
-	CityId uint32
+	CityId uint32 `json:"city_id" db:"fk_city_id"`
 
 	// For tracking persistence
 	persisted *Person
@@ -41,9 +46,25 @@ func (e *Person) HasChanged() bool {
 		e.Nickname == e.persisted.Nickname &&
 		e.FavoriteColor == e.persisted.FavoriteColor &&
 		e.Age == e.persisted.Age &&
+		equal(e.Preferences, e.persisted.Preferences) &&
+		e.DisplayName == e.persisted.DisplayName &&
+		e.DeletedAt == e.persisted.DeletedAt &&
 		e.CityId == e.persisted.CityId
 }
 
+// afterFetch calls the AfterFetch method of the Person if it implements AfterFetcher, followed by the given hook
+func (e *Person) afterFetch(hook func(*Person) error) error {
+	if f, ok := interface{}(e).(AfterFetcher); ok {
+		if err := f.AfterFetch(); err != nil {
+			return err
+		}
+	}
+	if hook != nil {
+		return hook(e)
+	}
+	return nil
+}
+
 func (e *Person) CopyValuesFrom(input Person) {
     e.Id = input.Id
     e.SomeBinary = input.SomeBinary
@@ -51,6 +72,9 @@ func (e *Person) CopyValuesFrom(input Person) {
     e.Nickname = input.Nickname
     e.FavoriteColor = input.FavoriteColor
     e.Age = input.Age
+    e.Preferences = input.Preferences
+    e.DisplayName = input.DisplayName
+    e.DeletedAt = input.DeletedAt
     e.CityId = input.CityId
 }
 
@@ -65,6 +89,12 @@ type Persons struct {
 	// This uses more application memory but fewer connections to the DBMS.
 	i  int
 	es []Person
+
+	// afterFetch is the registered AfterFetch hook, called for each scanned Person when not in a transaction
+	afterFetch func(*Person) error
+
+	// done observes the query once all of its rows have been read when not in a transaction
+	done func(rowsAffected int64, err error)
 }
 
 // Next is intended to feel familiar to the Next method of sql.Rows. In fact, when not in a transaction,
@@ -72,7 +102,14 @@ type Persons struct {
 func (es *Persons) Next() bool {
 	if es.rs != nil {
 		// not in a transaction
-		return es.rs.Next()
+		if es.rs.Next() {
+			return true
+		}
+		if es.done != nil {
+			es.done(0, es.rs.Err())
+			es.done = nil
+		}
+		return false
 	} else {
 		// in a transaction
 		es.i++
@@ -98,10 +135,13 @@ func (es *Persons) Scan(e *Person) (err error) {
 
 // scan wraps the Scan method of sql.Rows, only used when not in a connection to minimize memory usage
 func (es *Persons) scan(e *Person) (err error) {
-	err = es.rs.Scan(&e.Id, &e.SomeBinary, &e.Name, &e.Nickname, &e.FavoriteColor, &e.Age, &e.CityId)
+	err = es.rs.Scan(&e.Id, &e.SomeBinary, &e.Name, &e.Nickname, &e.FavoriteColor, &e.Age, &e.Preferences, &e.DisplayName, &e.DeletedAt, &e.CityId)
+	if err != nil {
+		return err
+	}
 	persisted := *e
 	e.persisted = &persisted
-	return err
+	return e.afterFetch(es.afterFetch)
 }
 
 // load pulls a result from memory, only used if in a transaction to avoid connection contention
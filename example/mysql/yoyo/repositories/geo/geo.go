@@ -0,0 +1,400 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package geo
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Geometry is one of the geometry types of this package. They are converted to and from the well-known binary (WKB)
+// format used by MySQL.
+type Geometry interface {
+	appendWKB(b []byte) []byte
+}
+
+// Point is a single location. An empty point has NaN coordinates.
+type Point struct {
+	X, Y float64
+}
+
+// LineString is a sequence of points connected by straight lines
+type LineString []Point
+
+// Polygon is a sequence of closed rings. The first ring is the outer boundary and any others are holes in it.
+type Polygon []LineString
+
+// MultiPoint is a collection of points
+type MultiPoint []Point
+
+// MultiLineString is a collection of line strings
+type MultiLineString []LineString
+
+// MultiPolygon is a collection of polygons
+type MultiPolygon []Polygon
+
+// Collection is a collection of geometries of any type, SQL's GEOMETRYCOLLECTION
+type Collection []Geometry
+
+// These are the geometry types of WKB
+const (
+	wkbPoint uint32 = iota + 1
+	wkbLineString
+	wkbPolygon
+	wkbMultiPoint
+	wkbMultiLineString
+	wkbMultiPolygon
+	wkbCollection
+)
+
+// These are the flags of the extended WKB (EWKB) of PostGIS, which are set in the geometry type
+const (
+	ewkbZ    uint32 = 0x80000000
+	ewkbM    uint32 = 0x40000000
+	ewkbSRID uint32 = 0x20000000
+)
+
+// Shape is a geometry of type T with its spatial reference system identifier (SRID). The zero Shape is null.
+type Shape[T Geometry] struct {
+	Geometry T
+	SRID     uint32
+	Valid    bool
+}
+
+// New returns a valid Shape of the given geometry and SRID
+func New[T Geometry](g T, srid uint32) Shape[T] {
+	return Shape[T]{Geometry: g, SRID: srid, Valid: true}
+}
+
+// Equal returns true if both shapes are null, or if both have the same SRID and geometry
+func (s Shape[T]) Equal(o Shape[T]) bool {
+	if !s.Valid || !o.Valid {
+		return s.Valid == o.Valid
+	}
+	if s.SRID != o.SRID {
+		return false
+	}
+	b1, err1 := wkb(s.Geometry)
+	b2, err2 := wkb(o.Geometry)
+	return err1 == nil && err2 == nil && bytes.Equal(b1, b2)
+}
+
+// Scan implements sql.Scanner
+func (s *Shape[T]) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*s = Shape[T]{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported Scan, storing %T into %T", src, s)
+	}
+
+	// MySQL stores geometries as a little-endian SRID followed by WKB
+	if len(b) < 4 {
+		return fmt.Errorf("unable to scan geometry: too short")
+	}
+
+	srid := binary.LittleEndian.Uint32(b)
+	r := reader{b: b[4:]}
+	g, _, err := r.geometry()
+	if err != nil {
+		return fmt.Errorf("unable to scan geometry: %w", err)
+	}
+
+	t, ok := g.(T)
+	if !ok {
+		return fmt.Errorf("unable to scan geometry: %T is not a %T", g, s.Geometry)
+	}
+	*s = Shape[T]{Geometry: t, SRID: srid, Valid: true}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (s Shape[T]) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+
+	b, err := wkb(s.Geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(binary.LittleEndian.AppendUint32(nil, s.SRID), b...), nil
+}
+
+// wkb returns the little-endian WKB of g, or an error if g or a geometry within it is a nil Geometry
+func wkb(g Geometry) ([]byte, error) {
+	if err := notNil(g); err != nil {
+		return nil, err
+	}
+	return g.appendWKB(nil), nil
+}
+
+// notNil returns an error if g is a nil Geometry, or a Collection containing one at any depth
+func notNil(g Geometry) error {
+	if g == nil {
+		return fmt.Errorf("geometry is nil")
+	}
+	if c, ok := g.(Collection); ok {
+		for i, m := range c {
+			if err := notNil(m); err != nil {
+				return fmt.Errorf("geometry %d of collection: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (p Point) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbPoint)
+	return appendPoint(b, p)
+}
+
+func (l LineString) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbLineString)
+	return appendPoints(b, l)
+}
+
+func (p Polygon) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbPolygon)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(p)))
+	for _, ring := range p {
+		b = appendPoints(b, ring)
+	}
+	return b
+}
+
+func (m MultiPoint) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbMultiPoint)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(m)))
+	for _, p := range m {
+		b = p.appendWKB(b)
+	}
+	return b
+}
+
+func (m MultiLineString) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbMultiLineString)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(m)))
+	for _, l := range m {
+		b = l.appendWKB(b)
+	}
+	return b
+}
+
+func (m MultiPolygon) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbMultiPolygon)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(m)))
+	for _, p := range m {
+		b = p.appendWKB(b)
+	}
+	return b
+}
+
+func (c Collection) appendWKB(b []byte) []byte {
+	b = appendHeader(b, wkbCollection)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(c)))
+	for _, g := range c {
+		b = g.appendWKB(b)
+	}
+	return b
+}
+
+// appendHeader appends the little-endian byte order mark and the geometry type
+func appendHeader(b []byte, typ uint32) []byte {
+	return binary.LittleEndian.AppendUint32(append(b, 1), typ)
+}
+
+func appendPoint(b []byte, p Point) []byte {
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+}
+
+func appendPoints(b []byte, ps []Point) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(ps)))
+	for _, p := range ps {
+		b = appendPoint(b, p)
+	}
+	return b
+}
+
+// reader decodes WKB and EWKB
+type reader struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+// geometry reads a whole geometry, including any nested geometries, and returns it with its EWKB SRID. Each geometry
+// has its own byte order, so the byte order of the enclosing geometry is restored afterwards.
+func (r *reader) geometry() (g Geometry, srid uint32, err error) {
+	if len(r.b) < 5 {
+		return nil, 0, fmt.Errorf("unexpected end of WKB")
+	}
+	defer func(order binary.ByteOrder) { r.order = order }(r.order)
+	switch r.b[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, 0, fmt.Errorf("invalid WKB byte order %d", r.b[0])
+	}
+	r.b = r.b[1:]
+
+	typ, err := r.uint32()
+	if err != nil {
+		return nil, 0, err
+	}
+	if typ&ewkbSRID != 0 {
+		if srid, err = r.uint32(); err != nil {
+			return nil, 0, err
+		}
+		typ &^= ewkbSRID
+	}
+	if typ&(ewkbZ|ewkbM) != 0 || typ > wkbCollection {
+		return nil, 0, fmt.Errorf("unsupported WKB geometry type %d, only 2D geometries are supported", typ)
+	}
+
+	switch typ {
+	case wkbPoint:
+		g, err = r.point()
+	case wkbLineString:
+		var ps []Point
+		ps, err = r.points()
+		g = LineString(ps)
+	case wkbPolygon:
+		var p Polygon
+		p, err = r.polygon()
+		g = p
+	case wkbMultiPoint:
+		var m MultiPoint
+		err = r.each(func(g Geometry) (ok bool) {
+			p, ok := g.(Point)
+			m = append(m, p)
+			return ok
+		})
+		g = m
+	case wkbMultiLineString:
+		var m MultiLineString
+		err = r.each(func(g Geometry) (ok bool) {
+			l, ok := g.(LineString)
+			m = append(m, l)
+			return ok
+		})
+		g = m
+	case wkbMultiPolygon:
+		var m MultiPolygon
+		err = r.each(func(g Geometry) (ok bool) {
+			p, ok := g.(Polygon)
+			m = append(m, p)
+			return ok
+		})
+		g = m
+	case wkbCollection:
+		var c Collection
+		err = r.each(func(g Geometry) bool {
+			c = append(c, g)
+			return true
+		})
+		g = c
+	default:
+		err = fmt.Errorf("unsupported WKB geometry type %d", typ)
+	}
+
+	return g, srid, err
+}
+
+// each reads the count of a multi-geometry or collection, and passes each of its geometries to add, which returns
+// false if the geometry has the wrong type
+func (r *reader) each(add func(Geometry) bool) error {
+	n, err := r.count(5)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		g, _, err := r.geometry()
+		if err != nil {
+			return err
+		}
+		if !add(g) {
+			return fmt.Errorf("unexpected %T in WKB", g)
+		}
+	}
+	return nil
+}
+
+func (r *reader) polygon() (Polygon, error) {
+	n, err := r.count(4)
+	if err != nil {
+		return nil, err
+	}
+	p := make(Polygon, n)
+	for i := range p {
+		if p[i], err = r.points(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (r *reader) points() ([]Point, error) {
+	n, err := r.count(16)
+	if err != nil {
+		return nil, err
+	}
+	ps := make([]Point, n)
+	for i := range ps {
+		if ps[i], err = r.point(); err != nil {
+			return nil, err
+		}
+	}
+	return ps, nil
+}
+
+func (r *reader) point() (p Point, err error) {
+	if p.X, err = r.float64(); err != nil {
+		return p, err
+	}
+	p.Y, err = r.float64()
+	return p, err
+}
+
+// count reads the number of items which follow, and makes sure the WKB is long enough for that many items of at least
+// minSize bytes each
+func (r *reader) count(minSize int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minSize) > uint64(len(r.b)) {
+		return 0, fmt.Errorf("unexpected end of WKB")
+	}
+	return int(n), nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, fmt.Errorf("unexpected end of WKB")
+	}
+	v := r.order.Uint32(r.b)
+	r.b = r.b[4:]
+	return v, nil
+}
+
+func (r *reader) float64() (float64, error) {
+	if len(r.b) < 8 {
+		return 0, fmt.Errorf("unexpected end of WKB")
+	}
+	v := math.Float64frombits(r.order.Uint64(r.b))
+	r.b = r.b[8:]
+	return v, nil
+}
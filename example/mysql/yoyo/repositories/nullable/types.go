@@ -3,7 +3,11 @@
 package nullable
 
 import (
+	"bytes"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -23,6 +27,20 @@ func (n *Time) SetNull() {
 	n.Time = time.Time{}
 }
 
+func (n Time) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Time)
+}
+
+func (n *Time) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Time)
+	n.Valid = valid
+	return err
+}
+
+func (n Time) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Time)
+}
+
 type Int16 struct {
 	sql.NullInt16
 }
@@ -37,6 +55,20 @@ func (n *Int16) SetNull() {
 	n.Int16 = 0
 }
 
+func (n Int16) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Int16)
+}
+
+func (n *Int16) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Int16)
+	n.Valid = valid
+	return err
+}
+
+func (n Int16) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Int16)
+}
+
 type Int32 struct {
 	sql.NullInt32
 }
@@ -51,6 +83,20 @@ func (n *Int32) SetNull() {
 	n.Int32 = 0
 }
 
+func (n Int32) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Int32)
+}
+
+func (n *Int32) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Int32)
+	n.Valid = valid
+	return err
+}
+
+func (n Int32) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Int32)
+}
+
 type Int64 struct {
 	sql.NullInt64
 }
@@ -65,6 +111,20 @@ func (n *Int64) SetNull() {
 	n.Int64 = 0
 }
 
+func (n Int64) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Int64)
+}
+
+func (n *Int64) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Int64)
+	n.Valid = valid
+	return err
+}
+
+func (n Int64) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Int64)
+}
+
 type Bool struct {
 	sql.NullBool
 }
@@ -79,6 +139,20 @@ func (n *Bool) SetNull() {
 	n.Bool = false
 }
 
+func (n Bool) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Bool)
+}
+
+func (n *Bool) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Bool)
+	n.Valid = valid
+	return err
+}
+
+func (n Bool) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Bool)
+}
+
 type Byte struct {
 	sql.NullByte
 }
@@ -93,6 +167,20 @@ func (n *Byte) SetNull() {
 	n.Byte = byte(0)
 }
 
+func (n Byte) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Byte)
+}
+
+func (n *Byte) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Byte)
+	n.Valid = valid
+	return err
+}
+
+func (n Byte) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Byte)
+}
+
 type String struct {
 	sql.NullString
 }
@@ -107,6 +195,20 @@ func (n *String) SetNull() {
 	n.String = ""
 }
 
+func (n String) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.String)
+}
+
+func (n *String) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.String)
+	n.Valid = valid
+	return err
+}
+
+func (n String) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.String)
+}
+
 type Float64 struct {
 	sql.NullFloat64
 }
@@ -121,4 +223,194 @@ func (n *Float64) SetNull() {
 	n.Float64 = 0
 }
 
+func (n Float64) MarshalJSON() ([]byte, error) {
+	return marshalJSON(n.Valid, n.Float64)
+}
+
+func (n *Float64) UnmarshalJSON(data []byte) error {
+	valid, err := unmarshalJSON(data, &n.Float64)
+	n.Valid = valid
+	return err
+}
+
+func (n Float64) MarshalYAML() (interface{}, error) {
+	return marshalYAML(n.Valid, n.Float64)
+}
+
+// JSON is a raw JSON document which may be null. Unlike the other types, a null JSON is simply a nil JSON.
+type JSON json.RawMessage
+
+func (n *JSON) Set(val json.RawMessage) {
+	*n = JSON(val)
+}
+
+func (n *JSON) SetNull() {
+	*n = nil
+}
+
+// Scan implements sql.Scanner
+func (n *JSON) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*n = nil
+	case []byte:
+		*n = bytes.Clone(s)
+	case string:
+		*n = JSON(s)
+	default:
+		return fmt.Errorf("unsupported Scan, storing %T into %T", src, n)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n JSON) Value() (driver.Value, error) {
+	if n == nil {
+		return nil, nil
+	}
+	return []byte(n), nil
+}
+
+func (n JSON) MarshalJSON() ([]byte, error) {
+	if n == nil {
+		return []byte("null"), nil
+	}
+	return n, nil
+}
+
+func (n *JSON) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = nil
+		return nil
+	}
+	*n = bytes.Clone(data)
+	return nil
+}
+
+func (n JSON) MarshalYAML() (interface{}, error) {
+	if n == nil {
+		return nil, nil
+	}
+	var v interface{}
+	err := json.Unmarshal(n, &v)
+	return v, err
+}
+
 // Later, export new types as needed...
+
+// marshalJSON marshals a null value as JSON null instead of the wrapped sql.Null* struct
+func marshalJSON(valid bool, v interface{}) ([]byte, error) {
+	if !valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalJSON unmarshals JSON null as a null value, returning false for valid
+func unmarshalJSON(data []byte, v interface{}) (valid bool, err error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// marshalYAML marshals a null value as YAML null instead of the wrapped sql.Null* struct
+func marshalYAML(valid bool, v interface{}) (interface{}, error) {
+	if !valid {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// JSONOf holds a T stored in a JSON column. T is marshalled to JSON when it is written to the database, and
+// unmarshalled from JSON when it is scanned. The zero JSONOf is null.
+type JSONOf[T any] struct {
+	V     T
+	Valid bool
+}
+
+// Set sets the value and marks it as not null
+func (n *JSONOf[T]) Set(val T) {
+	n.Valid = true
+	n.V = val
+}
+
+// SetNull sets the value to the zero value of T and marks it as null
+func (n *JSONOf[T]) SetNull() {
+	var zero T
+	n.Valid = false
+	n.V = zero
+}
+
+// Equal returns true if both values are null, or if both marshal to the same JSON
+func (n JSONOf[T]) Equal(o JSONOf[T]) bool {
+	if n.Valid != o.Valid {
+		return false
+	}
+	if !n.Valid {
+		return true
+	}
+	b1, err1 := json.Marshal(n.V)
+	b2, err2 := json.Marshal(o.V)
+	return err1 == nil && err2 == nil && bytes.Equal(b1, b2)
+}
+
+// Scan implements sql.Scanner
+func (n *JSONOf[T]) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		n.SetNull()
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("unsupported Scan, storing %T into %T", src, n)
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("unable to unmarshal %T: %w", v, err)
+	}
+	n.Set(v)
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n JSONOf[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return json.Marshal(n.V)
+}
+
+// MarshalJSON implements json.Marshaler. A null value is marshalled as JSON null.
+func (n JSONOf[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null is unmarshalled as a null value.
+func (n *JSONOf[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler. A null value is marshalled as YAML null.
+func (n JSONOf[T]) MarshalYAML() (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.V, nil
+}
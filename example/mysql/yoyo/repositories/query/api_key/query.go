@@ -0,0 +1,390 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package api_key
+
+import (
+	"fmt"
+	yoyouuid "github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/uuid"
+
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query"
+)
+
+type Query struct {
+	n query.Node
+}
+
+func (q Query) SQL() (string, []interface{}) {
+	cs, ps := q.n.SQL()
+	if cs == "" {
+		return "", ps
+	}
+	return fmt.Sprintf("WHERE %s", cs), ps
+}
+
+func (q Query) Or(q2 Query) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, q2.n},
+		Operator: query.Or,
+	}
+	return q
+}
+
+func (q Query) Id(val yoyouuid.UUID) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, Id(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) IdNot(val yoyouuid.UUID) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, IdNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) Label(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, Label(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelContains(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelContains(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelContainsNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelContainsNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelStartsWith(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelStartsWith(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelStartsWithNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelStartsWithNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelEndsWith(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelEndsWith(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) LabelEndsWithNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, LabelEndsWithNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) ReplacedBy(val yoyouuid.UUID) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, ReplacedBy(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) ReplacedByNot(val yoyouuid.UUID) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, ReplacedByNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) ReplacedByIsNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, ReplacedByIsNull().n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) ReplacedByIsNotNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, ReplacedByIsNotNull().n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PersonId(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PersonId(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PersonIdNot(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PersonIdNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PersonIdGreaterThan(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PersonIdGreaterThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PersonIdLessThan(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PersonIdLessThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PersonIdGreaterOrEqual(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PersonIdGreaterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PersonIdLessOrEqual(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PersonIdLessOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+
+func Id(val yoyouuid.UUID) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "id",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func IdNot(val yoyouuid.UUID) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "id",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func Label(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func LabelNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func LabelContains(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.Like,
+			Value:    fmt.Sprintf("'%%%s%%'", val),
+		},
+	}}
+}
+
+func LabelContainsNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.NotLike,
+			Value:    fmt.Sprintf("'%%%s%%'", val),
+		},
+	}}
+}
+
+func LabelStartsWith(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.Like,
+			Value:    fmt.Sprintf("'%s%%'", val),
+		},
+	}}
+}
+
+func LabelStartsWithNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.NotLike,
+			Value:    fmt.Sprintf("'%s%%'", val),
+		},
+	}}
+}
+
+func LabelEndsWith(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.Like,
+			Value:    fmt.Sprintf("'%%%s'", val),
+		},
+	}}
+}
+
+func LabelEndsWithNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "label",
+			Operator: query.NotLike,
+			Value:    fmt.Sprintf("'%%%s'", val),
+		},
+	}}
+}
+
+func ReplacedBy(val yoyouuid.UUID) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "replaced_by",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func ReplacedByNot(val yoyouuid.UUID) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "replaced_by",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func ReplacedByIsNull() Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "replaced_by",
+			Operator: query.IsNull,
+		},
+	}}
+}
+
+func ReplacedByIsNotNull() Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "replaced_by",
+			Operator: query.IsNotNull,
+		},
+	}}
+}
+
+func PersonId(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "fk_person_id",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func PersonIdNot(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "fk_person_id",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func PersonIdGreaterThan(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "fk_person_id",
+			Operator: query.GreaterThan,
+			Value:    val,
+		},
+	}}
+}
+
+func PersonIdLessThan(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "fk_person_id",
+			Operator: query.LessThan,
+			Value:    val,
+		},
+	}}
+}
+
+func PersonIdGreaterOrEqual(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "fk_person_id",
+			Operator: query.GreaterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func PersonIdLessOrEqual(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "fk_person_id",
+			Operator: query.LessOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
@@ -3,7 +3,9 @@
 package city
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query"
 )
@@ -14,117 +16,335 @@ type Query struct {
 
 func (q Query) SQL() (string, []interface{}) {
 	cs, ps := q.n.SQL()
+	if cs == "" {
+		return "", ps
+	}
 	return fmt.Sprintf("WHERE %s", cs), ps
 }
 
 func (q Query) Or(q2 Query) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, q2.n},
 		Operator: query.Or,
-	}}
+	}
+	return q
 }
 
 func (q Query) Id(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Id(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdNot(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdGreaterThan(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdGreaterThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdLessThan(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdLessThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdGreaterOrEqual(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdGreaterOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdLessOrEqual(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdLessOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// Name filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) Name(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Name(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameNot filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameContains filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameContains(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameContains(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameContainsNot filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameContainsNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameContainsNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameStartsWith filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameStartsWith(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameStartsWith(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameStartsWithNot filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameStartsWithNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameStartsWithNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameEndsWith filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameEndsWith(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameEndsWith(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
+// NameEndsWithNot filters on the name column.
+//
+// The city's name, as its residents write it
 func (q Query) NameEndsWithNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameEndsWithNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
+}
+
+func (q Query) MetadataIsNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, MetadataIsNull().n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) MetadataIsNotNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, MetadataIsNotNull().n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) MetadataPathEquals(path, val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, MetadataPathEquals(path, val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) MetadataContains(val json.RawMessage) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, MetadataContains(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) Version(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, Version(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) VersionNot(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, VersionNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) VersionGreaterThan(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, VersionGreaterThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) VersionLessThan(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, VersionLessThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) VersionGreaterOrEqual(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, VersionGreaterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) VersionLessOrEqual(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, VersionLessOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CreatedAt(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CreatedAt(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CreatedAtNot(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CreatedAtNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CreatedAtBefore(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CreatedAtBefore(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CreatedAtAfter(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CreatedAtAfter(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CreatedAtBeforeOrEqual(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CreatedAtBeforeOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CreatedAtAfterOrEqual(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CreatedAtAfterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) UpdatedAt(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, UpdatedAt(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) UpdatedAtNot(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, UpdatedAtNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) UpdatedAtBefore(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, UpdatedAtBefore(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) UpdatedAtAfter(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, UpdatedAtAfter(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) UpdatedAtBeforeOrEqual(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, UpdatedAtBeforeOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) UpdatedAtAfterOrEqual(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, UpdatedAtAfterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
 }
 
 
 func Id(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.Equals,
@@ -134,7 +354,7 @@ func Id(val uint32) Query {
 }
 
 func IdNot(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.NotEquals,
@@ -144,7 +364,7 @@ func IdNot(val uint32) Query {
 }
 
 func IdGreaterThan(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.GreaterThan,
@@ -154,7 +374,7 @@ func IdGreaterThan(val uint32) Query {
 }
 
 func IdLessThan(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.LessThan,
@@ -164,7 +384,7 @@ func IdLessThan(val uint32) Query {
 }
 
 func IdGreaterOrEqual(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.GreaterOrEqual,
@@ -174,7 +394,7 @@ func IdGreaterOrEqual(val uint32) Query {
 }
 
 func IdLessOrEqual(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.LessOrEqual,
@@ -183,8 +403,11 @@ func IdLessOrEqual(val uint32) Query {
 	}}
 }
 
+// Name filters on the name column.
+//
+// The city's name, as its residents write it
 func Name(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Equals,
@@ -193,8 +416,11 @@ func Name(val string) Query {
 	}}
 }
 
+// NameNot filters on the name column.
+//
+// The city's name, as its residents write it
 func NameNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotEquals,
@@ -203,8 +429,11 @@ func NameNot(val string) Query {
 	}}
 }
 
+// NameContains filters on the name column.
+//
+// The city's name, as its residents write it
 func NameContains(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Like,
@@ -213,8 +442,11 @@ func NameContains(val string) Query {
 	}}
 }
 
+// NameContainsNot filters on the name column.
+//
+// The city's name, as its residents write it
 func NameContainsNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotLike,
@@ -223,8 +455,11 @@ func NameContainsNot(val string) Query {
 	}}
 }
 
+// NameStartsWith filters on the name column.
+//
+// The city's name, as its residents write it
 func NameStartsWith(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Like,
@@ -233,8 +468,11 @@ func NameStartsWith(val string) Query {
 	}}
 }
 
+// NameStartsWithNot filters on the name column.
+//
+// The city's name, as its residents write it
 func NameStartsWithNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotLike,
@@ -243,8 +481,11 @@ func NameStartsWithNot(val string) Query {
 	}}
 }
 
+// NameEndsWith filters on the name column.
+//
+// The city's name, as its residents write it
 func NameEndsWith(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Like,
@@ -253,8 +494,11 @@ func NameEndsWith(val string) Query {
 	}}
 }
 
+// NameEndsWithNot filters on the name column.
+//
+// The city's name, as its residents write it
 func NameEndsWithNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotLike,
@@ -263,3 +507,221 @@ func NameEndsWithNot(val string) Query {
 	}}
 }
 
+func MetadataIsNull() Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "metadata",
+			Operator: query.IsNull,
+		},
+	}}
+}
+
+func MetadataIsNotNull() Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "metadata",
+			Operator: query.IsNotNull,
+		},
+	}}
+}
+
+func MetadataPathEquals(path, val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column: "metadata",
+			Format: "JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) = ?",
+			Values: []interface{}{path, val},
+		},
+	}}
+}
+
+func MetadataContains(val json.RawMessage) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column: "metadata",
+			Format: "JSON_CONTAINS(%s, ?)",
+			Values: []interface{}{string(val)},
+		},
+	}}
+}
+
+func Version(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "version",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func VersionNot(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "version",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func VersionGreaterThan(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "version",
+			Operator: query.GreaterThan,
+			Value:    val,
+		},
+	}}
+}
+
+func VersionLessThan(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "version",
+			Operator: query.LessThan,
+			Value:    val,
+		},
+	}}
+}
+
+func VersionGreaterOrEqual(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "version",
+			Operator: query.GreaterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func VersionLessOrEqual(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "version",
+			Operator: query.LessOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func CreatedAt(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "created_at",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func CreatedAtNot(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "created_at",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func CreatedAtBefore(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "created_at",
+			Operator: query.Before,
+			Value:    val,
+		},
+	}}
+}
+
+func CreatedAtAfter(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "created_at",
+			Operator: query.After,
+			Value:    val,
+		},
+	}}
+}
+
+func CreatedAtBeforeOrEqual(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "created_at",
+			Operator: query.BeforeOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func CreatedAtAfterOrEqual(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "created_at",
+			Operator: query.AfterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func UpdatedAt(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "updated_at",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func UpdatedAtNot(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "updated_at",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func UpdatedAtBefore(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "updated_at",
+			Operator: query.Before,
+			Value:    val,
+		},
+	}}
+}
+
+func UpdatedAtAfter(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "updated_at",
+			Operator: query.After,
+			Value:    val,
+		},
+	}}
+}
+
+func UpdatedAtBeforeOrEqual(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "updated_at",
+			Operator: query.BeforeOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func UpdatedAtAfterOrEqual(val time.Time) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "updated_at",
+			Operator: query.AfterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
@@ -0,0 +1,52 @@
+package city
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuery_SQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "empty",
+			query:   Query{},
+			wantSQL: "",
+		},
+		{
+			name:     "condition",
+			query:    Id(7),
+			wantSQL:  "WHERE id = ?",
+			wantArgs: []interface{}{uint32(7)},
+		},
+		{
+			name:     "or beneath and",
+			query:    Id(7).Or(Id(8)).Name("Oslo"),
+			wantSQL:  "WHERE ((id = ?) OR (id = ?)) AND name = ?",
+			wantArgs: []interface{}{uint32(7), uint32(8), "Oslo"},
+		},
+		{
+			name:     "and beneath or",
+			query:    Id(7).Name("Oslo").Or(Name("Bergen")),
+			wantSQL:  "WHERE (id = ? AND name = ?) OR (name = ?)",
+			wantArgs: []interface{}{uint32(7), "Oslo", "Bergen"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := tt.query.SQL()
+			if gotSQL != tt.wantSQL {
+				t.Errorf("SQL() = %q, want %q", gotSQL, tt.wantSQL)
+			}
+			if len(gotArgs) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+					t.Errorf("SQL() args = %v, want %v", gotArgs, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package city_population
+
+import (
+	"fmt"
+
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query"
+)
+
+type Query struct {
+	n query.Node
+}
+
+func (q Query) SQL() (string, []interface{}) {
+	cs, ps := q.n.SQL()
+	if cs == "" {
+		return "", ps
+	}
+	return fmt.Sprintf("WHERE %s", cs), ps
+}
+
+func (q Query) Or(q2 Query) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, q2.n},
+		Operator: query.Or,
+	}
+	return q
+}
+
+func (q Query) CityId(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityId(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityIdNot(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityIdNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityIdGreaterThan(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityIdGreaterThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityIdLessThan(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityIdLessThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityIdGreaterOrEqual(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityIdGreaterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityIdLessOrEqual(val uint32) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityIdLessOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityName(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityName(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameContains(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameContains(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameContainsNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameContainsNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameStartsWith(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameStartsWith(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameStartsWithNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameStartsWithNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameEndsWith(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameEndsWith(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) CityNameEndsWithNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, CityNameEndsWithNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) Population(val int64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, Population(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PopulationNot(val int64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PopulationNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PopulationGreaterThan(val int64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PopulationGreaterThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PopulationLessThan(val int64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PopulationLessThan(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PopulationGreaterOrEqual(val int64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PopulationGreaterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) PopulationLessOrEqual(val int64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PopulationLessOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+
+func CityId(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_id",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func CityIdNot(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_id",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func CityIdGreaterThan(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_id",
+			Operator: query.GreaterThan,
+			Value:    val,
+		},
+	}}
+}
+
+func CityIdLessThan(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_id",
+			Operator: query.LessThan,
+			Value:    val,
+		},
+	}}
+}
+
+func CityIdGreaterOrEqual(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_id",
+			Operator: query.GreaterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func CityIdLessOrEqual(val uint32) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_id",
+			Operator: query.LessOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func CityName(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func CityNameNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func CityNameContains(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.Like,
+			Value:    fmt.Sprintf("'%%%s%%'", val),
+		},
+	}}
+}
+
+func CityNameContainsNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.NotLike,
+			Value:    fmt.Sprintf("'%%%s%%'", val),
+		},
+	}}
+}
+
+func CityNameStartsWith(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.Like,
+			Value:    fmt.Sprintf("'%s%%'", val),
+		},
+	}}
+}
+
+func CityNameStartsWithNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.NotLike,
+			Value:    fmt.Sprintf("'%s%%'", val),
+		},
+	}}
+}
+
+func CityNameEndsWith(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.Like,
+			Value:    fmt.Sprintf("'%%%s'", val),
+		},
+	}}
+}
+
+func CityNameEndsWithNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "city_name",
+			Operator: query.NotLike,
+			Value:    fmt.Sprintf("'%%%s'", val),
+		},
+	}}
+}
+
+func Population(val int64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "population",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func PopulationNot(val int64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "population",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func PopulationGreaterThan(val int64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "population",
+			Operator: query.GreaterThan,
+			Value:    val,
+		},
+	}}
+}
+
+func PopulationLessThan(val int64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "population",
+			Operator: query.LessThan,
+			Value:    val,
+		},
+	}}
+}
+
+func PopulationGreaterOrEqual(val int64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "population",
+			Operator: query.GreaterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func PopulationLessOrEqual(val int64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "population",
+			Operator: query.LessOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
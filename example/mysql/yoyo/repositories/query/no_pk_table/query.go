@@ -14,103 +14,119 @@ type Query struct {
 
 func (q Query) SQL() (string, []interface{}) {
 	cs, ps := q.n.SQL()
+	if cs == "" {
+		return "", ps
+	}
 	return fmt.Sprintf("WHERE %s", cs), ps
 }
 
 func (q Query) Or(q2 Query) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, q2.n},
 		Operator: query.Or,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) ColNot(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, ColNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) ColGreaterThan(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, ColGreaterThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) ColLessThan(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, ColLessThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) ColGreaterOrEqual(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, ColGreaterOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) ColLessOrEqual(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, ColLessOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col2(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col2(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col2Not(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col2Not(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col2GreaterThan(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col2GreaterThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col2LessThan(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col2LessThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col2GreaterOrEqual(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col2GreaterOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Col2LessOrEqual(val int32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Col2LessOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 
 func Col(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col",
 			Operator: query.Equals,
@@ -120,7 +136,7 @@ func Col(val int32) Query {
 }
 
 func ColNot(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col",
 			Operator: query.NotEquals,
@@ -130,7 +146,7 @@ func ColNot(val int32) Query {
 }
 
 func ColGreaterThan(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col",
 			Operator: query.GreaterThan,
@@ -140,7 +156,7 @@ func ColGreaterThan(val int32) Query {
 }
 
 func ColLessThan(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col",
 			Operator: query.LessThan,
@@ -150,7 +166,7 @@ func ColLessThan(val int32) Query {
 }
 
 func ColGreaterOrEqual(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col",
 			Operator: query.GreaterOrEqual,
@@ -160,7 +176,7 @@ func ColGreaterOrEqual(val int32) Query {
 }
 
 func ColLessOrEqual(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col",
 			Operator: query.LessOrEqual,
@@ -170,7 +186,7 @@ func ColLessOrEqual(val int32) Query {
 }
 
 func Col2(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col2",
 			Operator: query.Equals,
@@ -180,7 +196,7 @@ func Col2(val int32) Query {
 }
 
 func Col2Not(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col2",
 			Operator: query.NotEquals,
@@ -190,7 +206,7 @@ func Col2Not(val int32) Query {
 }
 
 func Col2GreaterThan(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col2",
 			Operator: query.GreaterThan,
@@ -200,7 +216,7 @@ func Col2GreaterThan(val int32) Query {
 }
 
 func Col2LessThan(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col2",
 			Operator: query.LessThan,
@@ -210,7 +226,7 @@ func Col2LessThan(val int32) Query {
 }
 
 func Col2GreaterOrEqual(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col2",
 			Operator: query.GreaterOrEqual,
@@ -220,7 +236,7 @@ func Col2GreaterOrEqual(val int32) Query {
 }
 
 func Col2LessOrEqual(val int32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "col2",
 			Operator: query.LessOrEqual,
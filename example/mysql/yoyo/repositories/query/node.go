@@ -35,9 +35,17 @@ type Condition struct {
 	Column   string
 	Value    interface{}
 	Operator ComparisonOperator
+	// Format, if set, is used instead of Operator to build the SQL of conditions which aren't a simple comparison, like
+	// those on JSON columns. It is given the Column, and its parameters are Values.
+	Format string
+	Values []interface{}
 }
 
 func (c Condition) SQL() (string, []interface{}) {
+	if c.Format != "" {
+		return fmt.Sprintf(c.Format, c.Column), c.Values
+	}
+
 	switch c.Operator {
 	case IsNull, IsNotNull:
 		return fmt.Sprintf("%s %s", c.Column, c.Operator), []interface{}{}
@@ -52,13 +60,42 @@ type Node struct {
 	Condition Condition
 }
 
+// empty returns true for the zero Node, which has neither a Condition nor Children
+func (n Node) empty() bool {
+	return n.Children == nil && n.Condition.Column == ""
+}
+
+// or returns true if the SQL of the Node is an OR of two non-empty children
+func (n Node) or() bool {
+	return n.Operator == Or && n.Children != nil && !n.Children[0].empty() && !n.Children[1].empty()
+}
+
 func (n Node) SQL() (s string, args []interface{}) {
+	if n.empty() {
+		return "", nil
+	}
+
 	if n.Children != nil {
+		// An empty child, like the zero Query a chain of conditions starts from, contributes nothing
+		if n.Children[0].empty() {
+			return n.Children[1].SQL()
+		}
+		if n.Children[1].empty() {
+			return n.Children[0].SQL()
+		}
 		sql1, args1 := n.Children[0].SQL()
 		sql2, args2 := n.Children[1].SQL()
 		if n.Operator == Or {
 			sql1 = fmt.Sprintf("(%s)", sql1)
 			sql2 = fmt.Sprintf("(%s)", sql2)
+		} else {
+			// AND takes precedence over OR, so an OR beneath an AND must keep its own parentheses
+			if n.Children[0].or() {
+				sql1 = fmt.Sprintf("(%s)", sql1)
+			}
+			if n.Children[1].or() {
+				sql2 = fmt.Sprintf("(%s)", sql2)
+			}
 		}
 		s, args = fmt.Sprintf("%s %s %s", sql1, n.Operator, sql2), append(args1, args2...)
 		return s, args
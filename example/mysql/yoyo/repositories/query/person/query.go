@@ -3,352 +3,651 @@
 package person
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query"
 )
 
 type Query struct {
 	n query.Node
+	// deleted selects whether soft deleted rows are excluded (the default), included, or the only rows matched
+	deleted deletedScope
 }
 
+type deletedScope int
+
+const (
+	excludeDeleted deletedScope = iota
+	withDeleted
+	onlyDeleted
+)
+
 func (q Query) SQL() (string, []interface{}) {
-	cs, ps := q.n.SQL()
+	cs, ps := q.scoped().SQL()
+	if cs == "" {
+		return "", ps
+	}
 	return fmt.Sprintf("WHERE %s", cs), ps
 }
 
+// scoped returns the query's conditions with those of its soft delete scope
+func (q Query) scoped() query.Node {
+	switch q.deleted {
+	case excludeDeleted:
+		return query.Node{Children: &[2]query.Node{q.n, DeletedAtIsNull().n}, Operator: query.And}
+	case onlyDeleted:
+		return query.Node{Children: &[2]query.Node{q.n, DeletedAtIsNotNull().n}, Operator: query.And}
+	}
+	return q.n
+}
+
+// WithDeleted returns a Query which also matches soft deleted rows, which are excluded by default
+func (q Query) WithDeleted() Query {
+	q.deleted = withDeleted
+	return q
+}
+
+// OnlyDeleted returns a Query which only matches soft deleted rows
+func (q Query) OnlyDeleted() Query {
+	q.deleted = onlyDeleted
+	return q
+}
+
+// Or returns a Query which matches the rows matched by either query. If their soft delete scopes differ, each applies to
+// its own side, and the result includes soft deleted rows wherever one side does.
 func (q Query) Or(q2 Query) Query {
-	return Query{query.Node{
+	if q.deleted != q2.deleted {
+		q.n, q2.n = q.scoped(), q2.scoped()
+		q.deleted = withDeleted
+	}
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, q2.n},
 		Operator: query.Or,
-	}}
+	}
+	return q
+}
+
+// FavoriteColorEnum is the set of values allowed in the favorite_color column
+type FavoriteColorEnum string
+
+const (
+	FavoriteColorEnumBlue FavoriteColorEnum = "blue"
+	FavoriteColorEnumRed FavoriteColorEnum = "red"
+	FavoriteColorEnumGreen FavoriteColorEnum = "green"
+	FavoriteColorEnumYellow FavoriteColorEnum = "yellow"
+	FavoriteColorEnumOrange FavoriteColorEnum = "orange"
+	FavoriteColorEnumPurple FavoriteColorEnum = "purple"
+)
+
+// Valid returns true if the value is one of the values allowed in the favorite_color column
+func (e FavoriteColorEnum) Valid() bool {
+	switch e {
+	case FavoriteColorEnumBlue, FavoriteColorEnumRed, FavoriteColorEnumGreen, FavoriteColorEnumYellow, FavoriteColorEnumOrange, FavoriteColorEnumPurple:
+		return true
+	}
+	return false
+}
+
+// Scan implements sql.Scanner, returning an error if the scanned value is not valid
+func (e *FavoriteColorEnum) Scan(src interface{}) error {
+	var v FavoriteColorEnum
+	switch s := src.(type) {
+	case string:
+		v = FavoriteColorEnum(s)
+	case []byte:
+		v = FavoriteColorEnum(s)
+	default:
+		return fmt.Errorf("cannot scan %T into FavoriteColorEnum", src)
+	}
+	if !v.Valid() {
+		return fmt.Errorf("invalid value %q for FavoriteColorEnum", v)
+	}
+	*e = v
+	return nil
+}
+
+// Value implements driver.Valuer, returning an error if the value is not valid
+func (e FavoriteColorEnum) Value() (driver.Value, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("invalid value %q for FavoriteColorEnum", e)
+	}
+	return string(e), nil
+}
+
+// NullFavoriteColorEnum is the nullable counterpart of FavoriteColorEnum
+type NullFavoriteColorEnum struct {
+	FavoriteColorEnum FavoriteColorEnum
+	Valid bool
+}
+
+func (n *NullFavoriteColorEnum) Set(val FavoriteColorEnum) {
+	n.Valid = true
+	n.FavoriteColorEnum = val
+}
+
+func (n *NullFavoriteColorEnum) SetNull() {
+	n.Valid = false
+	n.FavoriteColorEnum = ""
+}
+
+// Scan implements sql.Scanner, returning an error and leaving the value unchanged if the scanned value is not valid
+func (n *NullFavoriteColorEnum) Scan(src interface{}) error {
+	if src == nil {
+		n.SetNull()
+		return nil
+	}
+	var v FavoriteColorEnum
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	n.Set(v)
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n NullFavoriteColorEnum) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.FavoriteColorEnum.Value()
+}
+
+// MarshalJSON implements json.Marshaler. A null value is marshalled as JSON null.
+func (n NullFavoriteColorEnum) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.FavoriteColorEnum)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null is unmarshalled as a null value, and an error is returned if the
+// value is not valid.
+func (n *NullFavoriteColorEnum) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.SetNull()
+		return nil
+	}
+	var v FavoriteColorEnum
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if !v.Valid() {
+		return fmt.Errorf("invalid value %q for FavoriteColorEnum", v)
+	}
+	n.Set(v)
+	return nil
 }
 
 func (q Query) Id(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Id(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdNot(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdGreaterThan(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdGreaterThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdLessThan(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdLessThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdGreaterOrEqual(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdGreaterOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) IdLessOrEqual(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, IdLessOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) SomeBinary(val []byte) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, SomeBinary(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) SomeBinaryNot(val []byte) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, SomeBinaryNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Name(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Name(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameContains(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameContains(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameContainsNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameContainsNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameStartsWith(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameStartsWith(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameStartsWithNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameStartsWithNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameEndsWith(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameEndsWith(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NameEndsWithNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NameEndsWithNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) Nickname(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, Nickname(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameContains(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameContains(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameContainsNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameContainsNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameStartsWith(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameStartsWith(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameStartsWithNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameStartsWithNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameEndsWith(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameEndsWith(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) NicknameEndsWithNot(val string) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, NicknameEndsWithNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColor(val string) Query {
-	return Query{query.Node{
+func (q Query) FavoriteColor(val FavoriteColorEnum) Query {
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, FavoriteColor(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorNot(val string) Query {
-	return Query{query.Node{
+func (q Query) FavoriteColorNot(val FavoriteColorEnum) Query {
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, FavoriteColorNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorContains(val string) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorContains(val).n},
+func (q Query) FavoriteColorIsNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, FavoriteColorIsNull().n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorContainsNot(val string) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorContainsNot(val).n},
+func (q Query) FavoriteColorIsNotNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, FavoriteColorIsNotNull().n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorStartsWith(val string) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorStartsWith(val).n},
+func (q Query) Age(val float64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, Age(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorStartsWithNot(val string) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorStartsWithNot(val).n},
+func (q Query) AgeNot(val float64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, AgeNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorEndsWith(val string) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorEndsWith(val).n},
+func (q Query) AgeGreaterThan(val float64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, AgeGreaterThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorEndsWithNot(val string) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorEndsWithNot(val).n},
+func (q Query) AgeLessThan(val float64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, AgeLessThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorIsNull() Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorIsNull().n},
+func (q Query) AgeGreaterOrEqual(val float64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, AgeGreaterOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) FavoriteColorIsNotNull() Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, FavoriteColorIsNotNull().n},
+func (q Query) AgeLessOrEqual(val float64) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, AgeLessOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) Age(val float64) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, Age(val).n},
+func (q Query) PreferencesPathEquals(path, val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PreferencesPathEquals(path, val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) AgeNot(val float64) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, AgeNot(val).n},
+func (q Query) PreferencesContains(val json.RawMessage) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, PreferencesContains(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) AgeGreaterThan(val float64) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, AgeGreaterThan(val).n},
+func (q Query) DisplayName(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayName(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) AgeLessThan(val float64) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, AgeLessThan(val).n},
+func (q Query) DisplayNameNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) AgeGreaterOrEqual(val float64) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, AgeGreaterOrEqual(val).n},
+func (q Query) DisplayNameContains(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameContains(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
-func (q Query) AgeLessOrEqual(val float64) Query {
-	return Query{query.Node{
-		Children: &[2]query.Node{q.n, AgeLessOrEqual(val).n},
+func (q Query) DisplayNameContainsNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameContainsNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
+}
+
+func (q Query) DisplayNameStartsWith(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameStartsWith(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DisplayNameStartsWithNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameStartsWithNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DisplayNameEndsWith(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameEndsWith(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DisplayNameEndsWithNot(val string) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DisplayNameEndsWithNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAt(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAt(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtNot(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtNot(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtBefore(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtBefore(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtAfter(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtAfter(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtBeforeOrEqual(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtBeforeOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtAfterOrEqual(val time.Time) Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtAfterOrEqual(val).n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtIsNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtIsNull().n},
+		Operator: query.And,
+	}
+	return q
+}
+
+func (q Query) DeletedAtIsNotNull() Query {
+	q.n = query.Node{
+		Children: &[2]query.Node{q.n, DeletedAtIsNotNull().n},
+		Operator: query.And,
+	}
+	return q
 }
 
 func (q Query) HometownId(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, HometownId(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) HometownIdNot(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, HometownIdNot(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) HometownIdGreaterThan(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, HometownIdGreaterThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) HometownIdLessThan(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, HometownIdLessThan(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) HometownIdGreaterOrEqual(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, HometownIdGreaterOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 func (q Query) HometownIdLessOrEqual(val uint32) Query {
-	return Query{query.Node{
+	q.n = query.Node{
 		Children: &[2]query.Node{q.n, HometownIdLessOrEqual(val).n},
 		Operator: query.And,
-	}}
+	}
+	return q
 }
 
 
 func Id(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.Equals,
@@ -358,7 +657,7 @@ func Id(val uint32) Query {
 }
 
 func IdNot(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.NotEquals,
@@ -368,7 +667,7 @@ func IdNot(val uint32) Query {
 }
 
 func IdGreaterThan(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.GreaterThan,
@@ -378,7 +677,7 @@ func IdGreaterThan(val uint32) Query {
 }
 
 func IdLessThan(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.LessThan,
@@ -388,7 +687,7 @@ func IdLessThan(val uint32) Query {
 }
 
 func IdGreaterOrEqual(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.GreaterOrEqual,
@@ -398,7 +697,7 @@ func IdGreaterOrEqual(val uint32) Query {
 }
 
 func IdLessOrEqual(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "id",
 			Operator: query.LessOrEqual,
@@ -408,7 +707,7 @@ func IdLessOrEqual(val uint32) Query {
 }
 
 func SomeBinary(val []byte) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "someBinary",
 			Operator: query.Equals,
@@ -418,7 +717,7 @@ func SomeBinary(val []byte) Query {
 }
 
 func SomeBinaryNot(val []byte) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "someBinary",
 			Operator: query.NotEquals,
@@ -428,7 +727,7 @@ func SomeBinaryNot(val []byte) Query {
 }
 
 func Name(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Equals,
@@ -438,7 +737,7 @@ func Name(val string) Query {
 }
 
 func NameNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotEquals,
@@ -448,7 +747,7 @@ func NameNot(val string) Query {
 }
 
 func NameContains(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Like,
@@ -458,7 +757,7 @@ func NameContains(val string) Query {
 }
 
 func NameContainsNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotLike,
@@ -468,7 +767,7 @@ func NameContainsNot(val string) Query {
 }
 
 func NameStartsWith(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Like,
@@ -478,7 +777,7 @@ func NameStartsWith(val string) Query {
 }
 
 func NameStartsWithNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotLike,
@@ -488,7 +787,7 @@ func NameStartsWithNot(val string) Query {
 }
 
 func NameEndsWith(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.Like,
@@ -498,7 +797,7 @@ func NameEndsWith(val string) Query {
 }
 
 func NameEndsWithNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "name",
 			Operator: query.NotLike,
@@ -508,7 +807,7 @@ func NameEndsWithNot(val string) Query {
 }
 
 func Nickname(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.Equals,
@@ -518,7 +817,7 @@ func Nickname(val string) Query {
 }
 
 func NicknameNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.NotEquals,
@@ -528,7 +827,7 @@ func NicknameNot(val string) Query {
 }
 
 func NicknameContains(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.Like,
@@ -538,7 +837,7 @@ func NicknameContains(val string) Query {
 }
 
 func NicknameContainsNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.NotLike,
@@ -548,7 +847,7 @@ func NicknameContainsNot(val string) Query {
 }
 
 func NicknameStartsWith(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.Like,
@@ -558,7 +857,7 @@ func NicknameStartsWith(val string) Query {
 }
 
 func NicknameStartsWithNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.NotLike,
@@ -568,7 +867,7 @@ func NicknameStartsWithNot(val string) Query {
 }
 
 func NicknameEndsWith(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.Like,
@@ -578,7 +877,7 @@ func NicknameEndsWith(val string) Query {
 }
 
 func NicknameEndsWithNot(val string) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "nickname",
 			Operator: query.NotLike,
@@ -587,8 +886,8 @@ func NicknameEndsWithNot(val string) Query {
 	}}
 }
 
-func FavoriteColor(val string) Query {
-	return Query{query.Node{
+func FavoriteColor(val FavoriteColorEnum) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "favorite_color",
 			Operator: query.Equals,
@@ -597,8 +896,8 @@ func FavoriteColor(val string) Query {
 	}}
 }
 
-func FavoriteColorNot(val string) Query {
-	return Query{query.Node{
+func FavoriteColorNot(val FavoriteColorEnum) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "favorite_color",
 			Operator: query.NotEquals,
@@ -607,146 +906,264 @@ func FavoriteColorNot(val string) Query {
 	}}
 }
 
-func FavoriteColorContains(val string) Query {
-	return Query{query.Node{
+func FavoriteColorIsNull() Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "favorite_color",
+			Operator: query.IsNull,
+		},
+	}}
+}
+
+func FavoriteColorIsNotNull() Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "favorite_color",
+			Operator: query.IsNotNull,
+		},
+	}}
+}
+
+func Age(val float64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "age",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func AgeNot(val float64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "age",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func AgeGreaterThan(val float64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "age",
+			Operator: query.GreaterThan,
+			Value:    val,
+		},
+	}}
+}
+
+func AgeLessThan(val float64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "age",
+			Operator: query.LessThan,
+			Value:    val,
+		},
+	}}
+}
+
+func AgeGreaterOrEqual(val float64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "age",
+			Operator: query.GreaterOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func AgeLessOrEqual(val float64) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "age",
+			Operator: query.LessOrEqual,
+			Value:    val,
+		},
+	}}
+}
+
+func PreferencesPathEquals(path, val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column: "preferences",
+			Format: "JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) = ?",
+			Values: []interface{}{path, val},
+		},
+	}}
+}
+
+func PreferencesContains(val json.RawMessage) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column: "preferences",
+			Format: "JSON_CONTAINS(%s, ?)",
+			Values: []interface{}{string(val)},
+		},
+	}}
+}
+
+func DisplayName(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "display_name",
+			Operator: query.Equals,
+			Value:    val,
+		},
+	}}
+}
+
+func DisplayNameNot(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "display_name",
+			Operator: query.NotEquals,
+			Value:    val,
+		},
+	}}
+}
+
+func DisplayNameContains(val string) Query {
+	return Query{n: query.Node{
+		Condition: query.Condition{
+			Column:   "display_name",
 			Operator: query.Like,
 			Value:    fmt.Sprintf("'%%%s%%'", val),
 		},
 	}}
 }
 
-func FavoriteColorContainsNot(val string) Query {
-	return Query{query.Node{
+func DisplayNameContainsNot(val string) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
+			Column:   "display_name",
 			Operator: query.NotLike,
 			Value:    fmt.Sprintf("'%%%s%%'", val),
 		},
 	}}
 }
 
-func FavoriteColorStartsWith(val string) Query {
-	return Query{query.Node{
+func DisplayNameStartsWith(val string) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
+			Column:   "display_name",
 			Operator: query.Like,
 			Value:    fmt.Sprintf("'%s%%'", val),
 		},
 	}}
 }
 
-func FavoriteColorStartsWithNot(val string) Query {
-	return Query{query.Node{
+func DisplayNameStartsWithNot(val string) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
+			Column:   "display_name",
 			Operator: query.NotLike,
 			Value:    fmt.Sprintf("'%s%%'", val),
 		},
 	}}
 }
 
-func FavoriteColorEndsWith(val string) Query {
-	return Query{query.Node{
+func DisplayNameEndsWith(val string) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
+			Column:   "display_name",
 			Operator: query.Like,
 			Value:    fmt.Sprintf("'%%%s'", val),
 		},
 	}}
 }
 
-func FavoriteColorEndsWithNot(val string) Query {
-	return Query{query.Node{
+func DisplayNameEndsWithNot(val string) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
+			Column:   "display_name",
 			Operator: query.NotLike,
 			Value:    fmt.Sprintf("'%%%s'", val),
 		},
 	}}
 }
 
-func FavoriteColorIsNull() Query {
-	return Query{query.Node{
+func DeletedAt(val time.Time) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
-			Operator: query.IsNull,
+			Column:   "deleted_at",
+			Operator: query.Equals,
+			Value:    val,
 		},
 	}}
 }
 
-func FavoriteColorIsNotNull() Query {
-	return Query{query.Node{
+func DeletedAtNot(val time.Time) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "favorite_color",
-			Operator: query.IsNotNull,
+			Column:   "deleted_at",
+			Operator: query.NotEquals,
+			Value:    val,
 		},
 	}}
 }
 
-func Age(val float64) Query {
-	return Query{query.Node{
+func DeletedAtBefore(val time.Time) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "age",
-			Operator: query.Equals,
+			Column:   "deleted_at",
+			Operator: query.Before,
 			Value:    val,
 		},
 	}}
 }
 
-func AgeNot(val float64) Query {
-	return Query{query.Node{
+func DeletedAtAfter(val time.Time) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "age",
-			Operator: query.NotEquals,
+			Column:   "deleted_at",
+			Operator: query.After,
 			Value:    val,
 		},
 	}}
 }
 
-func AgeGreaterThan(val float64) Query {
-	return Query{query.Node{
+func DeletedAtBeforeOrEqual(val time.Time) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "age",
-			Operator: query.GreaterThan,
+			Column:   "deleted_at",
+			Operator: query.BeforeOrEqual,
 			Value:    val,
 		},
 	}}
 }
 
-func AgeLessThan(val float64) Query {
-	return Query{query.Node{
+func DeletedAtAfterOrEqual(val time.Time) Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "age",
-			Operator: query.LessThan,
+			Column:   "deleted_at",
+			Operator: query.AfterOrEqual,
 			Value:    val,
 		},
 	}}
 }
 
-func AgeGreaterOrEqual(val float64) Query {
-	return Query{query.Node{
+func DeletedAtIsNull() Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "age",
-			Operator: query.GreaterOrEqual,
-			Value:    val,
+			Column:   "deleted_at",
+			Operator: query.IsNull,
 		},
 	}}
 }
 
-func AgeLessOrEqual(val float64) Query {
-	return Query{query.Node{
+func DeletedAtIsNotNull() Query {
+	return Query{n: query.Node{
 		Condition: query.Condition{
-			Column:   "age",
-			Operator: query.LessOrEqual,
-			Value:    val,
+			Column:   "deleted_at",
+			Operator: query.IsNotNull,
 		},
 	}}
 }
 
 func HometownId(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "fk_city_id",
 			Operator: query.Equals,
@@ -756,7 +1173,7 @@ func HometownId(val uint32) Query {
 }
 
 func HometownIdNot(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "fk_city_id",
 			Operator: query.NotEquals,
@@ -766,7 +1183,7 @@ func HometownIdNot(val uint32) Query {
 }
 
 func HometownIdGreaterThan(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "fk_city_id",
 			Operator: query.GreaterThan,
@@ -776,7 +1193,7 @@ func HometownIdGreaterThan(val uint32) Query {
 }
 
 func HometownIdLessThan(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "fk_city_id",
 			Operator: query.LessThan,
@@ -786,7 +1203,7 @@ func HometownIdLessThan(val uint32) Query {
 }
 
 func HometownIdGreaterOrEqual(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "fk_city_id",
 			Operator: query.GreaterOrEqual,
@@ -796,7 +1213,7 @@ func HometownIdGreaterOrEqual(val uint32) Query {
 }
 
 func HometownIdLessOrEqual(val uint32) Query {
-	return Query{query.Node{
+	return Query{n: query.Node{
 		Condition: query.Condition{
 			Column:   "fk_city_id",
 			Operator: query.LessOrEqual,
@@ -0,0 +1,156 @@
+package person
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestQuery_SQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "empty",
+			query:   Query{},
+			wantSQL: "WHERE deleted_at IS NULL",
+		},
+		{
+			name:    "empty with deleted",
+			query:   Query{}.WithDeleted(),
+			wantSQL: "",
+		},
+		{
+			name:    "empty only deleted",
+			query:   Query{}.OnlyDeleted(),
+			wantSQL: "WHERE deleted_at IS NOT NULL",
+		},
+		{
+			name:     "and",
+			query:    Name("Ada").Nickname("Countess"),
+			wantSQL:  "WHERE name = ? AND nickname = ? AND deleted_at IS NULL",
+			wantArgs: []interface{}{"Ada", "Countess"},
+		},
+		{
+			name:     "chained from an empty query",
+			query:    Query{}.Name("Ada"),
+			wantSQL:  "WHERE name = ? AND deleted_at IS NULL",
+			wantArgs: []interface{}{"Ada"},
+		},
+		{
+			name:     "or with an empty query",
+			query:    Query{}.Or(Name("Ada")),
+			wantSQL:  "WHERE name = ? AND deleted_at IS NULL",
+			wantArgs: []interface{}{"Ada"},
+		},
+		{
+			name:     "or keeps the soft delete scope on both sides",
+			query:    Name("Ada").Or(Nickname("Countess")),
+			wantSQL:  "WHERE ((name = ?) OR (nickname = ?)) AND deleted_at IS NULL",
+			wantArgs: []interface{}{"Ada", "Countess"},
+		},
+		{
+			name:     "or with deleted on one side",
+			query:    Name("Ada").Or(Nickname("Countess").WithDeleted()),
+			wantSQL:  "WHERE (name = ? AND deleted_at IS NULL) OR (nickname = ?)",
+			wantArgs: []interface{}{"Ada", "Countess"},
+		},
+		{
+			name:     "or with deleted on the other side",
+			query:    Name("Ada").WithDeleted().Or(Nickname("Countess")),
+			wantSQL:  "WHERE (name = ?) OR (nickname = ? AND deleted_at IS NULL)",
+			wantArgs: []interface{}{"Ada", "Countess"},
+		},
+		{
+			name:     "or with only deleted on one side",
+			query:    Name("Ada").Or(Nickname("Countess").OnlyDeleted()).Age(36),
+			wantSQL:  "WHERE ((name = ? AND deleted_at IS NULL) OR (nickname = ? AND deleted_at IS NOT NULL)) AND age = ?",
+			wantArgs: []interface{}{"Ada", "Countess", 36.0},
+		},
+		{
+			name:     "and beneath or",
+			query:    Name("Ada").Nickname("Countess").Or(Age(36)).WithDeleted(),
+			wantSQL:  "WHERE (name = ? AND nickname = ?) OR (age = ?)",
+			wantArgs: []interface{}{"Ada", "Countess", 36.0},
+		},
+		{
+			name:     "or beneath and",
+			query:    Name("Ada").Or(Nickname("Countess")).Age(36).WithDeleted(),
+			wantSQL:  "WHERE ((name = ?) OR (nickname = ?)) AND age = ?",
+			wantArgs: []interface{}{"Ada", "Countess", 36.0},
+		},
+		{
+			name:     "or of ors",
+			query:    Name("Ada").Or(Name("Grace")).Or(Age(36).Or(Age(85))).OnlyDeleted(),
+			wantSQL:  "WHERE (((name = ?) OR (name = ?)) OR ((age = ?) OR (age = ?))) AND deleted_at IS NOT NULL",
+			wantArgs: []interface{}{"Ada", "Grace", 36.0, 85.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := tt.query.SQL()
+			if gotSQL != tt.wantSQL {
+				t.Errorf("SQL() = %q, want %q", gotSQL, tt.wantSQL)
+			}
+			if len(gotArgs) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+					t.Errorf("SQL() args = %v, want %v", gotArgs, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
+
+func TestNullFavoriteColorEnum_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    NullFavoriteColorEnum
+		wantErr bool
+	}{
+		{name: "valid", src: []byte("red"), want: NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumRed, Valid: true}},
+		{name: "null", src: nil, want: NullFavoriteColorEnum{}},
+		{name: "invalid", src: "black", want: NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumBlue, Valid: true}, wantErr: true},
+		{name: "wrong type", src: 1, want: NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumBlue, Valid: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// an error leaves the previous value unchanged
+			got := NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumBlue, Valid: true}
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Scan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNullFavoriteColorEnum_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    NullFavoriteColorEnum
+		wantErr bool
+	}{
+		{name: "valid", data: `"green"`, want: NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumGreen, Valid: true}},
+		{name: "null", data: `null`, want: NullFavoriteColorEnum{}},
+		{name: "invalid", data: `"black"`, want: NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumBlue, Valid: true}, wantErr: true},
+		{name: "not a string", data: `1`, want: NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumBlue, Valid: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NullFavoriteColorEnum{FavoriteColorEnum: FavoriteColorEnumBlue, Valid: true}
+			if err := json.Unmarshal([]byte(tt.data), &got); (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
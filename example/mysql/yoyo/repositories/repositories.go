@@ -5,7 +5,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"slices"
+	"time"
 )
 
 type TransactFunc func(func() error, ...TransactOptions) error
@@ -20,20 +22,72 @@ type Repositories struct {
 	*NoPkTableRepository
 	*CityRepository
 	*PersonRepository
+	*ApiKeyRepository
+	*CityPopulationRepository
 }
 
-func InitRepositories(db *sql.DB) (Repositories, TransactFunc) {
-	baseRepo := &repository{db: db}
+// QueryEvent describes a single statement executed by one of the Repositories
+type QueryEvent struct {
+	// Table is the name of the table the statement runs against
+	Table string
+	// Operation is one of "select", "insert", "update" or "delete"
+	Operation string
+	Query     string
+	Args      []interface{}
+
+	// Duration, RowsAffected and Err are only set for Hooks.AfterQuery. RowsAffected is only set for insert, update and
+	// delete statements.
+	Duration     time.Duration
+	RowsAffected int64
+	Err          error
+}
+
+// Hooks are called before and after every statement executed by the Repositories, and can be used for logging, metrics
+// or tracing. Either func may be nil. The context returned from BeforeQuery is passed to AfterQuery, so it can carry
+// values like a tracing span. Inside a transaction, BeforeQuery receives the TransactOptions Context.
+type Hooks struct {
+	BeforeQuery func(ctx context.Context, e QueryEvent) context.Context
+	AfterQuery  func(ctx context.Context, e QueryEvent)
+}
+
+// Option configures the Repositories returned from InitRepositories
+type Option func(*repository)
+
+// WithHooks sets the Hooks called around every statement executed by the Repositories
+func WithHooks(h Hooks) Option {
+	return func(r *repository) {
+		r.hooks = h
+	}
+}
+
+// WithClock sets the func used to get the current time for auto_now_add, auto_now and soft delete columns, which is
+// time.Now by default
+func WithClock(now func() time.Time) Option {
+	return func(r *repository) {
+		r.now = now
+	}
+}
+
+func InitRepositories(db *sql.DB, options ...Option) (Repositories, TransactFunc) {
+	baseRepo := &repository{db: db, now: time.Now}
+	for _, o := range options {
+		o(baseRepo)
+	}
 	return Repositories{
-		NoPkTableRepository: &NoPkTableRepository{baseRepo},
-		CityRepository: &CityRepository{baseRepo},
-		PersonRepository: &PersonRepository{baseRepo},
+		NoPkTableRepository: &NoPkTableRepository{repository: baseRepo},
+		CityRepository: &CityRepository{repository: baseRepo},
+		PersonRepository: &PersonRepository{repository: baseRepo},
+		ApiKeyRepository: &ApiKeyRepository{repository: baseRepo},
+		CityPopulationRepository: &CityPopulationRepository{repository: baseRepo},
 	}, initTransact(baseRepo)
 }
 
 type repository struct {
-	db *sql.DB
-	tx *sql.Tx
+	db    *sql.DB
+	tx    *sql.Tx
+	ctx   context.Context
+	hooks Hooks
+	now   func() time.Time
 }
 
 func (r repository) prepare(query string) (*sql.Stmt, error) {
@@ -44,6 +98,57 @@ func (r repository) prepare(query string) (*sql.Stmt, error) {
 	}
 }
 
+// ErrStaleEntity is returned by Save when updating an entity of a table with a version column, if the row was changed or
+// deleted since the entity was fetched
+var ErrStaleEntity = errors.New("entity is stale: the row was changed or deleted since it was fetched")
+
+// BeforeSaver can be implemented by entities to validate or normalize them before they are inserted or updated by
+// Save. Returning an error aborts the Save.
+type BeforeSaver interface {
+	BeforeSave(ctx context.Context) error
+}
+
+// AfterFetcher can be implemented by entities to be called after they are fetched by FetchOne or Search. Returning an
+// error aborts the fetch.
+type AfterFetcher interface {
+	AfterFetch() error
+}
+
+// currentContext returns the Context of the current transaction, or context.Background() outside of a transaction
+func (r repository) currentContext() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// observe calls the BeforeQuery hook for a statement, and returns a func to call the AfterQuery hook once the statement
+// is done
+func (r repository) observe(table, operation, query string, args []interface{}) func(rowsAffected int64, err error) {
+	e := QueryEvent{
+		Table:     table,
+		Operation: operation,
+		Query:     query,
+		Args:      args,
+	}
+
+	ctx := r.currentContext()
+	if r.hooks.BeforeQuery != nil {
+		ctx = r.hooks.BeforeQuery(ctx, e)
+	}
+
+	start := time.Now()
+	return func(rowsAffected int64, err error) {
+		if r.hooks.AfterQuery == nil {
+			return
+		}
+		e.Duration = time.Since(start)
+		e.RowsAffected = rowsAffected
+		e.Err = err
+		r.hooks.AfterQuery(ctx, e)
+	}
+}
+
 func initTransact(r *repository) TransactFunc {
 	return func(f func() error, options ...TransactOptions) (err error) {
 		var opts *sql.TxOptions
@@ -55,19 +160,25 @@ func initTransact(r *repository) TransactFunc {
 			}
 		}
 		r.tx, err = r.db.BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
+		r.ctx = ctx
 		defer func() {
 			if err != nil {
-				err = r.tx.Rollback()
+				// keep the error which caused the rollback, it's more useful than a failed rollback
+				if rbErr := r.tx.Rollback(); rbErr != nil {
+					err = errors.Join(err, rbErr)
+				}
 			} else {
 				err = r.tx.Commit()
 			}
 
 			r.tx = nil
+			r.ctx = nil
 		}()
 
-		if err == nil {
-			err = f()
-		}
+		err = f()
 
 		return
 	}
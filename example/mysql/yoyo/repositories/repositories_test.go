@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/geo"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/nullable"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/city"
+)
+
+// now is the time given to the repositories by WithClock
+var now = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newMock returns a sqlmock database which matches queries exactly
+func newMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	return db, mock
+}
+
+// persisted returns a City as it would be after being fetched
+func persistedCity(c City) City {
+	p := c
+	c.persisted = &p
+	return c
+}
+
+type ctxKey struct{}
+
+// recorder records the QueryEvents given to its Hooks
+type recorder struct {
+	before, after []QueryEvent
+	// afterValues are the values of ctxKey in the contexts given to AfterQuery
+	afterValues []interface{}
+}
+
+func (r *recorder) hooks() Hooks {
+	return Hooks{
+		BeforeQuery: func(ctx context.Context, e QueryEvent) context.Context {
+			r.before = append(r.before, e)
+			return context.WithValue(ctx, ctxKey{}, e.Operation)
+		},
+		AfterQuery: func(ctx context.Context, e QueryEvent) {
+			r.after = append(r.after, e)
+			r.afterValues = append(r.afterValues, ctx.Value(ctxKey{}))
+		},
+	}
+}
+
+func TestHooks(t *testing.T) {
+	errDB := errors.New("connection refused")
+	updateQuery := fmt.Sprintf(updateCity, "WHERE id = ? AND version = ?")
+
+	tests := []struct {
+		name    string
+		run     func(r Repositories) error
+		expect  func(mock sqlmock.Sqlmock)
+		want    QueryEvent
+		wantErr error
+	}{
+		{
+			name: "insert",
+			run: func(r Repositories) error {
+				_, err := r.CityRepository.Save(City{Name: "Oslo"})
+				return err
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(insertCity).ExpectExec().WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+			want: QueryEvent{
+				Table:        "city",
+				Operation:    "insert",
+				Query:        insertCity,
+				Args:         []interface{}{"Oslo", nullable.JSON(nil), geo.Shape[geo.Point]{}, uint32(0), now, now},
+				RowsAffected: 1,
+			},
+		},
+		{
+			name: "update",
+			run: func(r Repositories) error {
+				_, err := r.CityRepository.Save(persistedCity(City{Id: 7, Name: "Oslo", Version: 2, CreatedAt: now}))
+				return err
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(updateQuery).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: QueryEvent{
+				Table:     "city",
+				Operation: "update",
+				Query:     updateQuery,
+				Args: []interface{}{uint32(7), "Oslo", nullable.JSON(nil), geo.Shape[geo.Point]{}, uint32(3), now, now,
+					uint32(7), uint32(2)},
+				RowsAffected: 1,
+			},
+		},
+		{
+			name: "delete",
+			run: func(r Repositories) error {
+				return r.CityRepository.Delete(city.Id(7))
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("DELETE FROM city WHERE id = ?;").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			want: QueryEvent{
+				Table:        "city",
+				Operation:    "delete",
+				Query:        "DELETE FROM city WHERE id = ?;",
+				Args:         []interface{}{uint32(7)},
+				RowsAffected: 2,
+			},
+		},
+		{
+			name: "select",
+			run: func(r Repositories) error {
+				_, err := r.CityRepository.FetchOne(city.Name("Oslo"))
+				return err
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(fmt.Sprintf(selectCity, "WHERE name = ?")).ExpectQuery().
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "metadata", "location", "version", "created_at", "updated_at"}).
+						AddRow(7, "Oslo", nil, nil, 2, now, now))
+			},
+			want: QueryEvent{
+				Table:     "city",
+				Operation: "select",
+				Query:     fmt.Sprintf(selectCity, "WHERE name = ?"),
+				Args:      []interface{}{"Oslo"},
+			},
+		},
+		{
+			name: "failed insert",
+			run: func(r Repositories) error {
+				_, err := r.CityRepository.Save(City{Name: "Oslo"})
+				return err
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(insertCity).ExpectExec().WillReturnError(errDB)
+			},
+			want: QueryEvent{
+				Table:     "city",
+				Operation: "insert",
+				Query:     insertCity,
+				Args:      []interface{}{"Oslo", nullable.JSON(nil), geo.Shape[geo.Point]{}, uint32(0), now, now},
+				Err:       errDB,
+			},
+			wantErr: errDB,
+		},
+		{
+			name: "failed prepare",
+			run: func(r Repositories) error {
+				return r.CityRepository.Delete(city.Id(7))
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("DELETE FROM city WHERE id = ?;").WillReturnError(errDB)
+			},
+			want: QueryEvent{
+				Table:     "city",
+				Operation: "delete",
+				Query:     "DELETE FROM city WHERE id = ?;",
+				Args:      []interface{}{uint32(7)},
+				Err:       errDB,
+			},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMock(t)
+			tt.expect(mock)
+
+			rec := &recorder{}
+			r, _ := InitRepositories(db, WithHooks(rec.hooks()), WithClock(func() time.Time { return now }))
+
+			if err := tt.run(r); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(rec.before) != 1 || len(rec.after) != 1 {
+				t.Fatalf("BeforeQuery called %d times and AfterQuery %d times, want once each", len(rec.before), len(rec.after))
+			}
+
+			before, after := rec.before[0], rec.after[0]
+			if after.Duration <= 0 {
+				t.Errorf("AfterQuery Duration = %s, want more than 0", after.Duration)
+			}
+			if !errors.Is(after.Err, tt.want.Err) {
+				t.Errorf("AfterQuery Err = %v, want %v", after.Err, tt.want.Err)
+			}
+			after.Duration, after.Err, tt.want.Err = 0, nil, nil
+			if !reflect.DeepEqual(after, tt.want) {
+				t.Errorf("AfterQuery event =\n%#v\nwant\n%#v", after, tt.want)
+			}
+
+			tt.want.RowsAffected = 0
+			if !reflect.DeepEqual(before, tt.want) {
+				t.Errorf("BeforeQuery event =\n%#v\nwant\n%#v", before, tt.want)
+			}
+
+			if rec.afterValues[0] != tt.want.Operation {
+				t.Errorf("AfterQuery context value = %v, want the one from BeforeQuery, %s", rec.afterValues[0], tt.want.Operation)
+			}
+		})
+	}
+}
+
+func TestHooks_transactionContext(t *testing.T) {
+	db, mock := newMock(t)
+	mock.ExpectBegin()
+	mock.ExpectPrepare("DELETE FROM city WHERE id = ?;").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	var got interface{}
+	r, transact := InitRepositories(db, WithHooks(Hooks{
+		BeforeQuery: func(ctx context.Context, e QueryEvent) context.Context {
+			got = ctx.Value(ctxKey{})
+			return ctx
+		},
+	}))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	err := transact(func() error {
+		return r.CityRepository.Delete(city.Id(7))
+	}, TransactOptions{Context: ctx})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got != "request" {
+		t.Errorf("BeforeQuery context value = %v, want the TransactOptions Context's", got)
+	}
+}
+
+func TestHooks_search(t *testing.T) {
+	errRows := errors.New("connection reset")
+	tests := []struct {
+		name    string
+		rows    func() *sqlmock.Rows
+		wantErr error
+	}{
+		{
+			name: "all rows read",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "name", "metadata", "location", "version", "created_at", "updated_at"}).
+					AddRow(7, "Oslo", nil, nil, 2, now, now).
+					AddRow(8, "Oslo", nil, nil, 1, now, now)
+			},
+		},
+		{
+			name: "rows error",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "name", "metadata", "location", "version", "created_at", "updated_at"}).
+					AddRow(7, "Oslo", nil, nil, 2, now, now).
+					RowError(0, errRows)
+			},
+			wantErr: errRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMock(t)
+			mock.ExpectPrepare(fmt.Sprintf(selectCity, "WHERE name = ?")).ExpectQuery().WillReturnRows(tt.rows())
+
+			rec := &recorder{}
+			r, _ := InitRepositories(db, WithHooks(rec.hooks()))
+
+			cs, err := r.CityRepository.Search(city.Name("Oslo"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(rec.after) != 0 {
+				t.Fatalf("AfterQuery called %d times before the rows were read, want none", len(rec.after))
+			}
+
+			for cs.Next() {
+				var c City
+				if err := cs.Scan(&c); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			cs.Next()
+
+			if len(rec.after) != 1 {
+				t.Fatalf("AfterQuery called %d times after the rows were read, want once", len(rec.after))
+			}
+			if after := rec.after[0]; after.Operation != "select" || !errors.Is(after.Err, tt.wantErr) {
+				t.Errorf("AfterQuery event = %+v, want a select with Err %v", after, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/api_key"
+	yoyouuid "github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/uuid"
+)
+
+const (
+	insertApiKey = "INSERT INTO api_key" +
+		" (id, label, replaced_by, fk_person_id) " +
+		" VALUES (?, ?, ?, ?);"
+	updateApiKey = "UPDATE api_key" +
+		" SET id = ?, label = ?, replaced_by = ?, fk_person_id = ? %s;"
+	selectApiKey = "SELECT id, label, replaced_by, fk_person_id FROM api_key %s;"
+	deleteApiKey = "DELETE FROM api_key %s;"
+)
+
+type ApiKeyRepository struct {
+	*repository
+	hooks ApiKeyHooks
+}
+
+// ApiKeyHooks are lifecycle hooks for ApiKey entities, registered with ApiKeyRepository.SetHooks. Any of them may
+// be nil. BeforeSave and AfterFetch are called after the entity's own BeforeSave and AfterFetch methods, if it
+// implements BeforeSaver or AfterFetcher. Returning an error aborts the operation, which rolls back the transaction when
+// the error is returned from a TransactFunc.
+type ApiKeyHooks struct {
+	BeforeSave   func(ctx context.Context, e *ApiKey) error
+	AfterFetch   func(e *ApiKey) error
+	BeforeDelete func(ctx context.Context, query api_key.Query) error
+}
+
+// SetHooks registers lifecycle hooks for ApiKey entities, replacing any previously registered hooks
+func (r *ApiKeyRepository) SetHooks(h ApiKeyHooks) {
+	r.hooks = h
+}
+
+// beforeSave calls the BeforeSave method of the ApiKey if it implements BeforeSaver, followed by the registered hook
+func (r *ApiKeyRepository) beforeSave(e *ApiKey) error {
+	if s, ok := interface{}(e).(BeforeSaver); ok {
+		if err := s.BeforeSave(r.currentContext()); err != nil {
+			return err
+		}
+	}
+	if r.hooks.BeforeSave != nil {
+		return r.hooks.BeforeSave(r.currentContext(), e)
+	}
+	return nil
+}
+
+func (r *ApiKeyRepository) FetchOne(query api_key.Query) (ent ApiKey, err error) {
+	var stmt *sql.Stmt
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	conditions, args := query.SQL()
+	queryString := fmt.Sprintf(selectApiKey, conditions)
+	done := r.observe("api_key", "select", queryString, args)
+	defer func() { done(0, err) }()
+
+	stmt, err = r.prepare(queryString)
+	if err != nil {
+		return
+	}
+
+	row := stmt.QueryRow(args...)
+
+	err = row.Scan(&ent.Id, &ent.Label, &ent.ReplacedBy, &ent.PersonId)
+	if err != nil {
+		return ent, err
+	}
+
+	persisted := ent
+	ent.persisted = &persisted
+
+	err = ent.afterFetch(r.hooks.AfterFetch)
+
+	return ent, err
+}
+
+func (r *ApiKeyRepository) Search(query api_key.Query) (es ApiKeys, err error) {
+	var stmt *sql.Stmt
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	conditions, args := query.SQL()
+	queryString := fmt.Sprintf(selectApiKey, conditions)
+	done := r.observe("api_key", "select", queryString, args)
+	defer func() {
+		// when not in a transaction, the rows are read by the caller, so es observes the query once they've all been read
+		if err != nil || es.rs == nil {
+			done(0, err)
+		}
+	}()
+
+	stmt, err = r.prepare(queryString)
+	if err != nil {
+		return es, err
+	}
+
+	// If we're in a transaction, take the full result set into memory to free up the sql connection's buffer
+	if r.tx != nil {
+		var rs *sql.Rows
+		rs, err = stmt.Query(args...)
+		if err != nil {
+			return es, err
+		}
+
+		for rs.Next() {
+			var ent ApiKey
+			err = rs.Scan(&ent.Id, &ent.Label, &ent.ReplacedBy, &ent.PersonId)
+			if err != nil {
+				return es, err
+			}
+			err = ent.afterFetch(r.hooks.AfterFetch)
+			if err != nil {
+				return es, err
+			}
+			es.es = append(es.es, ent)
+		}
+
+		es.i = -1
+
+		return es, nil
+	}
+
+	es.afterFetch = r.hooks.AfterFetch
+	es.done = done
+	es.rs, err = stmt.Query(args...)
+
+	return es, err
+}
+
+func (r *ApiKeyRepository) Save(in ApiKey) (ApiKey, error) {
+	if err := r.beforeSave(&in); err != nil {
+		return ApiKey{}, err
+	}
+
+	if in.persisted == nil {
+		return r.insert(in)
+	} else {
+		return r.update(in)
+	}
+}
+
+func (r *ApiKeyRepository) insert(in ApiKey) (e ApiKey, err error) {
+	var (
+		stmt *sql.Stmt
+		res  sql.Result
+	)
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	if in.Id.IsZero() {
+		in.Id = yoyouuid.New()
+	}
+
+	var rowsAffected int64
+	done := r.observe("api_key", "insert", insertApiKey, []interface{}{in.Id, in.Label, in.ReplacedBy, in.PersonId})
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(insertApiKey)
+	if err != nil {
+		return e, err
+	}
+
+	res, err = stmt.Exec(in.Id, in.Label, in.ReplacedBy, in.PersonId)
+	if err != nil {
+		return e, err
+	}
+	rowsAffected, _ = res.RowsAffected()
+
+	_ = res
+	e = in
+
+	in = e
+	e.persisted = &in
+
+	return e, err
+}
+
+func (r *ApiKeyRepository) update(in ApiKey) (e ApiKey, err error) {
+	var (
+		stmt *sql.Stmt
+	)
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	q, args := api_key.Query{}.
+		Id(in.persisted.Id).
+		SQL()
+
+	var (
+		res          sql.Result
+		rowsAffected int64
+		queryString  = fmt.Sprintf(updateApiKey, q)
+		fields       = []interface{}{in.Id, in.Label, in.ReplacedBy, in.PersonId}
+	)
+	args = append(fields, args...)
+	done := r.observe("api_key", "update", queryString, args)
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(queryString)
+	if err != nil {
+		return e, err
+	}
+
+	res, err = stmt.Exec(args...)
+	if err != nil {
+		return e, err
+	}
+	rowsAffected, _ = res.RowsAffected()
+
+	e = in
+	in = e
+	e.persisted = &in
+
+	return e, err
+}
+
+func (r *ApiKeyRepository) Delete(query api_key.Query) error {
+	if err := r.beforeDelete(query); err != nil {
+		return err
+	}
+
+	conditions, args := query.SQL()
+	return r.exec("delete", fmt.Sprintf(deleteApiKey, conditions), args)
+}
+
+func (r *ApiKeyRepository) beforeDelete(query api_key.Query) error {
+	if r.hooks.BeforeDelete != nil {
+		return r.hooks.BeforeDelete(r.currentContext(), query)
+	}
+	return nil
+}
+
+// exec prepares and executes a statement which doesn't return rows
+func (r *ApiKeyRepository) exec(operation, queryString string, args []interface{}) (err error) {
+	var stmt *sql.Stmt
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	var rowsAffected int64
+	done := r.observe("api_key", operation, queryString, args)
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(queryString)
+	if err != nil {
+		return err
+	}
+
+	var res sql.Result
+	res, err = stmt.Exec(args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ = res.RowsAffected()
+
+	return nil
+}
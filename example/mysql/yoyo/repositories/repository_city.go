@@ -3,6 +3,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -11,16 +12,45 @@ import (
 
 const (
 	insertCity = "INSERT INTO city" +
-		" (name) " +
-		" VALUES (?, ?);"
+		" (name, metadata, location, version, created_at, updated_at) " +
+		" VALUES (?, ?, ?, ?, ?, ?);"
 	updateCity = "UPDATE city" +
-		" SET id = ?, name = ? %s;"
-	selectCity = "SELECT id, name FROM city %s;"
+		" SET id = ?, name = ?, metadata = ?, location = ?, version = ?, created_at = ?, updated_at = ? %s;"
+	selectCity = "SELECT id, name, metadata, location, version, created_at, updated_at FROM city %s;"
 	deleteCity = "DELETE FROM city %s;"
 )
 
 type CityRepository struct {
 	*repository
+	hooks CityHooks
+}
+
+// CityHooks are lifecycle hooks for City entities, registered with CityRepository.SetHooks. Any of them may
+// be nil. BeforeSave and AfterFetch are called after the entity's own BeforeSave and AfterFetch methods, if it
+// implements BeforeSaver or AfterFetcher. Returning an error aborts the operation, which rolls back the transaction when
+// the error is returned from a TransactFunc.
+type CityHooks struct {
+	BeforeSave   func(ctx context.Context, e *City) error
+	AfterFetch   func(e *City) error
+	BeforeDelete func(ctx context.Context, query city.Query) error
+}
+
+// SetHooks registers lifecycle hooks for City entities, replacing any previously registered hooks
+func (r *CityRepository) SetHooks(h CityHooks) {
+	r.hooks = h
+}
+
+// beforeSave calls the BeforeSave method of the City if it implements BeforeSaver, followed by the registered hook
+func (r *CityRepository) beforeSave(e *City) error {
+	if s, ok := interface{}(e).(BeforeSaver); ok {
+		if err := s.BeforeSave(r.currentContext()); err != nil {
+			return err
+		}
+	}
+	if r.hooks.BeforeSave != nil {
+		return r.hooks.BeforeSave(r.currentContext(), e)
+	}
+	return nil
 }
 
 func (r *CityRepository) FetchOne(query city.Query) (ent City, err error) {
@@ -33,18 +63,27 @@ func (r *CityRepository) FetchOne(query city.Query) (ent City, err error) {
 	}()
 
 	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(selectCity, conditions))
+	queryString := fmt.Sprintf(selectCity, conditions)
+	done := r.observe("city", "select", queryString, args)
+	defer func() { done(0, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return
 	}
 
 	row := stmt.QueryRow(args...)
 
-	err = row.Scan(&ent.Id, &ent.Name)
+	err = row.Scan(&ent.Id, &ent.Name, &ent.Metadata, &ent.Location, &ent.Version, &ent.CreatedAt, &ent.UpdatedAt)
+	if err != nil {
+		return ent, err
+	}
 
 	persisted := ent
 	ent.persisted = &persisted
 
+	err = ent.afterFetch(r.hooks.AfterFetch)
+
 	return ent, err
 }
 
@@ -58,7 +97,16 @@ func (r *CityRepository) Search(query city.Query) (es Citys, err error) {
 	}()
 
 	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(selectCity, conditions))
+	queryString := fmt.Sprintf(selectCity, conditions)
+	done := r.observe("city", "select", queryString, args)
+	defer func() {
+		// when not in a transaction, the rows are read by the caller, so es observes the query once they've all been read
+		if err != nil || es.rs == nil {
+			done(0, err)
+		}
+	}()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return es, err
 	}
@@ -73,7 +121,11 @@ func (r *CityRepository) Search(query city.Query) (es Citys, err error) {
 
 		for rs.Next() {
 			var ent City
-			err = rs.Scan(&ent.Id, &ent.Name)
+			err = rs.Scan(&ent.Id, &ent.Name, &ent.Metadata, &ent.Location, &ent.Version, &ent.CreatedAt, &ent.UpdatedAt)
+			if err != nil {
+				return es, err
+			}
+			err = ent.afterFetch(r.hooks.AfterFetch)
 			if err != nil {
 				return es, err
 			}
@@ -85,12 +137,18 @@ func (r *CityRepository) Search(query city.Query) (es Citys, err error) {
 		return es, nil
 	}
 
+	es.afterFetch = r.hooks.AfterFetch
+	es.done = done
 	es.rs, err = stmt.Query(args...)
 
 	return es, err
 }
 
 func (r *CityRepository) Save(in City) (City, error) {
+	if err := r.beforeSave(&in); err != nil {
+		return City{}, err
+	}
+
 	if in.persisted == nil {
 		return r.insert(in)
 	} else {
@@ -110,15 +168,24 @@ func (r *CityRepository) insert(in City) (e City, err error) {
 		}
 	}()
 
+	now := r.now()
+	in.CreatedAt = now
+	in.UpdatedAt = now
+
+	var rowsAffected int64
+	done := r.observe("city", "insert", insertCity, []interface{}{in.Name, in.Metadata, in.Location, in.Version, in.CreatedAt, in.UpdatedAt})
+	defer func() { done(rowsAffected, err) }()
+
 	stmt, err = r.prepare(insertCity)
 	if err != nil {
 		return e, err
 	}
 
-	res, err = stmt.Exec(in.Id, in.Name)
+	res, err = stmt.Exec(in.Name, in.Metadata, in.Location, in.Version, in.CreatedAt, in.UpdatedAt)
 	if err != nil {
 		return e, err
 	}
+	rowsAffected, _ = res.RowsAffected()
 
 	e = in
 	var eid int64
@@ -147,18 +214,38 @@ func (r *CityRepository) update(in City) (e City, err error) {
 
 	q, args := city.Query{}.
 		Id(in.persisted.Id).
+		Version(in.persisted.Version).
 		SQL()
 
-	stmt, err = r.prepare(fmt.Sprintf(updateCity, q))
+	now := r.now()
+	in.UpdatedAt = now
+
+	in.Version = in.persisted.Version + 1
+
+	var (
+		res          sql.Result
+		rowsAffected int64
+		queryString  = fmt.Sprintf(updateCity, q)
+		fields       = []interface{}{in.Id, in.Name, in.Metadata, in.Location, in.Version, in.CreatedAt, in.UpdatedAt}
+	)
+	args = append(fields, args...)
+	done := r.observe("city", "update", queryString, args)
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return e, err
 	}
 
-	fields := []interface{}{in.Id, in.Name}
-	_, err = stmt.Exec(append(fields, args...)...)
+	res, err = stmt.Exec(args...)
 	if err != nil {
 		return e, err
 	}
+	rowsAffected, _ = res.RowsAffected()
+
+	if rowsAffected == 0 {
+		return e, ErrStaleEntity
+	}
 
 	e = in
 	in = e
@@ -167,7 +254,24 @@ func (r *CityRepository) update(in City) (e City, err error) {
 	return e, err
 }
 
-func (r *CityRepository) Delete(query city.Query) (err error) {
+func (r *CityRepository) Delete(query city.Query) error {
+	if err := r.beforeDelete(query); err != nil {
+		return err
+	}
+
+	conditions, args := query.SQL()
+	return r.exec("delete", fmt.Sprintf(deleteCity, conditions), args)
+}
+
+func (r *CityRepository) beforeDelete(query city.Query) error {
+	if r.hooks.BeforeDelete != nil {
+		return r.hooks.BeforeDelete(r.currentContext(), query)
+	}
+	return nil
+}
+
+// exec prepares and executes a statement which doesn't return rows
+func (r *CityRepository) exec(operation, queryString string, args []interface{}) (err error) {
 	var stmt *sql.Stmt
 	// ensure the *sql.Stmt is closed after we're done with it
 	defer func() {
@@ -176,13 +280,21 @@ func (r *CityRepository) Delete(query city.Query) (err error) {
 		}
 	}()
 
-	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(deleteCity, conditions))
+	var rowsAffected int64
+	done := r.observe("city", operation, queryString, args)
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(args...)
+	var res sql.Result
+	res, err = stmt.Exec(args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ = res.RowsAffected()
 
-	return err
+	return nil
 }
@@ -0,0 +1,120 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/city_population"
+)
+
+const selectCityPopulation = "SELECT city_id, city_name, population FROM city_population %s;"
+
+// CityPopulationRepository is read-only, because city_population is a view
+type CityPopulationRepository struct {
+	*repository
+	hooks CityPopulationHooks
+}
+
+// CityPopulationHooks are lifecycle hooks for CityPopulation entities, registered with CityPopulationRepository.SetHooks. AfterFetch
+// may be nil, and is called after the entity's own AfterFetch method, if it implements AfterFetcher.
+type CityPopulationHooks struct {
+	AfterFetch func(e *CityPopulation) error
+}
+
+// SetHooks registers lifecycle hooks for CityPopulation entities, replacing any previously registered hooks
+func (r *CityPopulationRepository) SetHooks(h CityPopulationHooks) {
+	r.hooks = h
+}
+
+func (r *CityPopulationRepository) FetchOne(query city_population.Query) (ent CityPopulation, err error) {
+	var stmt *sql.Stmt
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	conditions, args := query.SQL()
+	queryString := fmt.Sprintf(selectCityPopulation, conditions)
+	done := r.observe("city_population", "select", queryString, args)
+	defer func() { done(0, err) }()
+
+	stmt, err = r.prepare(queryString)
+	if err != nil {
+		return
+	}
+
+	row := stmt.QueryRow(args...)
+
+	err = row.Scan(&ent.CityId, &ent.CityName, &ent.Population)
+	if err != nil {
+		return ent, err
+	}
+
+	persisted := ent
+	ent.persisted = &persisted
+
+	err = ent.afterFetch(r.hooks.AfterFetch)
+
+	return ent, err
+}
+
+func (r *CityPopulationRepository) Search(query city_population.Query) (es CityPopulations, err error) {
+	var stmt *sql.Stmt
+	// ensure the *sql.Stmt is closed after we're done with it
+	defer func() {
+		if stmt != nil && r.tx == nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	conditions, args := query.SQL()
+	queryString := fmt.Sprintf(selectCityPopulation, conditions)
+	done := r.observe("city_population", "select", queryString, args)
+	defer func() {
+		// when not in a transaction, the rows are read by the caller, so es observes the query once they've all been read
+		if err != nil || es.rs == nil {
+			done(0, err)
+		}
+	}()
+
+	stmt, err = r.prepare(queryString)
+	if err != nil {
+		return es, err
+	}
+
+	// If we're in a transaction, take the full result set into memory to free up the sql connection's buffer
+	if r.tx != nil {
+		var rs *sql.Rows
+		rs, err = stmt.Query(args...)
+		if err != nil {
+			return es, err
+		}
+
+		for rs.Next() {
+			var ent CityPopulation
+			err = rs.Scan(&ent.CityId, &ent.CityName, &ent.Population)
+			if err != nil {
+				return es, err
+			}
+			err = ent.afterFetch(r.hooks.AfterFetch)
+			if err != nil {
+				return es, err
+			}
+			es.es = append(es.es, ent)
+		}
+
+		es.i = -1
+
+		return es, nil
+	}
+
+	es.afterFetch = r.hooks.AfterFetch
+	es.done = done
+	es.rs, err = stmt.Query(args...)
+
+	return es, err
+}
@@ -3,6 +3,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -21,6 +22,35 @@ const (
 
 type NoPkTableRepository struct {
 	*repository
+	hooks NoPkTableHooks
+}
+
+// NoPkTableHooks are lifecycle hooks for NoPkTable entities, registered with NoPkTableRepository.SetHooks. Any of them may
+// be nil. BeforeSave and AfterFetch are called after the entity's own BeforeSave and AfterFetch methods, if it
+// implements BeforeSaver or AfterFetcher. Returning an error aborts the operation, which rolls back the transaction when
+// the error is returned from a TransactFunc.
+type NoPkTableHooks struct {
+	BeforeSave   func(ctx context.Context, e *NoPkTable) error
+	AfterFetch   func(e *NoPkTable) error
+	BeforeDelete func(ctx context.Context, query no_pk_table.Query) error
+}
+
+// SetHooks registers lifecycle hooks for NoPkTable entities, replacing any previously registered hooks
+func (r *NoPkTableRepository) SetHooks(h NoPkTableHooks) {
+	r.hooks = h
+}
+
+// beforeSave calls the BeforeSave method of the NoPkTable if it implements BeforeSaver, followed by the registered hook
+func (r *NoPkTableRepository) beforeSave(e *NoPkTable) error {
+	if s, ok := interface{}(e).(BeforeSaver); ok {
+		if err := s.BeforeSave(r.currentContext()); err != nil {
+			return err
+		}
+	}
+	if r.hooks.BeforeSave != nil {
+		return r.hooks.BeforeSave(r.currentContext(), e)
+	}
+	return nil
 }
 
 func (r *NoPkTableRepository) FetchOne(query no_pk_table.Query) (ent NoPkTable, err error) {
@@ -33,7 +63,11 @@ func (r *NoPkTableRepository) FetchOne(query no_pk_table.Query) (ent NoPkTable,
 	}()
 
 	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(selectNoPkTable, conditions))
+	queryString := fmt.Sprintf(selectNoPkTable, conditions)
+	done := r.observe("no_pk_table", "select", queryString, args)
+	defer func() { done(0, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return
 	}
@@ -41,10 +75,15 @@ func (r *NoPkTableRepository) FetchOne(query no_pk_table.Query) (ent NoPkTable,
 	row := stmt.QueryRow(args...)
 
 	err = row.Scan(&ent.Col, &ent.Col2)
+	if err != nil {
+		return ent, err
+	}
 
 	persisted := ent
 	ent.persisted = &persisted
 
+	err = ent.afterFetch(r.hooks.AfterFetch)
+
 	return ent, err
 }
 
@@ -58,7 +97,16 @@ func (r *NoPkTableRepository) Search(query no_pk_table.Query) (es NoPkTables, er
 	}()
 
 	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(selectNoPkTable, conditions))
+	queryString := fmt.Sprintf(selectNoPkTable, conditions)
+	done := r.observe("no_pk_table", "select", queryString, args)
+	defer func() {
+		// when not in a transaction, the rows are read by the caller, so es observes the query once they've all been read
+		if err != nil || es.rs == nil {
+			done(0, err)
+		}
+	}()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return es, err
 	}
@@ -77,6 +125,10 @@ func (r *NoPkTableRepository) Search(query no_pk_table.Query) (es NoPkTables, er
 			if err != nil {
 				return es, err
 			}
+			err = ent.afterFetch(r.hooks.AfterFetch)
+			if err != nil {
+				return es, err
+			}
 			es.es = append(es.es, ent)
 		}
 
@@ -85,6 +137,8 @@ func (r *NoPkTableRepository) Search(query no_pk_table.Query) (es NoPkTables, er
 		return es, nil
 	}
 
+	es.afterFetch = r.hooks.AfterFetch
+	es.done = done
 	es.rs, err = stmt.Query(args...)
 
 	return es, err
@@ -101,15 +155,30 @@ func (r *NoPkTableRepository) Save(in NoPkTable) (e NoPkTable, err error) {
 		}
 	}()
 
+	if err = r.beforeSave(&in); err != nil {
+		return e, err
+	}
+
+	var (
+		res          sql.Result
+		rowsAffected int64
+	)
+	done := r.observe("no_pk_table", "insert", insertNoPkTable, []interface{}{in.Col, in.Col2})
+	defer func() { done(rowsAffected, err) }()
+
 	stmt, err = r.prepare(insertNoPkTable)
 	if err != nil {
 		return e, err
 	}
 
-	_, err = stmt.Exec(in.Col, in.Col2)
+	res, err = stmt.Exec(in.Col, in.Col2)
 	if err != nil {
 		return e, err
 	}
+	rowsAffected, _ = res.RowsAffected()
+
+	_ = res
+	e = in
 
 	in = e
 	e.persisted = &in
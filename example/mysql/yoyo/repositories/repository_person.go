@@ -3,6 +3,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -11,16 +12,47 @@ import (
 
 const (
 	insertPerson = "INSERT INTO person" +
-		" (someBinary, name, nickname, favorite_color, age, fk_city_id) " +
-		" VALUES (?, ?, ?, ?, ?, ?, ?);"
+		" (someBinary, name, nickname, favorite_color, age, preferences, deleted_at, fk_city_id) " +
+		" VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
 	updatePerson = "UPDATE person" +
-		" SET id = ?, someBinary = ?, name = ?, nickname = ?, favorite_color = ?, age = ?, fk_city_id = ? %s;"
-	selectPerson = "SELECT id, someBinary, name, nickname, favorite_color, age, fk_city_id FROM person %s;"
+		" SET id = ?, someBinary = ?, name = ?, nickname = ?, favorite_color = ?, age = ?, preferences = ?, deleted_at = ?, fk_city_id = ? %s;"
+	selectPerson = "SELECT id, someBinary, name, nickname, favorite_color, age, preferences, display_name, deleted_at, fk_city_id FROM person %s;"
 	deletePerson = "DELETE FROM person %s;"
+	softDeletePerson = "UPDATE person SET deleted_at = ? %s;"
+	restorePerson    = "UPDATE person SET deleted_at = NULL %s;"
 )
 
 type PersonRepository struct {
 	*repository
+	hooks PersonHooks
+}
+
+// PersonHooks are lifecycle hooks for Person entities, registered with PersonRepository.SetHooks. Any of them may
+// be nil. BeforeSave and AfterFetch are called after the entity's own BeforeSave and AfterFetch methods, if it
+// implements BeforeSaver or AfterFetcher. Returning an error aborts the operation, which rolls back the transaction when
+// the error is returned from a TransactFunc.
+type PersonHooks struct {
+	BeforeSave   func(ctx context.Context, e *Person) error
+	AfterFetch   func(e *Person) error
+	BeforeDelete func(ctx context.Context, query person.Query) error
+}
+
+// SetHooks registers lifecycle hooks for Person entities, replacing any previously registered hooks
+func (r *PersonRepository) SetHooks(h PersonHooks) {
+	r.hooks = h
+}
+
+// beforeSave calls the BeforeSave method of the Person if it implements BeforeSaver, followed by the registered hook
+func (r *PersonRepository) beforeSave(e *Person) error {
+	if s, ok := interface{}(e).(BeforeSaver); ok {
+		if err := s.BeforeSave(r.currentContext()); err != nil {
+			return err
+		}
+	}
+	if r.hooks.BeforeSave != nil {
+		return r.hooks.BeforeSave(r.currentContext(), e)
+	}
+	return nil
 }
 
 func (r *PersonRepository) FetchOne(query person.Query) (ent Person, err error) {
@@ -33,18 +65,27 @@ func (r *PersonRepository) FetchOne(query person.Query) (ent Person, err error)
 	}()
 
 	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(selectPerson, conditions))
+	queryString := fmt.Sprintf(selectPerson, conditions)
+	done := r.observe("person", "select", queryString, args)
+	defer func() { done(0, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return
 	}
 
 	row := stmt.QueryRow(args...)
 
-	err = row.Scan(&ent.Id, &ent.SomeBinary, &ent.Name, &ent.Nickname, &ent.FavoriteColor, &ent.Age, &ent.CityId)
+	err = row.Scan(&ent.Id, &ent.SomeBinary, &ent.Name, &ent.Nickname, &ent.FavoriteColor, &ent.Age, &ent.Preferences, &ent.DisplayName, &ent.DeletedAt, &ent.CityId)
+	if err != nil {
+		return ent, err
+	}
 
 	persisted := ent
 	ent.persisted = &persisted
 
+	err = ent.afterFetch(r.hooks.AfterFetch)
+
 	return ent, err
 }
 
@@ -58,7 +99,16 @@ func (r *PersonRepository) Search(query person.Query) (es Persons, err error) {
 	}()
 
 	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(selectPerson, conditions))
+	queryString := fmt.Sprintf(selectPerson, conditions)
+	done := r.observe("person", "select", queryString, args)
+	defer func() {
+		// when not in a transaction, the rows are read by the caller, so es observes the query once they've all been read
+		if err != nil || es.rs == nil {
+			done(0, err)
+		}
+	}()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return es, err
 	}
@@ -73,7 +123,11 @@ func (r *PersonRepository) Search(query person.Query) (es Persons, err error) {
 
 		for rs.Next() {
 			var ent Person
-			err = rs.Scan(&ent.Id, &ent.SomeBinary, &ent.Name, &ent.Nickname, &ent.FavoriteColor, &ent.Age, &ent.CityId)
+			err = rs.Scan(&ent.Id, &ent.SomeBinary, &ent.Name, &ent.Nickname, &ent.FavoriteColor, &ent.Age, &ent.Preferences, &ent.DisplayName, &ent.DeletedAt, &ent.CityId)
+			if err != nil {
+				return es, err
+			}
+			err = ent.afterFetch(r.hooks.AfterFetch)
 			if err != nil {
 				return es, err
 			}
@@ -85,12 +139,18 @@ func (r *PersonRepository) Search(query person.Query) (es Persons, err error) {
 		return es, nil
 	}
 
+	es.afterFetch = r.hooks.AfterFetch
+	es.done = done
 	es.rs, err = stmt.Query(args...)
 
 	return es, err
 }
 
 func (r *PersonRepository) Save(in Person) (Person, error) {
+	if err := r.beforeSave(&in); err != nil {
+		return Person{}, err
+	}
+
 	if in.persisted == nil {
 		return r.insert(in)
 	} else {
@@ -110,15 +170,20 @@ func (r *PersonRepository) insert(in Person) (e Person, err error) {
 		}
 	}()
 
+	var rowsAffected int64
+	done := r.observe("person", "insert", insertPerson, []interface{}{in.SomeBinary, in.Name, in.Nickname, in.FavoriteColor, in.Age, in.Preferences, in.DeletedAt, in.CityId})
+	defer func() { done(rowsAffected, err) }()
+
 	stmt, err = r.prepare(insertPerson)
 	if err != nil {
 		return e, err
 	}
 
-	res, err = stmt.Exec(in.Id, in.SomeBinary, in.Name, in.Nickname, in.FavoriteColor, in.Age, in.CityId)
+	res, err = stmt.Exec(in.SomeBinary, in.Name, in.Nickname, in.FavoriteColor, in.Age, in.Preferences, in.DeletedAt, in.CityId)
 	if err != nil {
 		return e, err
 	}
+	rowsAffected, _ = res.RowsAffected()
 
 	e = in
 	var eid int64
@@ -147,18 +212,29 @@ func (r *PersonRepository) update(in Person) (e Person, err error) {
 
 	q, args := person.Query{}.
 		Id(in.persisted.Id).
+		WithDeleted().
 		SQL()
 
-	stmt, err = r.prepare(fmt.Sprintf(updatePerson, q))
+	var (
+		res          sql.Result
+		rowsAffected int64
+		queryString  = fmt.Sprintf(updatePerson, q)
+		fields       = []interface{}{in.Id, in.SomeBinary, in.Name, in.Nickname, in.FavoriteColor, in.Age, in.Preferences, in.DeletedAt, in.CityId}
+	)
+	args = append(fields, args...)
+	done := r.observe("person", "update", queryString, args)
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return e, err
 	}
 
-	fields := []interface{}{in.Id, in.SomeBinary, in.Name, in.Nickname, in.FavoriteColor, in.Age, in.CityId}
-	_, err = stmt.Exec(append(fields, args...)...)
+	res, err = stmt.Exec(args...)
 	if err != nil {
 		return e, err
 	}
+	rowsAffected, _ = res.RowsAffected()
 
 	e = in
 	in = e
@@ -167,7 +243,43 @@ func (r *PersonRepository) update(in Person) (e Person, err error) {
 	return e, err
 }
 
-func (r *PersonRepository) Delete(query person.Query) (err error) {
+// Delete soft deletes the rows matched by the query, by setting deleted_at. Soft deleted rows are excluded
+// from queries unless they use WithDeleted or OnlyDeleted.
+func (r *PersonRepository) Delete(query person.Query) error {
+	if err := r.beforeDelete(query); err != nil {
+		return err
+	}
+
+	conditions, args := query.SQL()
+	return r.exec("update", fmt.Sprintf(softDeletePerson, conditions), append([]interface{}{r.now()}, args...))
+}
+
+// HardDelete permanently deletes the rows matched by the query. Like any query, it excludes soft deleted rows unless
+// it uses WithDeleted or OnlyDeleted.
+func (r *PersonRepository) HardDelete(query person.Query) error {
+	if err := r.beforeDelete(query); err != nil {
+		return err
+	}
+
+	conditions, args := query.SQL()
+	return r.exec("delete", fmt.Sprintf(deletePerson, conditions), args)
+}
+
+// Restore clears deleted_at on the soft deleted rows matched by the query
+func (r *PersonRepository) Restore(query person.Query) error {
+	conditions, args := query.OnlyDeleted().SQL()
+	return r.exec("update", fmt.Sprintf(restorePerson, conditions), args)
+}
+
+func (r *PersonRepository) beforeDelete(query person.Query) error {
+	if r.hooks.BeforeDelete != nil {
+		return r.hooks.BeforeDelete(r.currentContext(), query)
+	}
+	return nil
+}
+
+// exec prepares and executes a statement which doesn't return rows
+func (r *PersonRepository) exec(operation, queryString string, args []interface{}) (err error) {
 	var stmt *sql.Stmt
 	// ensure the *sql.Stmt is closed after we're done with it
 	defer func() {
@@ -176,13 +288,21 @@ func (r *PersonRepository) Delete(query person.Query) (err error) {
 		}
 	}()
 
-	conditions, args := query.SQL()
-	stmt, err = r.prepare(fmt.Sprintf(deletePerson, conditions))
+	var rowsAffected int64
+	done := r.observe("person", operation, queryString, args)
+	defer func() { done(rowsAffected, err) }()
+
+	stmt, err = r.prepare(queryString)
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(args...)
+	var res sql.Result
+	res, err = stmt.Exec(args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ = res.RowsAffected()
 
-	return err
+	return nil
 }
@@ -0,0 +1,430 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/city"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/no_pk_table"
+	"github.com/yoyo-project/yoyo/example/mysql/yoyo/repositories/query/person"
+)
+
+var (
+	errNegative = errors.New("col must not be negative")
+	errHook     = errors.New("rejected by hook")
+)
+
+// fetched counts the calls to NoPkTable.AfterFetch
+var fetched int
+
+// BeforeSave implements BeforeSaver, to test that the repositories call it. It rejects a negative Col and derives Col2.
+func (e *NoPkTable) BeforeSave(context.Context) error {
+	if e.Col < 0 {
+		return errNegative
+	}
+	e.Col2 = e.Col * 10
+	return nil
+}
+
+// AfterFetch implements AfterFetcher, to test that the repositories call it
+func (e *NoPkTable) AfterFetch() error {
+	fetched++
+	return nil
+}
+
+func noPkTableRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"col", "col2"}).AddRow(1, 10).AddRow(2, 20)
+}
+
+func TestBeforeSave(t *testing.T) {
+	t.Run("entity method", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(insertNoPkTable).ExpectExec().WithArgs(3, 30).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r, _ := InitRepositories(db)
+		if _, err := r.NoPkTableRepository.Save(NoPkTable{Col: 3}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("entity method error aborts the save", func(t *testing.T) {
+		db, _ := newMock(t)
+
+		r, _ := InitRepositories(db)
+		if _, err := r.NoPkTableRepository.Save(NoPkTable{Col: -1}); !errors.Is(err, errNegative) {
+			t.Fatalf("error = %v, want %v", err, errNegative)
+		}
+	})
+
+	t.Run("registered hook runs after the entity method", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(insertNoPkTable).ExpectExec().WithArgs(3, 31).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r, _ := InitRepositories(db)
+		r.NoPkTableRepository.SetHooks(NoPkTableHooks{
+			BeforeSave: func(ctx context.Context, e *NoPkTable) error {
+				e.Col2++
+				return nil
+			},
+		})
+		if _, err := r.NoPkTableRepository.Save(NoPkTable{Col: 3}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("registered hook error aborts insert and update", func(t *testing.T) {
+		db, _ := newMock(t)
+
+		var saved []City
+		r, _ := InitRepositories(db)
+		r.CityRepository.SetHooks(CityHooks{
+			BeforeSave: func(ctx context.Context, e *City) error {
+				saved = append(saved, *e)
+				return errHook
+			},
+		})
+
+		if _, err := r.CityRepository.Save(City{Name: "Oslo"}); !errors.Is(err, errHook) {
+			t.Errorf("insert error = %v, want %v", err, errHook)
+		}
+		if _, err := r.CityRepository.Save(persistedCity(City{Id: 7, Name: "Oslo"})); !errors.Is(err, errHook) {
+			t.Errorf("update error = %v, want %v", err, errHook)
+		}
+		if len(saved) != 2 || saved[0].Name != "Oslo" || saved[1].Id != 7 {
+			t.Errorf("BeforeSave received %+v, want the inserted and the updated City", saved)
+		}
+	})
+
+	t.Run("error rolls back the transaction", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectBegin()
+		mock.ExpectPrepare("DELETE FROM city WHERE id = ?;").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		var got interface{}
+		r, transact := InitRepositories(db)
+		r.CityRepository.SetHooks(CityHooks{
+			BeforeSave: func(ctx context.Context, e *City) error {
+				got = ctx.Value(ctxKey{})
+				return errHook
+			},
+		})
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+		err := transact(func() error {
+			if err := r.CityRepository.Delete(city.Id(7)); err != nil {
+				return err
+			}
+			_, err := r.CityRepository.Save(City{Name: "Oslo"})
+			return err
+		}, TransactOptions{Context: ctx})
+		if !errors.Is(err, errHook) {
+			t.Fatalf("error = %v, want %v", err, errHook)
+		}
+
+		if got != "request" {
+			t.Errorf("BeforeSave context value = %v, want the TransactOptions Context's", got)
+		}
+	})
+}
+
+func TestAfterFetch(t *testing.T) {
+	query := "SELECT col, col2 FROM no_pk_table WHERE col = ?;"
+
+	tests := []struct {
+		name string
+		tx   bool
+		// fetch runs a FetchOne or Search, returning the fetched NoPkTables
+		fetch func(r Repositories) ([]NoPkTable, error)
+	}{
+		{
+			name: "FetchOne",
+			fetch: func(r Repositories) ([]NoPkTable, error) {
+				e, err := r.NoPkTableRepository.FetchOne(no_pk_table.Col(1))
+				return []NoPkTable{e}, err
+			},
+		},
+		{
+			name:  "Search",
+			fetch: search,
+		},
+		{
+			name:  "Search in a transaction",
+			tx:    true,
+			fetch: search,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMock(t)
+			if tt.tx {
+				mock.ExpectBegin()
+			}
+			mock.ExpectPrepare(query).ExpectQuery().WithArgs(1).WillReturnRows(noPkTableRows())
+			if tt.tx {
+				mock.ExpectCommit()
+			}
+
+			var hooked []NoPkTable
+			r, transact := InitRepositories(db)
+			r.NoPkTableRepository.SetHooks(NoPkTableHooks{
+				AfterFetch: func(e *NoPkTable) error {
+					hooked = append(hooked, *e)
+					return nil
+				},
+			})
+
+			fetched = 0
+			var es []NoPkTable
+			var err error
+			if tt.tx {
+				err = transact(func() (err error) {
+					es, err = tt.fetch(r)
+					return err
+				})
+			} else {
+				es, err = tt.fetch(r)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if fetched != len(es) {
+				t.Errorf("AfterFetch method called %d times, want %d", fetched, len(es))
+			}
+			if len(hooked) != len(es) {
+				t.Fatalf("AfterFetch hook called %d times, want %d", len(hooked), len(es))
+			}
+			for i := range es {
+				if hooked[i].Col != es[i].Col {
+					t.Errorf("AfterFetch hook received %+v, want %+v", hooked[i], es[i])
+				}
+			}
+		})
+	}
+
+	t.Run("error aborts the fetch", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(1).WillReturnRows(noPkTableRows())
+		mock.ExpectPrepare(query).ExpectQuery().WithArgs(1).WillReturnRows(noPkTableRows())
+
+		r, _ := InitRepositories(db)
+		r.NoPkTableRepository.SetHooks(NoPkTableHooks{
+			AfterFetch: func(e *NoPkTable) error {
+				return errHook
+			},
+		})
+
+		if _, err := r.NoPkTableRepository.FetchOne(no_pk_table.Col(1)); !errors.Is(err, errHook) {
+			t.Errorf("FetchOne error = %v, want %v", err, errHook)
+		}
+		if _, err := search(r); !errors.Is(err, errHook) {
+			t.Errorf("Search error = %v, want %v", err, errHook)
+		}
+	})
+}
+
+// search runs a Search for NoPkTables with col 1 and scans every result
+func search(r Repositories) (es []NoPkTable, err error) {
+	rs, err := r.NoPkTableRepository.Search(no_pk_table.Col(1))
+	if err != nil {
+		return nil, err
+	}
+	for rs.Next() {
+		var e NoPkTable
+		if err = rs.Scan(&e); err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+	return es, nil
+}
+
+func TestBeforeDelete(t *testing.T) {
+	t.Run("receives the query", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare("DELETE FROM city WHERE id = ?;").ExpectExec().WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		var got city.Query
+		r, _ := InitRepositories(db)
+		r.CityRepository.SetHooks(CityHooks{
+			BeforeDelete: func(ctx context.Context, query city.Query) error {
+				got = query
+				return nil
+			},
+		})
+
+		if err := r.CityRepository.Delete(city.Id(7)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if sql, _ := got.SQL(); sql != "WHERE id = ?" {
+			t.Errorf("BeforeDelete received a query for %q, want %q", sql, "WHERE id = ?")
+		}
+	})
+
+	t.Run("error aborts the delete", func(t *testing.T) {
+		db, _ := newMock(t)
+
+		r, _ := InitRepositories(db)
+		r.CityRepository.SetHooks(CityHooks{
+			BeforeDelete: func(ctx context.Context, query city.Query) error {
+				return errHook
+			},
+		})
+
+		if err := r.CityRepository.Delete(city.Id(7)); !errors.Is(err, errHook) {
+			t.Fatalf("error = %v, want %v", err, errHook)
+		}
+	})
+}
+
+func TestCityRepository_Save_version(t *testing.T) {
+	query := "UPDATE city SET id = ?, name = ?, metadata = ?, location = ?, version = ?, created_at = ?, updated_at = ? " +
+		"WHERE id = ? AND version = ?;"
+
+	t.Run("increments the version", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(query).ExpectExec().
+			WithArgs(7, "Bergen", nil, nil, 3, now, now, 7, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r, _ := InitRepositories(db, WithClock(func() time.Time { return now }))
+		in := persistedCity(City{Id: 7, Name: "Oslo", Version: 2, CreatedAt: now})
+		in.Name = "Bergen"
+		got, err := r.CityRepository.Save(in)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got.Version != 3 {
+			t.Errorf("Version = %d, want 3", got.Version)
+		}
+		if !got.HasChanged() {
+			t.Errorf("HasChanged() = false, want the saved City to be persisted")
+		}
+	})
+
+	t.Run("uses the persisted version", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(query).ExpectExec().
+			WithArgs(7, "Oslo", nil, nil, 3, now, now, 7, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r, _ := InitRepositories(db, WithClock(func() time.Time { return now }))
+		in := persistedCity(City{Id: 7, Name: "Oslo", Version: 2, CreatedAt: now})
+		in.Version = 10
+		if _, err := r.CityRepository.Save(in); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("stale entity", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(query).ExpectExec().
+			WithArgs(7, "Oslo", nil, nil, 3, now, now, 7, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		r, _ := InitRepositories(db, WithClock(func() time.Time { return now }))
+		_, err := r.CityRepository.Save(persistedCity(City{Id: 7, Name: "Oslo", Version: 2, CreatedAt: now}))
+		if !errors.Is(err, ErrStaleEntity) {
+			t.Fatalf("error = %v, want %v", err, ErrStaleEntity)
+		}
+	})
+}
+
+func TestPersonRepository_softDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(r *PersonRepository) error
+		wantOp   string
+		wantSQL  string
+		wantArgs []driver.Value
+	}{
+		{
+			name:     "Delete",
+			run:      func(r *PersonRepository) error { return r.Delete(person.Id(7)) },
+			wantOp:   "update",
+			wantSQL:  "UPDATE person SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL;",
+			wantArgs: []driver.Value{now, 7},
+		},
+		{
+			name:     "HardDelete",
+			run:      func(r *PersonRepository) error { return r.HardDelete(person.Id(7)) },
+			wantOp:   "delete",
+			wantSQL:  "DELETE FROM person WHERE id = ? AND deleted_at IS NULL;",
+			wantArgs: []driver.Value{7},
+		},
+		{
+			name:     "HardDelete with deleted",
+			run:      func(r *PersonRepository) error { return r.HardDelete(person.Id(7).WithDeleted()) },
+			wantOp:   "delete",
+			wantSQL:  "DELETE FROM person WHERE id = ?;",
+			wantArgs: []driver.Value{7},
+		},
+		{
+			name:     "Restore",
+			run:      func(r *PersonRepository) error { return r.Restore(person.Id(7)) },
+			wantOp:   "update",
+			wantSQL:  "UPDATE person SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;",
+			wantArgs: []driver.Value{7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMock(t)
+			mock.ExpectPrepare(tt.wantSQL).ExpectExec().WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			rec := &recorder{}
+			r, _ := InitRepositories(db, WithHooks(rec.hooks()), WithClock(func() time.Time { return now }))
+			if err := tt.run(r.PersonRepository); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(rec.after) != 1 || rec.after[0].Operation != tt.wantOp {
+				t.Errorf("AfterQuery events = %+v, want one %s", rec.after, tt.wantOp)
+			}
+		})
+	}
+}
+
+func TestSave_returnsTheSavedEntity(t *testing.T) {
+	t.Run("primary key without auto_increment", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(insertApiKey).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r, _ := InitRepositories(db)
+		got, err := r.ApiKeyRepository.Save(ApiKey{Label: "ci", PersonId: 7})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got.Id.IsZero() || got.Label != "ci" || got.PersonId != 7 {
+			t.Errorf("Save() = %+v, want the ApiKey with a new Id", got)
+		}
+		if !got.HasChanged() {
+			t.Errorf("HasChanged() = false, want the saved ApiKey to be persisted")
+		}
+	})
+
+	t.Run("no primary key", func(t *testing.T) {
+		db, mock := newMock(t)
+		mock.ExpectPrepare(insertNoPkTable).ExpectExec().WithArgs(3, 30).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r, _ := InitRepositories(db)
+		got, err := r.NoPkTableRepository.Save(NoPkTable{Col: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got.Col != 3 || got.Col2 != 30 {
+			t.Errorf("Save() = %+v, want the NoPkTable as saved", got)
+		}
+		if !got.HasChanged() {
+			t.Errorf("HasChanged() = false, want the saved NoPkTable to be persisted")
+		}
+	})
+}
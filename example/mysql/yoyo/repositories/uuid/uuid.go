@@ -0,0 +1,180 @@
+// Generated by github.com/yoyo-project/yoyo
+
+package uuid
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// UUID is a universally unique identifier. In the database it is stored as 16 bytes.
+type UUID [16]byte
+
+// Nil is the zero UUID
+var Nil UUID
+
+// New returns a new version 7 UUID, which starts with the current time so that new primary keys stay in index order.
+// It panics if the system's secure random number generator fails.
+func New() UUID {
+	var u UUID
+	if _, err := rand.Read(u[6:]); err != nil {
+		panic(fmt.Errorf("unable to generate UUID: %w", err))
+	}
+
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixMilli()))
+	copy(u[:6], ms[2:])
+	u[6] = u[6]&0x0f | 0x70 // version 7
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return u
+}
+
+// Parse parses a UUID from its 36 character text form, like `f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
+func Parse(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("invalid UUID %q", s)
+	}
+
+	b := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(u[:], []byte(b)); err != nil {
+		return u, fmt.Errorf("invalid UUID %q: %w", s, err)
+	}
+
+	return u, nil
+}
+
+// MustParse works like Parse, but panics if s isn't a valid UUID
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String returns the 36 character text form of the UUID
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// IsZero returns true for the Nil UUID
+func (u UUID) IsZero() bool {
+	return u == Nil
+}
+
+// Scan implements sql.Scanner. It accepts both the 16 byte and 36 character forms of a UUID.
+func (u *UUID) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		if len(s) == 16 {
+			copy(u[:], s)
+			return nil
+		}
+		return u.UnmarshalText(s)
+	case string:
+		return u.UnmarshalText([]byte(s))
+	}
+	return fmt.Errorf("unsupported Scan, storing %T into %T", src, u)
+}
+
+// Value implements driver.Valuer
+func (u UUID) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+// MarshalText implements encoding.TextMarshaler, so UUIDs are marshalled as their text form
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (u *UUID) UnmarshalText(b []byte) error {
+	parsed, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (u UUID) MarshalYAML() (interface{}, error) {
+	return u.String(), nil
+}
+
+// NullUUID is a UUID which may be null
+type NullUUID struct {
+	UUID  UUID
+	Valid bool
+}
+
+func (n *NullUUID) Set(val UUID) {
+	n.Valid = true
+	n.UUID = val
+}
+
+func (n *NullUUID) SetNull() {
+	n.Valid = false
+	n.UUID = Nil
+}
+
+// Scan implements sql.Scanner
+func (n *NullUUID) Scan(src interface{}) error {
+	if src == nil {
+		n.SetNull()
+		return nil
+	}
+	n.Valid = true
+	return n.UUID.Scan(src)
+}
+
+// Value implements driver.Valuer
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
+
+// MarshalJSON implements json.Marshaler. A null value is marshalled as JSON null.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.UUID)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null is unmarshalled as a null value.
+func (n *NullUUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.SetNull()
+		return nil
+	}
+	n.Valid = true
+	return json.Unmarshal(data, &n.UUID)
+}
+
+// MarshalYAML implements yaml.Marshaler. A null value is marshalled as YAML null.
+func (n NullUUID) MarshalYAML() (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.String(), nil
+}
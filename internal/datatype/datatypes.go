@@ -1,16 +1,17 @@
 package datatype
 
 import (
+	"fmt"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Datatype is used to encode information about types for use in repository or validation
-// The least-significant 8 bits are reserved for general metadata
-// The next 16 bits are not currently used. They were historically reserved for DBMS support in the early concept stage.
+// The least-significant 16 bits are reserved for general metadata
+// The next 8 bits are not currently used. They were historically reserved for DBMS support in the early concept stage.
 // The next 8 bits are reserved for unique type identification
-// The last 32 bits are not currently used
+// The last 32 bits hold the element Datatype of arrays and ranges
 type Datatype uint64
 
 // These are the actual Datatype constants with all the metadata and unique identifiers encoded into them
@@ -43,8 +44,41 @@ const (
 	Time       = idTime | metaTime
 	Timestamp  = idTimestamp | metaTime
 	Year       = idYear | metaTime
+	JSON       = idJSON | metaJSON
+	JSONB      = idJSONB | metaJSON
+	UUID       = idUUID
+
+	// The range types of PostgreSQL. TsTzRange's element is DateTime, the closest thing yoyo has to TIMESTAMPTZ.
+	Int4Range = idInt4Range | metaRange | Integer<<elementShift
+	Int8Range = idInt8Range | metaRange | BigInt<<elementShift
+	NumRange  = idNumRange | metaRange | Numeric<<elementShift
+	TsRange   = idTsRange | metaRange | Timestamp<<elementShift
+	TsTzRange = idTsTzRange | metaRange | DateTime<<elementShift
+	DateRange = idDateRange | metaRange | Date<<elementShift
+
+	// The spatial types of MySQL and PostGIS
+	Geometry           = idGeometry | metaSpatial
+	Point              = idPoint | metaSpatial
+	LineString         = idLineString | metaSpatial
+	Polygon            = idPolygon | metaSpatial
+	MultiPoint         = idMultiPoint | metaSpatial
+	MultiLineString    = idMultiLineString | metaSpatial
+	MultiPolygon       = idMultiPolygon | metaSpatial
+	GeometryCollection = idGeometryCollection | metaSpatial
 )
 
+// elementShift is the offset of the element Datatype within an array or range Datatype
+const elementShift = 32
+
+// ArrayOf returns the Datatype of an array with elements of the given Datatype, like PostgreSQL's `TEXT[]`
+func ArrayOf(element Datatype) Datatype {
+	return idArray | metaArray | element<<elementShift
+}
+
+// UUIDPackage is the name generated code imports the generated uuid package as, so that it doesn't collide with other
+// packages named uuid
+const UUIDPackage = "yoyouuid"
+
 // These are the string representations of datatypes
 const (
 	integer    = "INTEGER" // yoyo considers "INTEGER" to be the canonical string, however
@@ -79,6 +113,25 @@ const (
 	datetime   = "DATETIME"
 	timestamp  = "TIMESTAMP"
 	year       = "YEAR"
+	sjson      = "JSON"
+	jsonb      = "JSONB"
+	uuid       = "UUID"
+	int4range  = "INT4RANGE"
+	int8range  = "INT8RANGE"
+	numrange   = "NUMRANGE"
+	tsrange    = "TSRANGE"
+	tstzrange  = "TSTZRANGE"
+	daterange  = "DATERANGE"
+	arraySufx  = "[]"
+	geometry   = "GEOMETRY"
+	point      = "POINT"
+	linestring = "LINESTRING"
+	polygon    = "POLYGON"
+	multipoint = "MULTIPOINT"
+	multilines = "MULTILINESTRING"
+	multipolys = "MULTIPOLYGON"
+	geomcoll   = "GEOMETRYCOLLECTION" // yoyo considers "GEOMETRYCOLLECTION" to be the canonical string, however
+	geomcollS  = "GEOMCOLLECTION"     // it still accepts MySQL's "GEOMCOLLECTION" as an alias
 
 	goInt64   = "int64"
 	goInt32   = "int32"
@@ -86,19 +139,24 @@ const (
 	goInt8    = "int8"
 	goFloat64 = "float64"
 	goString  = "string"
-	goByte    = "byte"
 	goBool    = "bool"
 	goBlob    = "[]byte"
 	goTime    = "time.Time"
+	goJSON    = "json.RawMessage"
+	goUUID    = UUIDPackage + ".UUID"
+	goArray   = "pg.Array[%s]"
+	goRange   = "pg.Range[%s]"
+	goShape   = "geo.Shape[geo.%s]"
 
 	goNullableInt64   = "nullable.Int64"
 	goNullableInt32   = "nullable.Int32"
 	goNullableInt16   = "nullable.Int16"
 	goNullableTime    = "nullable.Time"
 	goNullableBool    = "nullable.Bool"
-	goNullableByte    = "nullable.Byte"
 	goNullableFloat64 = "nullable.Float64"
 	goNullableString  = "nullable.String"
+	goNullableJSON    = "nullable.JSON"
+	goNullableUUID    = UUIDPackage + ".NullUUID"
 )
 
 // UnmarshalYAML provides an implementation for yaml/v2.Unmarshaler to parse the yaml config
@@ -113,6 +171,13 @@ func (dt Datatype) MarshalYAML() (interface{}, error) {
 }
 
 func (dt Datatype) String() (s string) {
+	if dt.IsArray() {
+		if s = dt.Element().String(); s != "NONE" {
+			s += arraySufx
+		}
+		return s
+	}
+
 	switch dt {
 	case Integer:
 		s = integer
@@ -170,6 +235,40 @@ func (dt Datatype) String() (s string) {
 		s = year
 	case Timestamp:
 		s = timestamp
+	case JSON:
+		s = sjson
+	case JSONB:
+		s = jsonb
+	case UUID:
+		s = uuid
+	case Int4Range:
+		s = int4range
+	case Int8Range:
+		s = int8range
+	case NumRange:
+		s = numrange
+	case TsRange:
+		s = tsrange
+	case TsTzRange:
+		s = tstzrange
+	case DateRange:
+		s = daterange
+	case Geometry:
+		s = geometry
+	case Point:
+		s = point
+	case LineString:
+		s = linestring
+	case Polygon:
+		s = polygon
+	case MultiPoint:
+		s = multipoint
+	case MultiLineString:
+		s = multilines
+	case MultiPolygon:
+		s = multipolys
+	case GeometryCollection:
+		s = geomcoll
 	default:
 		s = "NONE"
 	}
@@ -177,6 +276,11 @@ func (dt Datatype) String() (s string) {
 }
 
 func (dt Datatype) GoNullableTypeString() (s string) {
+	if dt.IsArray() || dt.IsRange() || dt.IsSpatial() {
+		// A nil pg.Array, an invalid pg.Range and an invalid geo.Shape are already null
+		return dt.GoTypeString()
+	}
+
 	switch dt {
 	case Integer, MediumInt:
 		s = goNullableInt32
@@ -186,16 +290,18 @@ func (dt Datatype) GoNullableTypeString() (s string) {
 		s = goNullableInt64
 	case Decimal, Numeric, Real, Float, Double:
 		s = goNullableFloat64
-	case Varchar, Text, TinyText, MediumText, LongText, Enum:
+	case Varchar, Text, TinyText, MediumText, LongText, Enum, Char:
 		s = goNullableString
-	case Char:
-		s = goNullableByte
 	case Blob, Binary, TinyBlob, MediumBlob, LongBlob:
 		s = goBlob
 	case Boolean:
 		s = goNullableBool
 	case DateTime, Timestamp, Date:
 		s = goNullableTime
+	case JSON, JSONB:
+		s = goNullableJSON
+	case UUID:
+		s = goNullableUUID
 	default:
 		s = "NONE"
 	}
@@ -203,6 +309,20 @@ func (dt Datatype) GoNullableTypeString() (s string) {
 }
 
 func (dt Datatype) GoTypeString() (s string) {
+	if dt.IsArray() || dt.IsRange() {
+		if s = dt.Element().GoTypeString(); s == "NONE" {
+			return s
+		}
+		if dt.IsArray() {
+			return fmt.Sprintf(goArray, s)
+		}
+		return fmt.Sprintf(goRange, s)
+	}
+
+	if dt.IsSpatial() {
+		return fmt.Sprintf(goShape, dt.geoTypeName())
+	}
+
 	switch dt {
 	case Integer, MediumInt:
 		s = goInt32
@@ -214,16 +334,18 @@ func (dt Datatype) GoTypeString() (s string) {
 		s = goInt64
 	case Decimal, Numeric, Real, Float, Double:
 		s = goFloat64
-	case Varchar, Text, TinyText, MediumText, LongText, Enum:
+	case Varchar, Text, TinyText, MediumText, LongText, Enum, Char:
 		s = goString
-	case Char:
-		s = goByte
 	case Blob, Binary, TinyBlob, MediumBlob, LongBlob:
 		s = goBlob
 	case Boolean:
 		s = goBool
 	case DateTime, Timestamp, Date:
 		s = goTime
+	case JSON, JSONB:
+		s = goJSON
+	case UUID:
+		s = goUUID
 	default:
 		s = "NONE"
 	}
@@ -271,8 +393,64 @@ func (dt Datatype) IsTime() bool {
 	return dt&metaTime > 0
 }
 
+// IsJSON returns true if the Datatype is a JSON document type
+func (dt Datatype) IsJSON() bool {
+	return dt&metaJSON > 0
+}
+
+// IsArray returns true if the Datatype is an array of another Datatype
+func (dt Datatype) IsArray() bool {
+	return dt&metaArray > 0
+}
+
+// IsRange returns true if the Datatype is a range of another Datatype
+func (dt Datatype) IsRange() bool {
+	return dt&metaRange > 0
+}
+
+// IsSpatial returns true if the Datatype is a geometry type
+func (dt Datatype) IsSpatial() bool {
+	return dt&metaSpatial > 0
+}
+
+// geoTypeName returns the name of the type of a spatial Datatype in the generated geo package
+func (dt Datatype) geoTypeName() string {
+	switch dt {
+	case Point:
+		return "Point"
+	case LineString:
+		return "LineString"
+	case Polygon:
+		return "Polygon"
+	case MultiPoint:
+		return "MultiPoint"
+	case MultiLineString:
+		return "MultiLineString"
+	case MultiPolygon:
+		return "MultiPolygon"
+	case GeometryCollection:
+		return "Collection"
+	}
+	return "Geometry"
+}
+
+// Element returns the Datatype of the elements of an array, or of the bounds of a range
+// For any other Datatype it returns 0, which isn't a valid Datatype
+func (dt Datatype) Element() Datatype {
+	return dt >> elementShift
+}
+
 // FromString returns the decoded Datatype, and an error if the in string is invalid or unknown
 func FromString(in string) (dt Datatype, err error) {
+	if trimmed := strings.TrimSpace(in); strings.HasSuffix(trimmed, arraySufx) {
+		dt, err = FromString(strings.TrimSuffix(trimmed, arraySufx))
+		if err != nil || dt.IsArray() || dt.IsRange() {
+			// Multidimensional arrays and arrays of ranges aren't supported
+			return 0, ErrUnknownDatatype
+		}
+		return ArrayOf(dt), nil
+	}
+
 	switch strings.ToUpper(strings.Split(in, "(")[0]) {
 	case integer, sint:
 		dt = Integer
@@ -326,6 +504,40 @@ func FromString(in string) (dt Datatype, err error) {
 		dt = Year
 	case binary:
 		dt = Binary
+	case sjson:
+		dt = JSON
+	case jsonb:
+		dt = JSONB
+	case uuid:
+		dt = UUID
+	case int4range:
+		dt = Int4Range
+	case int8range:
+		dt = Int8Range
+	case numrange:
+		dt = NumRange
+	case tsrange:
+		dt = TsRange
+	case tstzrange:
+		dt = TsTzRange
+	case daterange:
+		dt = DateRange
+	case geometry:
+		dt = Geometry
+	case point:
+		dt = Point
+	case linestring:
+		dt = LineString
+	case polygon:
+		dt = Polygon
+	case multipoint:
+		dt = MultiPoint
+	case multilines:
+		dt = MultiLineString
+	case multipolys:
+		dt = MultiPolygon
+	case geomcoll, geomcollS:
+		dt = GeometryCollection
 	default:
 		err = ErrUnknownDatatype
 	}
@@ -334,7 +546,7 @@ func FromString(in string) (dt Datatype, err error) {
 }
 
 // These metadata are general metadata to describe the data type
-// 8 bits are reserved for this
+// 16 bits are reserved for this
 const (
 	metaNumeric Datatype = 1 << iota
 	metaInteger
@@ -344,6 +556,10 @@ const (
 	metaSignable      // TODO: Remove because it is synonymous with metaNumeric?
 	metaHasGoUnisgned // TODO: Remove because it is synonymous with metaInteger?
 	metaRequiresParams
+	metaJSON
+	metaArray
+	metaRange
+	metaSpatial
 )
 
 // These are the unique type identifiers
@@ -378,4 +594,22 @@ const (
 	idDateTime
 	idTimestamp
 	idYear
+	idJSON
+	idJSONB
+	idUUID
+	idArray
+	idInt4Range
+	idInt8Range
+	idNumRange
+	idTsRange
+	idTsTzRange
+	idDateRange
+	idGeometry
+	idPoint
+	idLineString
+	idPolygon
+	idMultiPoint
+	idMultiLineString
+	idMultiPolygon
+	idGeometryCollection
 )
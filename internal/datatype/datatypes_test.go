@@ -102,6 +102,56 @@ func TestDatatype_UnmarshalYAML(t *testing.T) {
 			input:        "datatype: " + enum + "('Hello', 'world!')",
 			wantDatatype: Enum,
 		},
+		{
+			name:         sjson,
+			input:        "datatype: json",
+			wantDatatype: JSON,
+		},
+		{
+			name:         jsonb,
+			input:        "datatype: " + jsonb,
+			wantDatatype: JSONB,
+		},
+		{
+			name:         uuid,
+			input:        "datatype: uuid",
+			wantDatatype: UUID,
+		},
+		{
+			name:         "text array",
+			input:        "datatype: text[]",
+			wantDatatype: ArrayOf(Text),
+		},
+		{
+			name:         "varchar array",
+			input:        "datatype: varchar(32)[]",
+			wantDatatype: ArrayOf(Varchar),
+		},
+		{
+			name:         tstzrange,
+			input:        "datatype: tstzrange",
+			wantDatatype: TsTzRange,
+		},
+		{
+			name:         point,
+			input:        "datatype: point(4326)",
+			wantDatatype: Point,
+		},
+		{
+			name:         geomcollS,
+			input:        "datatype: geomcollection",
+			wantDatatype: GeometryCollection,
+		},
+		{
+			name:    "multidimensional array",
+			input:   "datatype: int[][]",
+			wantErr: true,
+		},
+		{
+			name:    "invalid array",
+			input:   "datatype: invalid[]",
+			wantErr: true,
+		},
 		{
 			name:    "invalid",
 			input:   "datatype: " + "invalid",
@@ -188,6 +238,34 @@ func TestDatatype_String(t *testing.T) {
 			dt:   Boolean,
 			want: boolean,
 		},
+		{
+			dt:   JSON,
+			want: sjson,
+		},
+		{
+			dt:   JSONB,
+			want: jsonb,
+		},
+		{
+			dt:   UUID,
+			want: uuid,
+		},
+		{
+			dt:   ArrayOf(Integer),
+			want: "INTEGER[]",
+		},
+		{
+			dt:   Int8Range,
+			want: int8range,
+		},
+		{
+			dt:   DateRange,
+			want: daterange,
+		},
+		{
+			dt:   MultiPolygon,
+			want: multipolys,
+		},
 		{
 			dt:   123123,
 			want: "NONE",
@@ -283,6 +361,37 @@ func TestDatatype_IsBinary(t *testing.T) {
 	}
 }
 
+func TestDatatype_IsJSON(t *testing.T) {
+	tests := []struct {
+		dt   Datatype
+		want bool
+	}{
+		{
+			dt:   Text,
+			want: false,
+		},
+		{
+			dt:   Blob,
+			want: false,
+		},
+		{
+			dt:   JSON,
+			want: true,
+		},
+		{
+			dt:   JSONB,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dt.String(), func(t *testing.T) {
+			if got := tt.dt.IsJSON(); got != tt.want {
+				t.Errorf("IsJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDatatype_RequiresParams(t *testing.T) {
 	tests := []struct {
 		dt   Datatype
@@ -455,6 +564,38 @@ func TestDatatype_GoTypeString(t *testing.T) {
 			dt:   Enum,
 			want: goString,
 		},
+		{
+			dt:   JSON,
+			want: goJSON,
+		},
+		{
+			dt:   JSONB,
+			want: goJSON,
+		},
+		{
+			dt:   UUID,
+			want: goUUID,
+		},
+		{
+			dt:   ArrayOf(Text),
+			want: "pg.Array[string]",
+		},
+		{
+			dt:   NumRange,
+			want: "pg.Range[float64]",
+		},
+		{
+			dt:   Point,
+			want: "geo.Shape[geo.Point]",
+		},
+		{
+			dt:   Geometry,
+			want: "geo.Shape[geo.Geometry]",
+		},
+		{
+			dt:   GeometryCollection,
+			want: "geo.Shape[geo.Collection]",
+		},
 		{
 			dt:   Boolean,
 			want: goBool,
@@ -0,0 +1,14 @@
+package dialect
+
+// IdentifierLimit returns the most characters the dialect allows in an identifier, like a table or index name, or 0 if
+// it has no limit
+func IdentifierLimit(dialect string) int {
+	switch dialect {
+	case MySQL:
+		return 64
+	case PostgreSQL:
+		// NAMEDATALEN is 64 bytes, including the terminating zero byte
+		return 63
+	}
+	return 0
+}
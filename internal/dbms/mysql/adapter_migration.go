@@ -2,12 +2,15 @@ package mysql
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/yoyo-project/yoyo/internal/datatype"
 	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
+var currentTimestamp = regexp.MustCompile(`(?i)^(CURRENT_TIMESTAMP|NOW|LOCALTIME|LOCALTIMESTAMP)(\(\d*\))?$`)
+
 // TypeString returns the string representation of a given datatype.Datatype for MySQL
 // An error will be returned if the datatype.Datatype is invalid or not supported by MySQL
 func (a *adapter) TypeString(dt datatype.Datatype) (s string, err error) {
@@ -51,11 +54,55 @@ func (a *adapter) CreateTable(tName string, t schema.Table) string {
 		sb.WriteString(fmt.Sprintf("\n    PRIMARY KEY (`%s`)", strings.Join(pks, ",")))
 	}
 
-	sb.WriteString("\n);")
+	sb.WriteString("\n)")
+	if opts := tableOptions(t.Options); len(opts) > 0 {
+		sb.WriteString(" " + strings.Join(opts, " "))
+	}
+	sb.WriteRune(';')
 
 	return sb.String()
 }
 
+// CreateView returns a query string that creates or replaces a given view
+func (a *adapter) CreateView(vName string, v schema.View) string {
+	return fmt.Sprintf("CREATE OR REPLACE VIEW `%s` AS %s;", vName, v.Definition)
+}
+
+// AlterTableOptions returns a string query which changes the options of an existing table to the ones in the schema,
+// or an empty string if they already match. Options which aren't set in the schema are left alone. A new charset or
+// collation converts the table's existing columns too.
+func (a *adapter) AlterTableOptions(tName string, from, to schema.TableOptions) string {
+	var changes []string
+
+	if optionChanged(from.Engine, to.Engine) {
+		changes = append(changes, fmt.Sprintf("ENGINE = %s", to.Engine))
+	}
+	if optionChanged(from.Charset, to.Charset) || optionChanged(from.Collation, to.Collation) {
+		charset := to.Charset
+		if charset == "" {
+			// Collations are named after their charset, like utf8mb4_unicode_ci
+			charset, _, _ = strings.Cut(to.Collation, "_")
+		}
+		convert := fmt.Sprintf("CONVERT TO CHARACTER SET %s", charset)
+		if to.Collation != "" {
+			convert += fmt.Sprintf(" COLLATE %s", to.Collation)
+		}
+		changes = append(changes, convert)
+	}
+	if optionChanged(from.RowFormat, to.RowFormat) {
+		changes = append(changes, fmt.Sprintf("ROW_FORMAT = %s", to.RowFormat))
+	}
+	if to.Comment != "" && to.Comment != from.Comment {
+		changes = append(changes, fmt.Sprintf("COMMENT = %s", quote(to.Comment)))
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("ALTER TABLE `%s` %s;", tName, strings.Join(changes, ", "))
+}
+
 // AddColumn returns a string query which adds a column to an existing table
 func (a *adapter) AddColumn(tName, cName string, c schema.Column) string {
 	return fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s;", tName, a.generateColumn(cName, c))
@@ -68,6 +115,8 @@ func (a *adapter) AddIndex(tName, iName string, i schema.Index) string {
 	switch {
 	case i.Unique:
 		indexType = "UNIQUE INDEX"
+	case i.Type == schema.IndexTypeSpatial:
+		indexType = "SPATIAL INDEX"
 	default:
 		indexType = "INDEX"
 	}
@@ -85,6 +134,16 @@ func (a *adapter) AddIndex(tName, iName string, i schema.Index) string {
 	return fmt.Sprintf("ALTER TABLE `%s` ADD %s `%s` (%s);", tName, indexType, iName, cols.String())
 }
 
+// AddCheck returns a string query which adds the specified CHECK constraint to an existing table
+func (a *adapter) AddCheck(tName string, c schema.Check) string {
+	return fmt.Sprintf("ALTER TABLE `%s` ADD CONSTRAINT `%s` CHECK (%s);", tName, c.Name, c.Expr)
+}
+
+// AddUnique returns a string query which adds the specified UNIQUE constraint to an existing table
+func (a *adapter) AddUnique(tName string, u schema.Unique) string {
+	return fmt.Sprintf("ALTER TABLE `%s` ADD CONSTRAINT `%s` UNIQUE (`%s`);", tName, u.Name, strings.Join(u.Columns, "`, `"))
+}
+
 // AddReference returns a query string that adds columns and foreign keys for the given table, foreign table, and schema.Reference
 func (a *adapter) AddReference(tName string, fTable schema.Table, r schema.Reference) string {
 	var (
@@ -108,8 +167,12 @@ func (a *adapter) AddReference(tName string, fTable schema.Table, r schema.Refer
 		sw.WriteRune('\n')
 	}
 
-	sw.WriteString(fmt.Sprintf("ALTER TABLE `%s` ADD CONSTRAINT `reference_%s_%s_%s` FOREIGN KEY (`%s`) REFERENCES %s(`%s`)",
-		tName, tName, ftName, strings.Join(fCols, "_"), strings.Join(lCols, "`, `"), ftName, strings.Join(fCols, "`, `")))
+	constraint := r.ConstraintName
+	if constraint == "" {
+		constraint = schema.ShortenName(fmt.Sprintf("reference_%s_%s_%s", tName, ftName, strings.Join(fCols, "_")), a.IdentifierLimit())
+	}
+	sw.WriteString(fmt.Sprintf("ALTER TABLE `%s` ADD CONSTRAINT `%s` FOREIGN KEY (`%s`) REFERENCES %s(`%s`)",
+		tName, constraint, strings.Join(lCols, "`, `"), ftName, strings.Join(fCols, "`, `")))
 
 	if r.OnDelete != "" {
 		sw.WriteString(fmt.Sprintf(" ON DELETE %s", r.OnDelete))
@@ -127,8 +190,17 @@ func (a *adapter) AddReference(tName string, fTable schema.Table, r schema.Refer
 func (a *adapter) generateColumn(cName string, c schema.Column) string {
 	sb := strings.Builder{}
 	ts, _ := a.TypeString(c.Datatype)
+	if c.Datatype == datatype.UUID {
+		ts = uuidType(c.UUIDStorage)
+	}
 
-	if len(c.Params) > 0 {
+	if c.Datatype.IsSpatial() {
+		// MySQL gives spatial columns an SRID attribute rather than a parameter
+		sb.WriteString(fmt.Sprintf("`%s` %s", cName, ts))
+		if srid := c.SRID(); srid != "" {
+			sb.WriteString(fmt.Sprintf(" SRID %s", srid))
+		}
+	} else if len(c.Params) > 0 {
 		sb.WriteString(fmt.Sprintf("`%s` %s(%s)", cName, ts, strings.Join(c.Params, ", ")))
 	} else {
 		sb.WriteString(fmt.Sprintf("`%s` %s", cName, ts))
@@ -142,21 +214,61 @@ func (a *adapter) generateColumn(cName string, c schema.Column) string {
 		}
 	}
 
+	if c.Charset != "" {
+		sb.WriteString(fmt.Sprintf(` CHARACTER SET %s`, c.Charset))
+	}
+
 	if c.Collation != "" {
 		sb.WriteString(fmt.Sprintf(` COLLATE %s`, c.Collation))
 	}
 
-	if c.Default != nil {
+	if c.Generated != nil {
+		// generated columns can't have defaults or be auto-updated, so only nullability applies
+		sb.WriteString(fmt.Sprintf(" GENERATED ALWAYS AS (%s)", c.Generated.Expr))
+		if c.Generated.Stored {
+			sb.WriteString(" STORED")
+		} else {
+			sb.WriteString(" VIRTUAL")
+		}
+		if !c.Nullable {
+			sb.WriteString(" NOT")
+		}
+		sb.WriteString(" NULL")
+		writeComment(&sb, c.Comment)
+
+		return sb.String()
+	}
+
+	if c.DefaultExpr != "" {
+		if isCurrentTimestamp(c.DefaultExpr) {
+			sb.WriteString(fmt.Sprintf(" DEFAULT %s", c.DefaultExpr))
+		} else {
+			// MySQL requires expression defaults other than CURRENT_TIMESTAMP to be parenthesized
+			sb.WriteString(fmt.Sprintf(" DEFAULT (%s)", c.DefaultExpr))
+		}
+	} else if c.Default != nil {
 		sb.WriteString(` DEFAULT `)
-		if c.Datatype.IsString() {
+		switch {
+		case c.Datatype.IsTime() && isCurrentTimestamp(*c.Default):
+			sb.WriteString(*c.Default)
+		case c.Datatype.IsJSON():
+			// MySQL only accepts JSON defaults as expressions, so the literal is wrapped in one
+			sb.WriteString(fmt.Sprintf("(%s)", quote(*c.Default)))
+		case c.Datatype.IsString(), c.Datatype.IsTime():
 			sb.WriteString(fmt.Sprintf(`"%s"`, *c.Default))
-		} else {
+		default:
 			sb.WriteString(fmt.Sprintf("%s", *c.Default))
 		}
+	} else if c.AutoNowAdd || c.AutoNow {
+		sb.WriteString(` DEFAULT CURRENT_TIMESTAMP`)
 	} else if c.Nullable {
 		sb.WriteString(` DEFAULT NULL`)
 	}
 
+	if c.AutoNow {
+		sb.WriteString(` ON UPDATE CURRENT_TIMESTAMP`)
+	}
+
 	if !c.Nullable {
 		sb.WriteString(" NOT")
 	}
@@ -165,6 +277,68 @@ func (a *adapter) generateColumn(cName string, c schema.Column) string {
 	if c.AutoIncrement {
 		sb.WriteString(" AUTO_INCREMENT")
 	}
+	writeComment(&sb, c.Comment)
 
 	return sb.String()
 }
+
+// writeComment writes the COMMENT clause of a column definition, if the column has a comment
+func writeComment(sb *strings.Builder, comment string) {
+	if comment != "" {
+		sb.WriteString(fmt.Sprintf(" COMMENT %s", quote(comment)))
+	}
+}
+
+// AddComments returns an empty string, because MySQL declares comments in the table and column definitions
+func (a *adapter) AddComments(string, schema.Table) string {
+	return ""
+}
+
+// tableOptions returns the table options clauses of a CREATE TABLE query
+func tableOptions(o schema.TableOptions) (opts []string) {
+	if o.Engine != "" {
+		opts = append(opts, fmt.Sprintf("ENGINE = %s", o.Engine))
+	}
+	if o.Charset != "" {
+		opts = append(opts, fmt.Sprintf("DEFAULT CHARACTER SET = %s", o.Charset))
+	}
+	if o.Collation != "" {
+		opts = append(opts, fmt.Sprintf("COLLATE = %s", o.Collation))
+	}
+	if o.RowFormat != "" {
+		opts = append(opts, fmt.Sprintf("ROW_FORMAT = %s", o.RowFormat))
+	}
+	if o.Comment != "" {
+		opts = append(opts, fmt.Sprintf("COMMENT = %s", quote(o.Comment)))
+	}
+	return opts
+}
+
+// optionChanged returns true if the schema sets an option to something other than the existing one. MySQL option
+// values are case-insensitive.
+func optionChanged(existing, want string) bool {
+	return want != "" && !strings.EqualFold(existing, want)
+}
+
+// literalEscaper escapes the characters which end or escape a MySQL string literal. MySQL treats backslashes as escapes
+// unless NO_BACKSLASH_ESCAPES is set, and doubled backslashes are read as one either way.
+var literalEscaper = strings.NewReplacer(`'`, `''`, `\`, `\\`)
+
+// quote returns s as a single-quoted string literal
+func quote(s string) string {
+	return "'" + literalEscaper.Replace(s) + "'"
+}
+
+// uuidType returns the type of a UUID column with the given storage, since MySQL has no UUID type of its own
+func uuidType(storage string) string {
+	if storage == schema.UUIDStorageChar {
+		return "CHAR(36)"
+	}
+	return "BINARY(16)"
+}
+
+// isCurrentTimestamp returns true if def is CURRENT_TIMESTAMP or one of its synonyms, optionally with a precision, which
+// must not be quoted in a DEFAULT clause
+func isCurrentTimestamp(def string) bool {
+	return currentTimestamp.MatchString(def)
+}
@@ -130,6 +130,26 @@ func Test_adapter_CreateTable(t *testing.T) {
 				"    `column2` INT SIGNED NOT NULL,\n" +
 				"    PRIMARY KEY (`column`)\n);",
 		},
+		"table options": {
+			tName: "table",
+			t: schema.Table{
+				Columns: []schema.Column{
+					{
+						Name:     "column",
+						Datatype: datatype.Integer,
+					},
+				},
+				Options: schema.TableOptions{
+					Engine:    "InnoDB",
+					Charset:   "utf8mb4",
+					Collation: "utf8mb4_unicode_ci",
+					RowFormat: "DYNAMIC",
+					Comment:   "the table's comment",
+				},
+			},
+			wantS: "CREATE TABLE `table` (\n    `column` INT SIGNED NOT NULL,\n) ENGINE = InnoDB DEFAULT CHARACTER SET = utf8mb4 " +
+				"COLLATE = utf8mb4_unicode_ci ROW_FORMAT = DYNAMIC COMMENT = 'the table''s comment';",
+		},
 	}
 
 	m := &adapter{
@@ -146,6 +166,48 @@ func Test_adapter_CreateTable(t *testing.T) {
 	}
 }
 
+func Test_adapter_AlterTableOptions(t *testing.T) {
+	existing := schema.TableOptions{
+		Engine:    "InnoDB",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_0900_ai_ci",
+		RowFormat: "DYNAMIC",
+		Comment:   "people",
+	}
+	tests := map[string]struct {
+		to   schema.TableOptions
+		want string
+	}{
+		"nothing set": {},
+		"same options": {
+			to: schema.TableOptions{Engine: "innodb", Charset: "UTF8MB4", RowFormat: "dynamic", Comment: "people"},
+		},
+		"engine": {
+			to:   schema.TableOptions{Engine: "MyISAM"},
+			want: "ALTER TABLE `table` ENGINE = MyISAM;",
+		},
+		"charset": {
+			to:   schema.TableOptions{Charset: "latin1", Collation: "latin1_swedish_ci"},
+			want: "ALTER TABLE `table` CONVERT TO CHARACTER SET latin1 COLLATE latin1_swedish_ci;",
+		},
+		"collation only": {
+			to:   schema.TableOptions{Collation: "utf8mb4_unicode_ci"},
+			want: "ALTER TABLE `table` CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;",
+		},
+		"row format and comment": {
+			to:   schema.TableOptions{RowFormat: "COMPRESSED", Comment: "everyone's data"},
+			want: "ALTER TABLE `table` ROW_FORMAT = COMPRESSED, COMMENT = 'everyone''s data';",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().AlterTableOptions("table", existing, tt.to); got != tt.want {
+				t.Errorf("\nwant `%s`\n got `%s`", tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_adapter_AddColumn(t *testing.T) {
 	tests := map[string]struct {
 		tName string
@@ -220,6 +282,15 @@ func Test_adapter_AddIndex(t *testing.T) {
 			},
 			wantS: "ALTER TABLE `table` ADD UNIQUE INDEX `foreign` (`col`, `col2`);",
 		},
+		"spatial": {
+			tName: "table",
+			iName: "location",
+			i: schema.Index{
+				Columns: []string{"col"},
+				Type:    schema.IndexTypeSpatial,
+			},
+			wantS: "ALTER TABLE `table` ADD SPATIAL INDEX `location` (`col`);",
+		},
 	}
 
 	m := &adapter{
@@ -236,6 +307,46 @@ func Test_adapter_AddIndex(t *testing.T) {
 	}
 }
 
+func Test_adapter_AddCheck(t *testing.T) {
+	m := &adapter{
+		Base: base.Base{Dialect: dialect.MySQL},
+	}
+
+	want := "ALTER TABLE `table` ADD CONSTRAINT `positive_price` CHECK (price > 0);"
+	if got := m.AddCheck("table", schema.Check{Name: "positive_price", Expr: "price > 0"}); got != want {
+		t.Errorf("expected string `%s`, got string `%s`", want, got)
+	}
+}
+
+func Test_adapter_AddUnique(t *testing.T) {
+	tests := map[string]struct {
+		u     schema.Unique
+		wantS string
+	}{
+		"single column": {
+			u:     schema.Unique{Name: "uq", Columns: []string{"col"}},
+			wantS: "ALTER TABLE `table` ADD CONSTRAINT `uq` UNIQUE (`col`);",
+		},
+		"two columns": {
+			u:     schema.Unique{Name: "uq", Columns: []string{"col", "col2"}},
+			wantS: "ALTER TABLE `table` ADD CONSTRAINT `uq` UNIQUE (`col`, `col2`);",
+		},
+	}
+
+	m := &adapter{
+		Base: base.Base{Dialect: dialect.MySQL},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotS := m.AddUnique("table", tt.u)
+			if gotS != tt.wantS {
+				t.Errorf("expected string `%s`, got string `%s`", tt.wantS, gotS)
+			}
+		})
+	}
+}
+
 func Test_adapter_generateColumn(t *testing.T) {
 	point := func(s string) *string {
 		return &s
@@ -276,6 +387,41 @@ func Test_adapter_generateColumn(t *testing.T) {
 			},
 			wantS: "`col` INT UNSIGNED NOT NULL",
 		},
+		"varchar charset and collation": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:  datatype.Varchar,
+				Params:    []string{"32"},
+				Charset:   "utf8mb4",
+				Collation: "utf8mb4_bin",
+			},
+			wantS: "`col` VARCHAR(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL",
+		},
+		"comment": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.Integer,
+				Comment:  "the column's comment",
+			},
+			wantS: "`col` INT SIGNED NOT NULL COMMENT 'the column''s comment'",
+		},
+		"comment with backslashes": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.Integer,
+				Comment:  `C:\new\'s`,
+			},
+			wantS: "`col` INT SIGNED NOT NULL COMMENT 'C:\\\\new\\\\''s'",
+		},
+		"generated column comment": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:  datatype.Integer,
+				Generated: &schema.Generated{Expr: "a + b"},
+				Comment:   "a plus b",
+			},
+			wantS: "`col` INT SIGNED GENERATED ALWAYS AS (a + b) VIRTUAL NOT NULL COMMENT 'a plus b'",
+		},
 		"int auto_increment": {
 			cName: "col",
 			c: schema.Column{
@@ -301,6 +447,130 @@ func Test_adapter_generateColumn(t *testing.T) {
 			},
 			wantS: "`col` TEXT DEFAULT \"blah\" NOT NULL",
 		},
+		"timestamp default current_timestamp": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.Timestamp,
+				Default:  point("CURRENT_TIMESTAMP(3)"),
+			},
+			wantS: "`col` TIMESTAMP DEFAULT CURRENT_TIMESTAMP(3) NOT NULL",
+		},
+		"datetime default literal": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.DateTime,
+				Default:  point("2000-01-01 00:00:00"),
+			},
+			wantS: "`col` DATETIME DEFAULT \"2000-01-01 00:00:00\" NOT NULL",
+		},
+		"uuid": {
+			cName: "id",
+			c: schema.Column{
+				Datatype:    datatype.UUID,
+				PrimaryKey:  true,
+				UUIDStorage: schema.UUIDStorageBinarySwapped,
+			},
+			wantS: "`id` BINARY(16) NOT NULL",
+		},
+		"uuid char": {
+			cName: "id",
+			c: schema.Column{
+				Datatype:    datatype.UUID,
+				UUIDStorage: schema.UUIDStorageChar,
+			},
+			wantS: "`id` CHAR(36) NOT NULL",
+		},
+		"json": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.JSON,
+				Nullable: true,
+			},
+			wantS: "`col` JSON DEFAULT NULL NULL",
+		},
+		"point with srid": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.Point,
+				Params:   []string{"4326"},
+			},
+			wantS: "`col` POINT SRID 4326 NOT NULL",
+		},
+		"nullable geometry": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.Geometry,
+				Nullable: true,
+			},
+			wantS: "`col` GEOMETRY DEFAULT NULL NULL",
+		},
+		"json default literal": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.JSON,
+				Default:  point(`{"name": "O'Brien"}`),
+			},
+			wantS: "`col` JSON DEFAULT ('{\"name\": \"O''Brien\"}') NOT NULL",
+		},
+		"json default literal with escapes": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.JSON,
+				Default:  point(`{"path": "C:\\new"}`),
+			},
+			wantS: "`col` JSON DEFAULT ('{\"path\": \"C:\\\\\\\\new\"}') NOT NULL",
+		},
+		"binary default_expr": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:    datatype.Binary,
+				Params:      []string{"16"},
+				DefaultExpr: "UUID_TO_BIN(UUID())",
+			},
+			wantS: "`col` BINARY(16) DEFAULT (UUID_TO_BIN(UUID())) NOT NULL",
+		},
+		"datetime default_expr now": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:    datatype.DateTime,
+				DefaultExpr: "NOW()",
+			},
+			wantS: "`col` DATETIME DEFAULT NOW() NOT NULL",
+		},
+		"virtual generated int": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:  datatype.Integer,
+				Generated: &schema.Generated{Expr: "a + b"},
+			},
+			wantS: "`col` INT SIGNED GENERATED ALWAYS AS (a + b) VIRTUAL NOT NULL",
+		},
+		"stored generated nullable varchar": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:  datatype.Varchar,
+				Params:    []string{"64"},
+				Nullable:  true,
+				Generated: &schema.Generated{Expr: "UPPER(name)", Stored: true},
+			},
+			wantS: "`col` VARCHAR(64) GENERATED ALWAYS AS (UPPER(name)) STORED NULL",
+		},
+		"timestamp auto_now_add": {
+			cName: "col",
+			c: schema.Column{
+				Datatype:   datatype.Timestamp,
+				AutoNowAdd: true,
+			},
+			wantS: "`col` TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL",
+		},
+		"timestamp auto_now": {
+			cName: "col",
+			c: schema.Column{
+				Datatype: datatype.Timestamp,
+				AutoNow:  true,
+			},
+			wantS: "`col` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NOT NULL",
+		},
 		"varchar": {
 			cName: "col",
 			c: schema.Column{
@@ -439,6 +709,37 @@ func Test_adapter_AddReference(t *testing.T) {
 			wantS: "ALTER TABLE `local` ADD COLUMN `fk` INT SIGNED NOT NULL;\n" +
 				"ALTER TABLE `local` ADD CONSTRAINT `reference_local_foreign_id` FOREIGN KEY (`fk`) REFERENCES foreign(`id`);",
 		},
+		"constraint name from the naming conventions": {
+			tName: "local",
+			fTable: schema.Table{
+				Name: "foreign",
+				Columns: []schema.Column{
+					{Name: "id", PrimaryKey: true, Datatype: datatype.Integer},
+				},
+			},
+			r: schema.Reference{
+				ColumnNames:    []string{"foreign_id"},
+				Required:       true,
+				ConstraintName: "fk_local_foreign",
+			},
+			wantS: "ALTER TABLE `local` ADD COLUMN `foreign_id` INT SIGNED NOT NULL;\n" +
+				"ALTER TABLE `local` ADD CONSTRAINT `fk_local_foreign` FOREIGN KEY (`foreign_id`) REFERENCES foreign(`id`);",
+		},
+		"constraint name longer than the limit": {
+			tName: "a_table_with_a_rather_long_descriptive_name",
+			fTable: schema.Table{
+				Name: "another_table_with_a_long_name",
+				Columns: []schema.Column{
+					{Name: "id", PrimaryKey: true, Datatype: datatype.Integer},
+				},
+			},
+			r: schema.Reference{
+				ColumnNames: []string{"fk"},
+				Required:    true,
+			},
+			wantS: "ALTER TABLE `a_table_with_a_rather_long_descriptive_name` ADD COLUMN `fk` INT SIGNED NOT NULL;\n" +
+				"ALTER TABLE `a_table_with_a_rather_long_descriptive_name` ADD CONSTRAINT `reference_a_table_with_a_rather_long_descriptive_name_a_31257cc0` FOREIGN KEY (`fk`) REFERENCES another_table_with_a_long_name(`id`);",
+		},
 	}
 
 	m := &adapter{
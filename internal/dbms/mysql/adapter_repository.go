@@ -1,5 +1,7 @@
 package mysql
 
+import "github.com/yoyo-project/yoyo/internal/datatype"
+
 func (a *adapter) PreparedStatementPlaceholders(count int) []string {
 	out := make([]string, count)
 	for i := range out {
@@ -7,3 +9,11 @@ func (a *adapter) PreparedStatementPlaceholders(count int) []string {
 	}
 	return out
 }
+
+func (a *adapter) JSONPathEquals() (string, bool) {
+	return "JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) = ?", true
+}
+
+func (a *adapter) JSONContains(datatype.Datatype) (string, bool) {
+	return "JSON_CONTAINS(%s, ?)", true
+}
@@ -12,7 +12,21 @@ import (
 	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
-var paramIsolator = regexp.MustCompile("(^.*?(\\(|$)|[\"\\)\\s])")
+// literalDefault matches the way MySQL reports string literals in expression defaults, like `_utf8mb4\'{}\'`
+var literalDefault = regexp.MustCompile(`^_\w+\\'(.*)\\'$`)
+
+const listTablesQuery = `SELECT TABLE_NAME FROM information_schema.TABLES
+    WHERE TABLE_SCHEMA = DATABASE()
+        AND TABLE_TYPE = 'BASE TABLE'
+    ORDER BY TABLE_NAME`
+
+const listViewsQuery = `SELECT TABLE_NAME FROM information_schema.VIEWS
+    WHERE TABLE_SCHEMA = DATABASE()
+    ORDER BY TABLE_NAME`
+
+const getViewQuery = `SELECT VIEW_DEFINITION FROM information_schema.VIEWS
+    WHERE TABLE_NAME = '%s'
+        AND TABLE_SCHEMA = DATABASE()`
 
 const listColumnsQuery = `SELECT c.COLUMN_NAME FROM information_schema.COLUMNS c
     LEFT JOIN information_schema.KEY_COLUMN_USAGE kcu
@@ -36,10 +50,22 @@ const listReferencesQuery = `SELECT REFERENCED_TABLE_NAME
 	WHERE TABLE_NAME = %s 
 		AND TABLE_SCHEMA = DATABASE()`
 
-// TODO: Charset and Collation
-const getColumnQuery = "SHOW COLUMNS IN `%s` WHERE Field = '%s'"
+const getColumnQuery = `SELECT c.DATA_TYPE, c.COLUMN_TYPE, c.IS_NULLABLE, c.COLUMN_KEY, c.COLUMN_DEFAULT, c.EXTRA,
+        c.CHARACTER_SET_NAME, c.COLLATION_NAME, COALESCE(col.IS_DEFAULT = 'Yes', FALSE),
+        ccsa.CHARACTER_SET_NAME, t.TABLE_COLLATION, c.GENERATION_EXPRESSION, c.SRS_ID, c.COLUMN_COMMENT
+    FROM information_schema.COLUMNS c
+    JOIN information_schema.TABLES t
+        ON t.TABLE_NAME = c.TABLE_NAME
+            AND t.TABLE_SCHEMA = c.TABLE_SCHEMA
+    LEFT JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY ccsa
+        ON ccsa.COLLATION_NAME = t.TABLE_COLLATION
+    LEFT JOIN information_schema.COLLATIONS col
+        ON col.COLLATION_NAME = c.COLLATION_NAME
+    WHERE c.TABLE_NAME = '%s'
+        AND c.TABLE_SCHEMA = DATABASE()
+        AND c.COLUMN_NAME = '%s'`
 
-const getIndexQuery = `SELECT NOT NON_UNIQUE, COLUMN_NAME 
+const getIndexQuery = `SELECT NOT NON_UNIQUE, COLUMN_NAME, INDEX_TYPE
     FROM information_schema.STATISTICS
     WHERE TABLE_NAME = '%s'
         AND TABLE_SCHEMA = DATABASE()
@@ -63,6 +89,35 @@ const getReferenceColumnsQuery = `SELECT kcu.COLUMN_NAME, NOT c.IS_NULLABLE
         AND kcu.REFERENCED_TABLE_NAME = '%s'
         AND kcu.CONSTRAINT_NAME = '%s'`
 
+const listConstraintsQuery = `SELECT CONSTRAINT_NAME FROM information_schema.TABLE_CONSTRAINTS
+    WHERE TABLE_NAME = '%s'
+        AND TABLE_SCHEMA = DATABASE()
+        AND CONSTRAINT_TYPE = '%s'
+    ORDER BY CONSTRAINT_NAME`
+
+const getCheckQuery = `SELECT cc.CHECK_CLAUSE
+    FROM information_schema.CHECK_CONSTRAINTS cc
+    JOIN information_schema.TABLE_CONSTRAINTS tc
+        ON cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+            AND cc.CONSTRAINT_SCHEMA = tc.TABLE_SCHEMA
+    WHERE tc.TABLE_NAME = '%s'
+        AND tc.TABLE_SCHEMA = DATABASE()
+        AND tc.CONSTRAINT_TYPE = 'CHECK'
+        AND tc.CONSTRAINT_NAME = '%s'`
+
+const getTableOptionsQuery = `SELECT t.ENGINE, ccsa.CHARACTER_SET_NAME, t.TABLE_COLLATION, t.ROW_FORMAT, t.TABLE_COMMENT
+    FROM information_schema.TABLES t
+    LEFT JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY ccsa
+        ON ccsa.COLLATION_NAME = t.TABLE_COLLATION
+    WHERE t.TABLE_NAME = '%s'
+        AND t.TABLE_SCHEMA = DATABASE()`
+
+const getUniqueQuery = `SELECT COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_NAME = '%s'
+        AND TABLE_SCHEMA = DATABASE()
+        AND CONSTRAINT_NAME = '%s'
+    ORDER BY ORDINAL_POSITION`
+
 // InitReverserBuilder returns a `NewReverser` function, which returns a reverse.Adapter.
 func InitReverserBuilder(open func(driver, dsn string) (*sql.DB, error)) func(host, user, dbname, password, port string) (reverse.Adapter, error) {
 	return func(host, user, dbname, password, port string) (reverse.Adapter, error) {
@@ -88,9 +143,9 @@ func InitReverserBuilder(open func(driver, dsn string) (*sql.DB, error)) func(ho
 	}
 }
 
-// ListTables returns a list of tables on the selected database.
+// ListTables returns a list of tables on the selected database. Views are not included, they come from ListViews.
 func (a *adapter) ListTables() ([]string, error) {
-	rs, err := a.db.Query("SHOW TABLES")
+	rs, err := a.db.Query(listTablesQuery)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list tables: %w", err)
 	}
@@ -186,16 +241,15 @@ func (a *adapter) ListReferences(table string) ([]string, error) {
 	return tableNames, nil
 }
 
-// GetColumn returns a schema.Column representing the given tableName and colName.
-// TODO: Charset and Collation
+// GetColumn returns a schema.Column representing the given tableName and colName. The charset and collation are only
+// set where they differ from the table's, and a NULL default is no Default at all, so that the column generates the
+// same DDL it was created with.
 func (a *adapter) GetColumn(tableName, colName string) (schema.Column, error) {
 	var (
-		dt         string
-		nullable   string
-		key        = new(string)
-		defaultVal = new(string)
-		extra      string
-		col        schema.Column
+		dataType, columnType, nullable, key, extra, generation, comment    string
+		defaultVal, charset, collation, tableCharset, tableCollation, srid sql.NullString
+		defaultCollation                                                   bool
+		col                                                                schema.Column
 	)
 	rs, err := a.db.Query(fmt.Sprintf(getColumnQuery, tableName, colName))
 	if err != nil {
@@ -205,7 +259,8 @@ func (a *adapter) GetColumn(tableName, colName string) (schema.Column, error) {
 	if !rs.Next() {
 		return col, fmt.Errorf("unable to get column, empty result")
 	}
-	err = rs.Scan(new(interface{}), &dt, &nullable, &key, &defaultVal, &extra)
+	err = rs.Scan(&dataType, &columnType, &nullable, &key, &defaultVal, &extra, &charset, &collation, &defaultCollation,
+		&tableCharset, &tableCollation, &generation, &srid, &comment)
 	if err != nil {
 		return col, fmt.Errorf("unable to scan result reading column `%s`.`%s`: %w", tableName, colName, err)
 	}
@@ -215,33 +270,99 @@ func (a *adapter) GetColumn(tableName, colName string) (schema.Column, error) {
 		return col, fmt.Errorf("unable to close rows after getting column, too many rows: %w", err)
 	}
 
-	dts := strings.Split(strings.ToUpper(dt), " ")
-	col.Datatype, err = datatype.FromString(dts[0])
+	col.Datatype, err = datatype.FromString(strings.ToUpper(dataType))
 	if err != nil {
 		return col, fmt.Errorf("unable to determine datatype for column `%s`.`%s`: %w", tableName, colName, err)
 	}
 
-	if col.Datatype.IsSignable() && len(dts) > 1 && dts[1] == "UNSIGNED" {
-		col.Unsigned = true
+	extra = strings.ToLower(extra)
+
+	col.Unsigned = col.Datatype.IsSignable() && strings.Contains(strings.ToLower(columnType), " unsigned")
+	col.Params = columnParams(columnType)
+	if col.Datatype.IsSpatial() && srid.Valid {
+		// MySQL gives spatial columns an SRID attribute, which yoyo keeps as the column's param
+		col.Params = []string{srid.String}
+	}
+	col.Nullable = nullable == "YES"
+	col.PrimaryKey = key == "PRI"
+	col.AutoIncrement = strings.Contains(extra, "auto_increment")
+	col.AutoNow = strings.Contains(extra, "on update current_timestamp")
+	col.Charset, col.Collation = columnCharset(charset, collation, defaultCollation, tableCharset, tableCollation)
+	col.Comment = comment
+
+	switch {
+	case strings.Contains(extra, "stored generated"), strings.Contains(extra, "virtual generated"):
+		col.Generated = &schema.Generated{Expr: generation, Stored: strings.Contains(extra, "stored generated")}
+	case !defaultVal.Valid:
+		// NULL is the default of every nullable column without one
+	case col.Datatype.IsTime() && strings.EqualFold(defaultVal.String, "CURRENT_TIMESTAMP"):
+		// auto_now columns default to the current time by themselves
+		col.AutoNowAdd = !col.AutoNow
+	case strings.Contains(extra, "default_generated"):
+		// JSON columns can only have expression defaults, which yoyo writes for their literal defaults
+		if m := literalDefault.FindStringSubmatch(defaultVal.String); m != nil && col.Datatype.IsJSON() {
+			literal := strings.ReplaceAll(m[1], `\'`, `'`)
+			col.Default = &literal
+		} else {
+			col.DefaultExpr = defaultVal.String
+		}
+	default:
+		col.Default = &defaultVal.String
 	}
 
-	ps := strings.Split(paramIsolator.ReplaceAllString(dts[0], ""), ",")
+	return col, nil
+}
 
-	if len(ps) > 0 {
-		col.Params = ps
+// columnParams returns the params in the parentheses of a COLUMN_TYPE, like `decimal(5,3)` or `enum('a','b')`. Quoted
+// values keep their quotes, and commas in them don't separate params.
+func columnParams(columnType string) (params []string) {
+	start, end := strings.IndexByte(columnType, '(')+1, strings.LastIndexByte(columnType, ')')
+	if start == 0 || end < start {
+		return nil
 	}
 
-	col.Default = defaultVal
-	col.PrimaryKey = key != nil && *key == "PRI"
-	col.AutoIncrement = strings.Contains(strings.ToLower(extra), "auto_increment")
+	quoted := false
+	for i := start; i < end; i++ {
+		switch columnType[i] {
+		case '\'':
+			// quotes in values are doubled, so they toggle twice
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				params = append(params, columnType[start:i])
+				start = i + 1
+			}
+		}
+	}
 
-	return col, nil
+	return append(params, columnType[start:end])
+}
+
+// columnCharset returns the charset and collation of a column where they differ from its table's. A collation which is
+// the default of the column's charset is left out when the charset is set, since it's implied.
+func columnCharset(charset, collation sql.NullString, defaultCollation bool, tableCharset, tableCollation sql.NullString) (string, string) {
+	if !charset.Valid || !tableCollation.Valid {
+		// only text columns have a charset, and the columns of views follow their definition
+		return "", ""
+	}
+
+	switch {
+	case charset.String != tableCharset.String && defaultCollation:
+		return charset.String, ""
+	case charset.String != tableCharset.String:
+		return charset.String, collation.String
+	case collation.String != tableCollation.String:
+		return "", collation.String
+	default:
+		return "", ""
+	}
 }
 
 // GetIndex returns a schema.Index representing the given tableName and indexName.
 func (a *adapter) GetIndex(tableName, indexName string) (schema.Index, error) {
 	var (
 		tempColName string
+		indexType   string
 		columns     []string
 		index       schema.Index
 	)
@@ -252,7 +373,7 @@ func (a *adapter) GetIndex(tableName, indexName string) (schema.Index, error) {
 	}
 
 	for rs.Next() {
-		err = rs.Scan(&index.Unique, &tempColName)
+		err = rs.Scan(&index.Unique, &tempColName, &indexType)
 		if err != nil {
 			return index, fmt.Errorf("unable to scan result reading index `%s` on table `%s`: %w", indexName, tableName, err)
 		}
@@ -261,6 +382,10 @@ func (a *adapter) GetIndex(tableName, indexName string) (schema.Index, error) {
 	}
 	_ = rs.Close()
 
+	if indexType == "SPATIAL" {
+		index.Type = schema.IndexTypeSpatial
+	}
+
 	index.Columns = columns
 	return index, nil
 }
@@ -274,13 +399,19 @@ func (a *adapter) GetReference(tableName, referenceName string) (schema.Referenc
 		columnNames    []string
 	)
 
-	rs, err := a.db.Query(fmt.Sprintf(fmt.Sprintf(getReferenceQuery, tableName, referenceName)))
+	rs, err := a.db.Query(fmt.Sprintf(getReferenceQuery, tableName, referenceName))
 	if err != nil {
 		return ref, fmt.Errorf("unable to get reference information for table `%s` from table `%s`: %w", referenceName, tableName, err)
 	}
 
+	// UPDATE_RULE and DELETE_RULE are reported as CASCADE, SET NULL, SET DEFAULT, RESTRICT or NO ACTION, exactly as
+	// schema.Reference expects them
 	for rs.Next() {
 		err = rs.Scan(&ref.OnUpdate, &ref.OnDelete, &constraintName)
+		if err != nil {
+			_ = rs.Close()
+			return ref, fmt.Errorf("unable to scan reference information for table `%s` from table `%s`: %w", referenceName, tableName, err)
+		}
 	}
 	_ = rs.Close()
 
@@ -303,3 +434,138 @@ func (a *adapter) GetReference(tableName, referenceName string) (schema.Referenc
 
 	return ref, err
 }
+
+// ListChecks returns a []string of CHECK constraint names for the given table
+func (a *adapter) ListChecks(table string) ([]string, error) {
+	names, err := a.listConstraints(table, "CHECK")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list checks: %w", err)
+	}
+	return names, nil
+}
+
+// ListUniques returns no names. MySQL doesn't distinguish between UNIQUE constraints and unique indices, so all unique
+// keys are read back as unique indices by ListIndices and GetIndex, which keeps a schema's `unique: true` indices intact.
+func (a *adapter) ListUniques(string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (a *adapter) listConstraints(table, constraintType string) ([]string, error) {
+	rs, err := a.db.Query(fmt.Sprintf(listConstraintsQuery, table, constraintType))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tempString string
+		names      = make([]string, 0)
+	)
+	for rs.Next() {
+		err := rs.Scan(&tempString)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan constraint list results: %w", err)
+		}
+		names = append(names, tempString)
+	}
+	_ = rs.Close()
+
+	return names, nil
+}
+
+// GetCheck returns a schema.Check representing the given CHECK constraint on the given table
+func (a *adapter) GetCheck(tableName, checkName string) (schema.Check, error) {
+	check := schema.Check{Name: checkName}
+
+	err := a.db.QueryRow(fmt.Sprintf(getCheckQuery, tableName, checkName)).Scan(&check.Expr)
+	if err != nil {
+		return check, fmt.Errorf("unable to get check `%s` on table `%s`: %w", checkName, tableName, err)
+	}
+
+	return check, nil
+}
+
+// GetUnique returns a schema.Unique representing the given UNIQUE constraint on the given table
+func (a *adapter) GetUnique(tableName, uniqueName string) (schema.Unique, error) {
+	var (
+		tempColName string
+		unique      = schema.Unique{Name: uniqueName}
+	)
+
+	rs, err := a.db.Query(fmt.Sprintf(getUniqueQuery, tableName, uniqueName))
+	if err != nil {
+		return unique, fmt.Errorf("unable to get information for unique `%s` on table `%s`: %w", uniqueName, tableName, err)
+	}
+
+	for rs.Next() {
+		err = rs.Scan(&tempColName)
+		if err != nil {
+			return unique, fmt.Errorf("unable to scan result reading unique `%s` on table `%s`: %w", uniqueName, tableName, err)
+		}
+		unique.Columns = append(unique.Columns, tempColName)
+	}
+	_ = rs.Close()
+
+	if len(unique.Columns) == 0 {
+		return unique, fmt.Errorf("unable to find any columns for unique `%s` on table `%s`", uniqueName, tableName)
+	}
+
+	return unique, nil
+}
+
+// GetTableOptions returns the schema.TableOptions of the given table. MySQL reports ROW_FORMAT capitalized, like
+// `Dynamic`, so it's upper-cased to match the way it's written in DDL.
+func (a *adapter) GetTableOptions(tableName string) (schema.TableOptions, error) {
+	var (
+		engine, charset, collation, rowFormat, comment sql.NullString
+		options                                        schema.TableOptions
+	)
+
+	err := a.db.QueryRow(fmt.Sprintf(getTableOptionsQuery, tableName)).Scan(&engine, &charset, &collation, &rowFormat, &comment)
+	if err != nil {
+		return options, fmt.Errorf("unable to get options for table `%s`: %w", tableName, err)
+	}
+
+	options.Engine = engine.String
+	options.Charset = charset.String
+	options.Collation = collation.String
+	options.RowFormat = strings.ToUpper(rowFormat.String)
+	options.Comment = comment.String
+
+	return options, nil
+}
+
+// ListViews returns a list of views on the selected database.
+func (a *adapter) ListViews() ([]string, error) {
+	rs, err := a.db.Query(listViewsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list views: %w", err)
+	}
+
+	var (
+		viewNames  []string
+		tempString string
+	)
+
+	for rs.Next() {
+		err := rs.Scan(&tempString)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan view results: %w", err)
+		}
+		viewNames = append(viewNames, tempString)
+	}
+	_ = rs.Close()
+
+	return viewNames, nil
+}
+
+// GetView returns a schema.View with the definition of the given view. Its columns come from ListColumns and GetColumn.
+func (a *adapter) GetView(viewName string) (schema.View, error) {
+	view := schema.View{Table: schema.Table{Name: viewName}}
+
+	err := a.db.QueryRow(fmt.Sprintf(getViewQuery, viewName)).Scan(&view.Definition)
+	if err != nil {
+		return view, fmt.Errorf("unable to get definition of view `%s`: %w", viewName, err)
+	}
+
+	return view, nil
+}
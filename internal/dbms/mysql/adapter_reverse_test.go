@@ -2,15 +2,19 @@ package mysql
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/reverse"
 	"github.com/yoyo-project/yoyo/internal/schema"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
 )
 
 func TestInitNewReverser(t *testing.T) {
@@ -79,7 +83,7 @@ func Test_reverser_ListTables(t *testing.T) {
 			fields: fields{
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New()
-					mock.ExpectQuery("SHOW TABLES").
+					mock.ExpectQuery(regexp.QuoteMeta(listTablesQuery)).
 						WillReturnRows(mock.NewRows([]string{"Tables_in_db"}).
 							AddRow("table"))
 					return db
@@ -92,7 +96,7 @@ func Test_reverser_ListTables(t *testing.T) {
 			fields: fields{
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New()
-					mock.ExpectQuery("SHOW TABLES").
+					mock.ExpectQuery(regexp.QuoteMeta(listTablesQuery)).
 						WillReturnRows(mock.NewRows([]string{"Tables_in_db"}).
 							AddRow("table1").
 							AddRow("table2").
@@ -108,7 +112,7 @@ func Test_reverser_ListTables(t *testing.T) {
 			fields: fields{
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New()
-					mock.ExpectQuery("SHOW TABLES").
+					mock.ExpectQuery(regexp.QuoteMeta(listTablesQuery)).
 						WillReturnRows(mock.NewRows([]string{"Tables_in_db"}))
 					return db
 				}(),
@@ -119,7 +123,7 @@ func Test_reverser_ListTables(t *testing.T) {
 			fields: fields{
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New()
-					mock.ExpectQuery("SHOW TABLES").
+					mock.ExpectQuery(regexp.QuoteMeta(listTablesQuery)).
 						WillReturnRows(mock.NewRows([]string{"Tables_in_db", "bonus_col"}).
 							AddRow("table", "bonus_val"))
 					return db
@@ -132,7 +136,7 @@ func Test_reverser_ListTables(t *testing.T) {
 			fields: fields{
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New()
-					mock.ExpectQuery("SHOW TABLES").
+					mock.ExpectQuery(regexp.QuoteMeta(listTablesQuery)).
 						WillReturnError(fmt.Errorf("oh no it broke"))
 					return db
 				}(),
@@ -442,6 +446,20 @@ func Test_reverser_ListReferences(t *testing.T) {
 	}
 }
 
+// columnFields are the fields of getColumnQuery
+var columnFields = []string{"DATA_TYPE", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_KEY", "COLUMN_DEFAULT", "EXTRA",
+	"CHARACTER_SET_NAME", "COLLATION_NAME", "IS_DEFAULT", "CHARACTER_SET_NAME", "TABLE_COLLATION",
+	"GENERATION_EXPRESSION", "SRS_ID", "COLUMN_COMMENT"}
+
+// mockColumn returns a *sql.DB which answers getColumnQuery for `table`.`col` with a row of the given values, in the
+// order of columnFields
+func mockColumn(col string, values ...driver.Value) *sql.DB {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", col)).
+		WillReturnRows(mock.NewRows(columnFields).AddRow(values...))
+	return db
+}
+
 func Test_reverser_GetColumn(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -464,13 +482,7 @@ func Test_reverser_GetColumn(t *testing.T) {
 				colName: "id",
 			},
 			fields: fields{
-				db: func() *sql.DB {
-					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-							AddRow("id", "INT(11)", "NO", "PRI", nil, "auto_increment"))
-					return db
-				}(),
+				db: mockColumn("id", "int", "int(11)", "NO", "PRI", nil, "auto_increment", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
 			},
 			want: schema.Column{
 				Datatype:      datatype.Integer,
@@ -486,17 +498,10 @@ func Test_reverser_GetColumn(t *testing.T) {
 				colName: "id",
 			},
 			fields: fields{
-				db: func() *sql.DB {
-					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-							AddRow("col", "INT(11) UNSIGNED", "NO", "", nil, ""))
-					return db
-				}(),
+				db: mockColumn("id", "int", "int unsigned", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
 			},
 			want: schema.Column{
 				Datatype: datatype.Integer,
-				Params:   []string{"11"},
 				Unsigned: true,
 			},
 		},
@@ -507,13 +512,7 @@ func Test_reverser_GetColumn(t *testing.T) {
 				colName: "id",
 			},
 			fields: fields{
-				db: func() *sql.DB {
-					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-							AddRow("col", "DECIMAL(5,3)", "NO", "", nil, ""))
-					return db
-				}(),
+				db: mockColumn("id", "decimal", "decimal(5,3)", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
 			},
 			want: schema.Column{
 				Datatype: datatype.Decimal,
@@ -521,23 +520,222 @@ func Test_reverser_GetColumn(t *testing.T) {
 			},
 		},
 		{
-			name: "unsigned int",
+			name: "nullable json",
+			args: args{
+				table:   "table",
+				colName: "doc",
+			},
+			fields: fields{
+				db: mockColumn("doc", "json", "json", "YES", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.JSON,
+				Nullable: true,
+			},
+		},
+		{
+			name: "comment",
 			args: args{
 				table:   "table",
 				colName: "id",
 			},
 			fields: fields{
-				db: func() *sql.DB {
-					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-							AddRow("col", "DECIMAL(5,3)", "NO", "", nil, ""))
-					return db
-				}(),
+				db: mockColumn("id", "int", "int", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, "Number of seats"),
 			},
 			want: schema.Column{
-				Datatype: datatype.Decimal,
-				Params:   []string{"5", "3"},
+				Datatype: datatype.Integer,
+				Comment:  "Number of seats",
+			},
+		},
+		{
+			name: "literal default",
+			args: args{
+				table:   "table",
+				colName: "name",
+			},
+			fields: fields{
+				db: mockColumn("name", "varchar", "varchar(32)", "NO", "", "nobody", "", "utf8mb4", "utf8mb4_0900_ai_ci", true, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Varchar,
+				Params:   []string{"32"},
+				Default:  func() *string { s := "nobody"; return &s }(),
+			},
+		},
+		{
+			name: "charset and collation of the table",
+			args: args{
+				table:   "table",
+				colName: "name",
+			},
+			fields: fields{
+				db: mockColumn("name", "varchar", "varchar(32)", "YES", "", nil, "", "latin1", "latin1_swedish_ci", true, "latin1", "latin1_swedish_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Varchar,
+				Params:   []string{"32"},
+				Nullable: true,
+			},
+		},
+		{
+			name: "collation other than the table's",
+			args: args{
+				table:   "table",
+				colName: "name",
+			},
+			fields: fields{
+				db: mockColumn("name", "varchar", "varchar(32)", "NO", "", nil, "", "utf8mb4", "utf8mb4_bin", false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype:  datatype.Varchar,
+				Params:    []string{"32"},
+				Collation: "utf8mb4_bin",
+			},
+		},
+		{
+			name: "charset other than the table's with its default collation",
+			args: args{
+				table:   "table",
+				colName: "name",
+			},
+			fields: fields{
+				db: mockColumn("name", "varchar", "varchar(32)", "NO", "", nil, "", "latin1", "latin1_swedish_ci", true, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Varchar,
+				Params:   []string{"32"},
+				Charset:  "latin1",
+			},
+		},
+		{
+			name: "charset and collation other than the table's",
+			args: args{
+				table:   "table",
+				colName: "name",
+			},
+			fields: fields{
+				db: mockColumn("name", "varchar", "varchar(32)", "NO", "", nil, "", "latin1", "latin1_bin", false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype:  datatype.Varchar,
+				Params:    []string{"32"},
+				Charset:   "latin1",
+				Collation: "latin1_bin",
+			},
+		},
+		{
+			name: "enum values with commas and quotes",
+			args: args{
+				table:   "table",
+				colName: "color",
+			},
+			fields: fields{
+				db: mockColumn("color", "enum", "enum('red','green, ish','o''range')", "NO", "", "red", "", "utf8mb4", "utf8mb4_0900_ai_ci", true, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Enum,
+				Params:   []string{"'red'", "'green, ish'", "'o''range'"},
+				Default:  func() *string { s := "red"; return &s }(),
+			},
+		},
+		{
+			name: "auto_now_add",
+			args: args{
+				table:   "table",
+				colName: "created_at",
+			},
+			fields: fields{
+				db: mockColumn("created_at", "timestamp", "timestamp", "NO", "", "CURRENT_TIMESTAMP", "DEFAULT_GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype:   datatype.Timestamp,
+				AutoNowAdd: true,
+			},
+		},
+		{
+			name: "auto_now",
+			args: args{
+				table:   "table",
+				colName: "updated_at",
+			},
+			fields: fields{
+				db: mockColumn("updated_at", "timestamp", "timestamp", "NO", "", "CURRENT_TIMESTAMP", "DEFAULT_GENERATED on update CURRENT_TIMESTAMP", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Timestamp,
+				AutoNow:  true,
+			},
+		},
+		{
+			name: "expression default",
+			args: args{
+				table:   "table",
+				colName: "id",
+			},
+			fields: fields{
+				db: mockColumn("id", "binary", "binary(16)", "NO", "", "uuid_to_bin(uuid())", "DEFAULT_GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype:    datatype.Binary,
+				Params:      []string{"16"},
+				DefaultExpr: "uuid_to_bin(uuid())",
+			},
+		},
+		{
+			name: "json literal default",
+			args: args{
+				table:   "table",
+				colName: "doc",
+			},
+			fields: fields{
+				db: mockColumn("doc", "json", "json", "NO", "", `_utf8mb4\'{"a": \'b\'}\'`, "DEFAULT_GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.JSON,
+				Default:  func() *string { s := `{"a": 'b'}`; return &s }(),
+			},
+		},
+		{
+			name: "stored generated column",
+			args: args{
+				table:   "table",
+				colName: "total",
+			},
+			fields: fields{
+				db: mockColumn("total", "int", "int", "YES", "", nil, "STORED GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "(`price` * `quantity`)", nil, ""),
+			},
+			want: schema.Column{
+				Datatype:  datatype.Integer,
+				Nullable:  true,
+				Generated: &schema.Generated{Expr: "(`price` * `quantity`)", Stored: true},
+			},
+		},
+		{
+			name: "spatial column with an SRID",
+			args: args{
+				table:   "table",
+				colName: "location",
+			},
+			fields: fields{
+				db: mockColumn("location", "point", "point", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", "4326", ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Point,
+				Params:   []string{"4326"},
+			},
+		},
+		{
+			name: "view column",
+			args: args{
+				table:   "table",
+				colName: "name",
+			},
+			fields: fields{
+				db: mockColumn("name", "varchar", "varchar(32)", "NO", "", nil, "", "latin1", "latin1_bin", false, nil, nil, "", nil, ""),
+			},
+			want: schema.Column{
+				Datatype: datatype.Varchar,
+				Params:   []string{"32"},
 			},
 		},
 		{
@@ -566,7 +764,7 @@ func Test_reverser_GetColumn(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}))
+						WillReturnRows(mock.NewRows(columnFields))
 					return db
 				}(),
 			},
@@ -582,8 +780,8 @@ func Test_reverser_GetColumn(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra", "BORK"}).
-							AddRow("col", "DECIMAL(5,3)", "NO", "", nil, "", "BORK"))
+						WillReturnRows(mock.NewRows(append(columnFields[:len(columnFields):len(columnFields)], "BORK")).
+							AddRow("int", "int", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, "", "BORK"))
 					return db
 				}(),
 			},
@@ -599,9 +797,9 @@ func Test_reverser_GetColumn(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-							AddRow("col", "DECIMAL(5,3)", "NO", "", nil, "").
-							AddRow("col", "DECIMAL(5,3)", "NO", "", nil, "").
+						WillReturnRows(mock.NewRows(columnFields).
+							AddRow("int", "int", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, "").
+							AddRow("int", "int", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, "").
 							CloseError(fmt.Errorf("oh no")))
 					return db
 				}(),
@@ -615,51 +813,10 @@ func Test_reverser_GetColumn(t *testing.T) {
 				colName: "id",
 			},
 			fields: fields{
-				db: func() *sql.DB {
-					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-					mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-						WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-							AddRow("col", "WAT", "NO", "", nil, ""))
-					return db
-				}(),
+				db: mockColumn("id", "wat", "wat", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""),
 			},
 			wantErr: "unable to determine datatype",
 		},
-		// TODO: Add in some validation to make these test cases valid again
-		//{
-		//	name: "malformed precision/length",
-		//	args: args{
-		//		table:   "table",
-		//		colName: "id",
-		//	},
-		//	fields: fields{
-		//		db: func() *sql.DB {
-		//			db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-		//			mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-		//				WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-		//					AddRow("col", "DECIMAL(asd,1)", "NO", "", nil, ""))
-		//			return db
-		//		}(),
-		//	},
-		//	wantErr: "unable to determine precision",
-		//},
-		//{
-		//	name: "malformed scale",
-		//	args: args{
-		//		table:   "table",
-		//		colName: "id",
-		//	},
-		//	fields: fields{
-		//		db: func() *sql.DB {
-		//			db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
-		//			mock.ExpectQuery(fmt.Sprintf(getColumnQuery, "table", "id")).
-		//				WillReturnRows(mock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
-		//					AddRow("col", "DECIMAL(4,asd)", "NO", "", nil, ""))
-		//			return db
-		//		}(),
-		//	},
-		//	wantErr: "unable to determine scale",
-		//},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -668,19 +825,98 @@ func Test_reverser_GetColumn(t *testing.T) {
 			}
 			got, err := d.GetColumn(tt.args.table, tt.args.colName)
 			if (err != nil) && len(tt.wantErr) != 0 && !strings.Contains(err.Error(), tt.wantErr) {
-				t.Errorf("ListTables() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetColumn() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil && tt.wantErr == "" {
-				t.Errorf("ListTables() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetColumn() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err == nil && len(tt.wantErr) != 0 {
-				t.Errorf("ListTables() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetColumn() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if tt.wantErr == "" && !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("ListTables() got = %v, want %v", got, tt.want)
+				t.Errorf("GetColumn() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_reverser_GetColumn_roundTrip reverses columns from what information_schema reports for the DDL yoyo generates,
+// and checks that they generate the same DDL again
+func Test_reverser_GetColumn_roundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		ddl  string
+		row  []driver.Value
+	}{
+		{
+			name: "id",
+			ddl:  "`id` INT UNSIGNED NOT NULL AUTO_INCREMENT",
+			row:  []driver.Value{"int", "int unsigned", "NO", "PRI", nil, "auto_increment", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "price",
+			ddl:  "`price` DECIMAL(10, 2) SIGNED DEFAULT 0.00 NOT NULL",
+			row:  []driver.Value{"decimal", "decimal(10,2)", "NO", "", "0.00", "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "nickname",
+			ddl:  "`nickname` VARCHAR(32) DEFAULT NULL NULL COMMENT 'What friends call them'",
+			row:  []driver.Value{"varchar", "varchar(32)", "YES", "", nil, "", "utf8mb4", "utf8mb4_0900_ai_ci", true, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, "What friends call them"},
+		},
+		{
+			name: "code",
+			ddl:  "`code` CHAR(3) CHARACTER SET latin1 COLLATE latin1_bin DEFAULT \"abc\" NOT NULL",
+			row:  []driver.Value{"char", "char(3)", "NO", "", "abc", "", "latin1", "latin1_bin", false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "color",
+			ddl:  "`color` ENUM('red', 'green, ish', 'o''range') DEFAULT \"red\" NOT NULL",
+			row:  []driver.Value{"enum", "enum('red','green, ish','o''range')", "NO", "", "red", "", "utf8mb4", "utf8mb4_0900_ai_ci", true, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "created_at",
+			ddl:  "`created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL",
+			row:  []driver.Value{"timestamp", "timestamp", "NO", "", "CURRENT_TIMESTAMP", "DEFAULT_GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "updated_at",
+			ddl:  "`updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NOT NULL",
+			row:  []driver.Value{"timestamp", "timestamp", "NO", "", "CURRENT_TIMESTAMP", "DEFAULT_GENERATED on update CURRENT_TIMESTAMP", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "doc",
+			ddl:  "`doc` JSON DEFAULT ('{}') NOT NULL",
+			row:  []driver.Value{"json", "json", "NO", "", `_utf8mb4\'{}\'`, "DEFAULT_GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "token",
+			ddl:  "`token` BINARY(16) DEFAULT (uuid_to_bin(uuid())) NOT NULL",
+			row:  []driver.Value{"binary", "binary(16)", "NO", "", "uuid_to_bin(uuid())", "DEFAULT_GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", nil, ""},
+		},
+		{
+			name: "total",
+			ddl:  "`total` INT SIGNED GENERATED ALWAYS AS ((`price` * 2)) VIRTUAL NULL",
+			row:  []driver.Value{"int", "int", "YES", "", nil, "VIRTUAL GENERATED", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "(`price` * 2)", nil, ""},
+		},
+		{
+			name: "location",
+			ddl:  "`location` POINT SRID 4326 NOT NULL",
+			row:  []driver.Value{"point", "point", "NO", "", nil, "", nil, nil, false, "utf8mb4", "utf8mb4_0900_ai_ci", "", "4326", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &adapter{db: mockColumn(tt.name, tt.row...)}
+			col, err := a.GetColumn("table", tt.name)
+			if err != nil {
+				t.Fatalf("GetColumn() error = %v", err)
+			}
+
+			if got := a.generateColumn(tt.name, col); got != tt.ddl {
+				t.Errorf("generateColumn() of the reversed column =\n%s\nwant\n%s", got, tt.ddl)
 			}
 		})
 	}
@@ -711,8 +947,8 @@ func Test_reverser_GetIndex(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getIndexQuery, "table", "col")).
-						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME"}).
-							AddRow(0, "col"))
+						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "INDEX_TYPE"}).
+							AddRow(0, "col", "BTREE"))
 					return db
 				}(),
 			},
@@ -731,8 +967,8 @@ func Test_reverser_GetIndex(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getIndexQuery, "table", "col")).
-						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME"}).
-							AddRow(0, "col").AddRow(0, "col2"))
+						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "INDEX_TYPE"}).
+							AddRow(0, "col", "BTREE").AddRow(0, "col2", "BTREE"))
 					return db
 				}(),
 			},
@@ -751,8 +987,8 @@ func Test_reverser_GetIndex(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getIndexQuery, "table", "col")).
-						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME"}).
-							AddRow(1, "col"))
+						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "INDEX_TYPE"}).
+							AddRow(1, "col", "BTREE"))
 					return db
 				}(),
 			},
@@ -761,6 +997,26 @@ func Test_reverser_GetIndex(t *testing.T) {
 				Columns: []string{"col"},
 			},
 		},
+		{
+			name: "spatial index",
+			args: args{
+				table:     "table",
+				indexName: "col",
+			},
+			fields: fields{
+				db: func() *sql.DB {
+					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					mock.ExpectQuery(fmt.Sprintf(getIndexQuery, "table", "col")).
+						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "INDEX_TYPE"}).
+							AddRow(0, "col", "SPATIAL"))
+					return db
+				}(),
+			},
+			want: schema.Index{
+				Columns: []string{"col"},
+				Type:    schema.IndexTypeSpatial,
+			},
+		},
 		{
 			name: "query error",
 			args: args{
@@ -787,8 +1043,8 @@ func Test_reverser_GetIndex(t *testing.T) {
 				db: func() *sql.DB {
 					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 					mock.ExpectQuery(fmt.Sprintf(getIndexQuery, "table", "col")).
-						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "EXTRA_COL"}).
-							AddRow(1, "col", "aaaaah"))
+						WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "INDEX_TYPE", "EXTRA_COL"}).
+							AddRow(1, "col", "BTREE", "aaaaah"))
 					return db
 				}(),
 			},
@@ -835,6 +1091,47 @@ func Test_reverser_GetReference(t *testing.T) {
 		want    schema.Reference
 		wantErr string
 	}{
+		{
+			name: "set null and cascade actions",
+			args: args{
+				table:         "table",
+				referenceName: "foreign",
+			},
+			fields: fields{
+				db: func() *sql.DB {
+					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					mock.ExpectQuery(fmt.Sprintf(getReferenceQuery, "table", "foreign")).
+						WillReturnRows(mock.NewRows([]string{"UPDATE_RULE", "DELETE_RULE", "CONSTRAINT_NAME"}).
+							AddRow("CASCADE", "SET NULL", "foreign_fk"))
+					mock.ExpectQuery(fmt.Sprintf(getReferenceColumnsQuery, "table", "foreign", "foreign_fk")).
+						WillReturnRows(mock.NewRows([]string{"COLUMN_NAME", "IS_NULLABLE"}).
+							AddRow("foreign_id", 0))
+					return db
+				}(),
+			},
+			want: schema.Reference{
+				OnUpdate:    "CASCADE",
+				OnDelete:    "SET NULL",
+				ColumnNames: []string{"foreign_id"},
+			},
+		},
+		{
+			name: "reference information scan error",
+			args: args{
+				table:         "table",
+				referenceName: "foreign",
+			},
+			fields: fields{
+				db: func() *sql.DB {
+					db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+					mock.ExpectQuery(fmt.Sprintf(getReferenceQuery, "table", "foreign")).
+						WillReturnRows(mock.NewRows([]string{"UPDATE_RULE", "DELETE_RULE"}).
+							AddRow("CASCADE", "SET NULL"))
+					return db
+				}(),
+			},
+			wantErr: "unable to scan reference information",
+		},
 		{
 			name: "non-optional reference with single column",
 			args: args{
@@ -1016,3 +1313,339 @@ func Test_reverser_GetReference(t *testing.T) {
 		})
 	}
 }
+
+func Test_reverser_ListChecks(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(fmt.Sprintf(listConstraintsQuery, "table", "CHECK")).
+		WillReturnRows(mock.NewRows([]string{"CONSTRAINT_NAME"}).AddRow("chk1").AddRow("chk2"))
+	mock.ExpectQuery(fmt.Sprintf(listConstraintsQuery, "table", "CHECK")).
+		WillReturnError(fmt.Errorf("oh no it broke"))
+
+	d := &adapter{db: db}
+	got, err := d.ListChecks("table")
+	if err != nil {
+		t.Fatalf("ListChecks() error = %v", err)
+	}
+	if want := []string{"chk1", "chk2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListChecks() got = %v, want %v", got, want)
+	}
+
+	if _, err = d.ListChecks("table"); err == nil || !strings.Contains(err.Error(), "unable to list checks") {
+		t.Errorf("ListChecks() error = %v, want 'unable to list checks'", err)
+	}
+}
+
+func Test_reverser_GetCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() *sql.DB
+		want    schema.Check
+		wantErr string
+	}{
+		{
+			name: "check found",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getCheckQuery, "table", "chk")).
+					WillReturnRows(mock.NewRows([]string{"CHECK_CLAUSE"}).AddRow("(`price` > 0)"))
+				return db
+			},
+			want: schema.Check{Name: "chk", Expr: "(`price` > 0)"},
+		},
+		{
+			name: "check missing",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getCheckQuery, "table", "chk")).
+					WillReturnRows(mock.NewRows([]string{"CHECK_CLAUSE"}))
+				return db
+			},
+			want:    schema.Check{Name: "chk"},
+			wantErr: "unable to get check",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &adapter{db: tt.db()}
+			got, err := d.GetCheck("table", "chk")
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("GetCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCheck() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reverser_GetUnique(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() *sql.DB
+		want    schema.Unique
+		wantErr string
+	}{
+		{
+			name: "two columns",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getUniqueQuery, "table", "uq")).
+					WillReturnRows(mock.NewRows([]string{"COLUMN_NAME"}).AddRow("a").AddRow("b"))
+				return db
+			},
+			want: schema.Unique{Name: "uq", Columns: []string{"a", "b"}},
+		},
+		{
+			name: "unique missing",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getUniqueQuery, "table", "uq")).
+					WillReturnRows(mock.NewRows([]string{"COLUMN_NAME"}))
+				return db
+			},
+			want:    schema.Unique{Name: "uq"},
+			wantErr: "unable to find any columns",
+		},
+		{
+			name: "query error",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getUniqueQuery, "table", "uq")).
+					WillReturnError(fmt.Errorf("oh no it broke"))
+				return db
+			},
+			want:    schema.Unique{Name: "uq"},
+			wantErr: "unable to get information for unique",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &adapter{db: tt.db()}
+			got, err := d.GetUnique("table", "uq")
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("GetUnique() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetUnique() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reverser_ListUniques(t *testing.T) {
+	d := &adapter{}
+	got, err := d.ListUniques("table")
+	if err != nil {
+		t.Fatalf("ListUniques() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ListUniques() got = %v, want none", got)
+	}
+}
+
+func Test_reverser_uniqueIndex_roundTrip(t *testing.T) {
+	index := schema.Index{Name: "email_idx", Columns: []string{"email"}, Unique: true}
+
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(listTablesQuery).
+		WillReturnRows(mock.NewRows([]string{"TABLE_NAME"}).AddRow("person"))
+	mock.ExpectQuery(fmt.Sprintf(listColumnsQuery, "person")).
+		WillReturnRows(mock.NewRows([]string{"COLUMN_NAME"}))
+	mock.ExpectQuery(fmt.Sprintf(listIndicesQuery, "person")).
+		WillReturnRows(mock.NewRows([]string{"INDEX_NAME"}).AddRow(index.Name))
+	mock.ExpectQuery(fmt.Sprintf(getIndexQuery, "person", index.Name)).
+		WillReturnRows(mock.NewRows([]string{"NOT NON_UNIQUE", "COLUMN_NAME", "INDEX_TYPE"}).AddRow(true, "email", "BTREE"))
+	mock.ExpectQuery(fmt.Sprintf(listReferencesQuery, "person")).
+		WillReturnRows(mock.NewRows([]string{"REFERENCED_TABLE_NAME"}))
+	mock.ExpectQuery(fmt.Sprintf(listConstraintsQuery, "person", "CHECK")).
+		WillReturnRows(mock.NewRows([]string{"CONSTRAINT_NAME"}))
+	mock.ExpectQuery(fmt.Sprintf(getTableOptionsQuery, "person")).
+		WillReturnRows(mock.NewRows([]string{"ENGINE", "CHARACTER_SET_NAME", "TABLE_COLLATION", "ROW_FORMAT", "TABLE_COMMENT"}).
+			AddRow("InnoDB", "utf8mb4", "utf8mb4_0900_ai_ci", "Dynamic", ""))
+	mock.ExpectQuery(listViewsQuery).
+		WillReturnRows(mock.NewRows([]string{"TABLE_NAME"}))
+
+	a := &adapter{db: db}
+	read := reverse.InitDatabaseReader(func(string) (reverse.Adapter, error) { return a, nil })
+	got, err := read(yoyo.Config{})
+	if err != nil {
+		t.Fatalf("reading the database: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+	if len(got.Tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(got.Tables))
+	}
+
+	table := got.Tables[0]
+	if len(table.Uniques) != 0 {
+		t.Errorf("got uniques %v, want none", table.Uniques)
+	}
+	if !reflect.DeepEqual(table.Indices, []schema.Index{index}) {
+		t.Fatalf("got indices %v, want %v", table.Indices, []schema.Index{index})
+	}
+	if got, want := a.AddIndex("person", table.Indices[0].Name, table.Indices[0]), a.AddIndex("person", index.Name, index); got != want {
+		t.Errorf("AddIndex() for the read index = %q, want %q", got, want)
+	}
+}
+
+func Test_reverser_GetTableOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() *sql.DB
+		want    schema.TableOptions
+		wantErr string
+	}{
+		{
+			name: "table found",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getTableOptionsQuery, "table")).
+					WillReturnRows(mock.NewRows([]string{"ENGINE", "CHARACTER_SET_NAME", "TABLE_COLLATION", "ROW_FORMAT", "TABLE_COMMENT"}).
+						AddRow("InnoDB", "utf8mb4", "utf8mb4_0900_ai_ci", "Dynamic", "people"))
+				return db
+			},
+			want: schema.TableOptions{
+				Engine:    "InnoDB",
+				Charset:   "utf8mb4",
+				Collation: "utf8mb4_0900_ai_ci",
+				RowFormat: "DYNAMIC",
+				Comment:   "people",
+			},
+		},
+		{
+			name: "table missing",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getTableOptionsQuery, "table")).
+					WillReturnRows(mock.NewRows([]string{"ENGINE", "CHARACTER_SET_NAME", "TABLE_COLLATION", "ROW_FORMAT", "TABLE_COMMENT"}))
+				return db
+			},
+			wantErr: "unable to get options for table",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &adapter{db: tt.db()}
+			got, err := d.GetTableOptions("table")
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("GetTableOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTableOptions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reverser_ListViews(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() *sql.DB
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "two views",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(listViewsQuery).
+					WillReturnRows(mock.NewRows([]string{"TABLE_NAME"}).
+						AddRow("view1").
+						AddRow("view2"))
+				return db
+			},
+			want: []string{"view1", "view2"},
+		},
+		{
+			name: "zero views",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(listViewsQuery).
+					WillReturnRows(mock.NewRows([]string{"TABLE_NAME"}))
+				return db
+			},
+		},
+		{
+			name: "wrong number of columns",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(listViewsQuery).
+					WillReturnRows(mock.NewRows([]string{"TABLE_NAME", "bonus_col"}).
+						AddRow("view", "bonus_val"))
+				return db
+			},
+			wantErr: "unable to scan view results",
+		},
+		{
+			name: "query error",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(listViewsQuery).
+					WillReturnError(fmt.Errorf("oh no it broke"))
+				return db
+			},
+			wantErr: "unable to list views",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &adapter{db: tt.db()}
+			got, err := d.ListViews()
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ListViews() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListViews() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reverser_GetView(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() *sql.DB
+		want    schema.View
+		wantErr string
+	}{
+		{
+			name: "view found",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getViewQuery, "view")).
+					WillReturnRows(mock.NewRows([]string{"VIEW_DEFINITION"}).
+						AddRow("select `db`.`table`.`id` AS `id` from `db`.`table`"))
+				return db
+			},
+			want: schema.View{
+				Table:      schema.Table{Name: "view"},
+				Definition: "select `db`.`table`.`id` AS `id` from `db`.`table`",
+			},
+		},
+		{
+			name: "view missing",
+			db: func() *sql.DB {
+				db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				mock.ExpectQuery(fmt.Sprintf(getViewQuery, "view")).
+					WillReturnRows(mock.NewRows([]string{"VIEW_DEFINITION"}))
+				return db
+			},
+			want:    schema.View{Table: schema.Table{Name: "view"}},
+			wantErr: "unable to get definition of view",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &adapter{db: tt.db()}
+			got, err := d.GetView("view")
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("GetView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetView() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
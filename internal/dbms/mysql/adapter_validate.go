@@ -1,7 +1,12 @@
 package mysql
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
+	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
 func (*adapter) SupportsDatatype(dt datatype.Datatype) bool {
@@ -24,7 +29,17 @@ func (*adapter) SupportsDatatype(dt datatype.Datatype) bool {
 		datatype.Date,
 		datatype.Timestamp,
 		datatype.Binary,
-		datatype.Year:
+		datatype.Year,
+		datatype.JSON,
+		datatype.UUID,
+		datatype.Geometry,
+		datatype.Point,
+		datatype.LineString,
+		datatype.Polygon,
+		datatype.MultiPoint,
+		datatype.MultiLineString,
+		datatype.MultiPolygon,
+		datatype.GeometryCollection:
 		return true
 	}
 
@@ -34,3 +49,51 @@ func (*adapter) SupportsDatatype(dt datatype.Datatype) bool {
 func (*adapter) SupportsAutoIncrement() bool {
 	return true
 }
+
+// IdentifierLimit returns the most characters MySQL allows in an identifier
+func (*adapter) IdentifierLimit() int {
+	return dialect.IdentifierLimit(dialect.MySQL)
+}
+
+// TruncatesIdentifiers returns false, because MySQL rejects identifiers which are too long
+func (*adapter) TruncatesIdentifiers() bool {
+	return false
+}
+
+// IsReservedWord returns true if word, in any case, is reserved by MySQL
+func (*adapter) IsReservedWord(word string) bool {
+	return reservedWords[strings.ToUpper(word)]
+}
+
+// ValidateTable returns an error if the table uses features MySQL doesn't support
+func (*adapter) ValidateTable(t schema.Table) error {
+	if t.Options.Tablespace != "" || t.Options.Unlogged {
+		return fmt.Errorf("tablespace and unlogged are postgresql table options")
+	}
+	for _, r := range t.References {
+		// InnoDB parses SET DEFAULT but rejects it when creating the foreign key
+		if r.OnDelete == schema.ActionSetDefault || r.OnUpdate == schema.ActionSetDefault {
+			return fmt.Errorf("mysql does not support SET DEFAULT on reference to `%s`", r.TableName)
+		}
+	}
+	for _, i := range t.Indices {
+		if i.Type != schema.IndexTypeSpatial {
+			continue
+		}
+		if len(i.Columns) != 1 {
+			return fmt.Errorf("mysql spatial index `%s` must have exactly one column", i.Name)
+		}
+		if c, _ := t.GetColumn(i.Columns[0]); c.Nullable {
+			return fmt.Errorf("mysql spatial index `%s` requires column `%s` to be NOT NULL", i.Name, c.Name)
+		}
+	}
+	for _, c := range t.Columns {
+		if c.Datatype.IsJSON() && t.IsKeyColumn(c.Name) {
+			return fmt.Errorf("mysql cannot index JSON column `%s`", c.Name)
+		}
+		if c.Datatype == datatype.UUID && c.UUIDStorage == schema.UUIDStorageNative {
+			return fmt.Errorf("mysql has no native UUID type for column `%s`, use binary, binary_swapped or char uuid_storage", c.Name)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
 func Test_validator_SupportsDatatype(t *testing.T) {
@@ -22,6 +23,26 @@ func Test_validator_SupportsDatatype(t *testing.T) {
 			args: args{dt: datatype.Integer},
 			want: true,
 		},
+		{
+			args: args{dt: datatype.JSON},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.JSONB},
+			want: false,
+		},
+		{
+			args: args{dt: datatype.UUID},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.ArrayOf(datatype.Integer)},
+			want: false,
+		},
+		{
+			args: args{dt: datatype.Int4Range},
+			want: false,
+		},
 		{
 			args: args{dt: 0},
 			want: false,
@@ -37,3 +58,67 @@ func Test_validator_SupportsDatatype(t *testing.T) {
 		})
 	}
 }
+
+func Test_adapter_ValidateTable(t *testing.T) {
+	tests := map[string]struct {
+		ref     schema.Reference
+		indices []schema.Index
+		storage string
+		options schema.TableOptions
+		wantErr bool
+	}{
+		"cascade":               {ref: schema.Reference{TableName: "t", OnDelete: schema.ActionCascade}},
+		"set null":              {ref: schema.Reference{TableName: "t", OnDelete: schema.ActionSetNull}},
+		"set default on delete": {ref: schema.Reference{TableName: "t", OnDelete: schema.ActionSetDefault}, wantErr: true},
+		"set default on update": {ref: schema.Reference{TableName: "t", OnUpdate: schema.ActionSetDefault}, wantErr: true},
+		"indexed json column":   {indices: []schema.Index{{Name: "i", Columns: []string{"doc"}}}, wantErr: true},
+		"binary uuid":           {storage: schema.UUIDStorageBinary},
+		"native uuid":           {storage: schema.UUIDStorageNative, wantErr: true},
+		"spatial index":         {indices: []schema.Index{{Name: "i", Columns: []string{"location"}, Type: schema.IndexTypeSpatial}}},
+		"nullable spatial index": {
+			indices: []schema.Index{{Name: "i", Columns: []string{"area"}, Type: schema.IndexTypeSpatial}},
+			wantErr: true,
+		},
+		"two column spatial index": {
+			indices: []schema.Index{{Name: "i", Columns: []string{"location", "area"}, Type: schema.IndexTypeSpatial}},
+			wantErr: true,
+		},
+		"engine":   {options: schema.TableOptions{Engine: "InnoDB"}},
+		"unlogged": {options: schema.TableOptions{Unlogged: true}, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewAdapter().ValidateTable(schema.Table{
+				Columns: []schema.Column{
+					{Name: "doc", Datatype: datatype.JSON},
+					{Name: "id", Datatype: datatype.UUID, UUIDStorage: tt.storage},
+					{Name: "location", Datatype: datatype.Point},
+					{Name: "area", Datatype: datatype.Polygon, Nullable: true},
+				},
+				Indices:    tt.indices,
+				References: []schema.Reference{tt.ref},
+				Options:    tt.options,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_adapter_IsReservedWord(t *testing.T) {
+	tests := map[string]bool{
+		"order":  true,
+		"ORDER":  true,
+		"Select": true,
+		"user":   false,
+		"person": false,
+	}
+	for word, want := range tests {
+		t.Run(word, func(t *testing.T) {
+			if got := NewAdapter().IsReservedWord(word); got != want {
+				t.Errorf("IsReservedWord() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/yoyo-project/yoyo/internal/datatype"
 	"github.com/yoyo-project/yoyo/internal/dbms/base"
@@ -9,6 +11,8 @@ import (
 	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
+var currentTimestamp = regexp.MustCompile(`(?i)^(CURRENT_TIMESTAMP|NOW\(\)|LOCALTIMESTAMP)(\(\d*\))?$`)
+
 // NewAdapter returns an implementation of migration.Dialect for PostgreSQL
 func NewAdapter() *adapter {
 	return &adapter{
@@ -30,71 +34,281 @@ func (a *adapter) TypeString(dt datatype.Datatype) (s string, err error) {
 	if !a.SupportsDatatype(dt) {
 		return "", fmt.Errorf("datatype %s is not supported in postgresql", dt)
 	}
-	switch dt {
+	switch {
+	case dt == datatype.Geometry:
+		s = "GEOMETRY"
+	case dt.IsSpatial():
+		// PostGIS has a single GEOMETRY type, constrained to a subtype with a type modifier
+		s = fmt.Sprintf("GEOMETRY(%s)", dt)
+	case dt == datatype.MediumInt:
+		s = "INTEGER"
+	case dt == datatype.TinyText, dt == datatype.MediumText, dt == datatype.LongText:
+		s = "TEXT"
+	case dt == datatype.Blob:
+		s = "BYTEA"
+	case dt == datatype.Enum:
+		// PostgreSQL enums are separate types, so enum columns are text with a CHECK of their values instead
+		s = "TEXT"
 	default:
 		s, err = a.Base.TypeString(dt)
 	}
 	return s, err
 }
 
+// ValidateTable returns an error if the table uses features PostgreSQL doesn't support
+func (a *adapter) ValidateTable(t schema.Table) error {
+	if o := t.Options; o.Engine != "" || o.Charset != "" || o.Collation != "" || o.RowFormat != "" {
+		return fmt.Errorf("engine, charset, collation and row_format are mysql table options")
+	}
+	for _, c := range t.Columns {
+		if c.Generated != nil && !c.Generated.Stored {
+			return fmt.Errorf("postgresql only supports stored generated columns, but `%s` is virtual", c.Name)
+		}
+		if c.Datatype == datatype.JSON && t.IsKeyColumn(c.Name) {
+			// Unlike JSONB, JSON has no equality operator, so it can't be part of a btree index
+			return fmt.Errorf("postgresql cannot index JSON column `%s`, use JSONB instead", c.Name)
+		}
+		if c.Datatype == datatype.UUID && c.UUIDStorage != "" && c.UUIDStorage != schema.UUIDStorageNative {
+			return fmt.Errorf("postgresql only supports native uuid_storage, but `%s` uses %s", c.Name, c.UUIDStorage)
+		}
+	}
+	return nil
+}
+
 func (a *adapter) PreparedStatementPlaceholders(count int) []string {
 	panic("implement me")
 }
 
-// CreateTable generates a query to create a given table.
+// JSONPathEquals uses the #>> operator, so paths are text arrays like '{address,city}'
+func (a *adapter) JSONPathEquals() (string, bool) {
+	return "%s #>> ? = ?", true
+}
+
+// JSONContains uses the @> operator, which only exists for JSONB, so JSON columns are cast
+func (a *adapter) JSONContains(dt datatype.Datatype) (string, bool) {
+	if dt == datatype.JSON {
+		return "%s::jsonb @> ?", true
+	}
+	return "%s @> ?", true
+}
+
+// CreateTable generates a query to create a given table, unlogged and in a tablespace if its options set them. Its
+// comments are set by AddComments, since PostgreSQL can't declare them here.
 func (a *adapter) CreateTable(table string, t schema.Table) string {
-	panic("implement me")
+	var defs []string
+	for _, c := range t.Columns {
+		defs = append(defs, a.generateColumn(c.Name, c))
+	}
+	if pks := t.PKColNames(); len(pks) > 0 {
+		defs = append(defs, fmt.Sprintf(`PRIMARY KEY ("%s")`, strings.Join(pks, `", "`)))
+	}
+
+	create := "CREATE TABLE"
+	if t.Options.Unlogged {
+		create = "CREATE UNLOGGED TABLE"
+	}
+	var tablespace string
+	if t.Options.Tablespace != "" {
+		tablespace = fmt.Sprintf(` TABLESPACE "%s"`, t.Options.Tablespace)
+	}
+
+	return fmt.Sprintf("%s \"%s\" (\n    %s\n)%s;", create, table, strings.Join(defs, ",\n    "), tablespace)
+}
+
+// CreateView generates a query that creates or replaces a given view
+func (a *adapter) CreateView(view string, v schema.View) string {
+	return fmt.Sprintf(`CREATE OR REPLACE VIEW "%s" AS %s;`, view, v.Definition)
 }
 
-// AddColumn generates a query that adds a column to an existing table
+// AddColumn generates a query that adds a column to an existing table, followed by one that sets its comment if it has
+// one
 func (a *adapter) AddColumn(table, column string, c schema.Column) string {
-	panic("implement me")
+	q := fmt.Sprintf(`ALTER TABLE "%s" ADD COLUMN %s;`, table, a.generateColumn(column, c))
+	if c.Comment != "" {
+		q += "\n" + columnComment(table, column, c.Comment)
+	}
+	return q
 }
 
-// AddIndex returns a string query which adds the specified index to a table
-func (a *adapter) AddIndex(table, index string, i schema.Index) string {
-	panic("implement me")
+// generateColumn returns the definition of a column in CREATE TABLE and ALTER TABLE queries
+func (a *adapter) generateColumn(column string, c schema.Column) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(`"%s" %s`, column, a.columnType(c)))
+
+	if c.Collation != "" {
+		sb.WriteString(fmt.Sprintf(` COLLATE "%s"`, c.Collation))
+	}
+
+	switch {
+	case c.Generated != nil:
+		// PostgreSQL only has stored generated columns, which can't have defaults
+		sb.WriteString(fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", c.Generated.Expr))
+	case c.DefaultExpr != "":
+		sb.WriteString(fmt.Sprintf(" DEFAULT (%s)", c.DefaultExpr))
+	case c.Default != nil:
+		sb.WriteString(" DEFAULT " + defaultLiteral(c.Datatype, *c.Default))
+	case c.AutoNowAdd || c.AutoNow:
+		// PostgreSQL has no ON UPDATE, so auto_now columns are set on update by the generated repositories
+		sb.WriteString(" DEFAULT CURRENT_TIMESTAMP")
+	}
+
+	if !c.Nullable {
+		sb.WriteString(" NOT")
+	}
+	sb.WriteString(" NULL")
+
+	if c.IsEnum() {
+		sb.WriteString(fmt.Sprintf(` CHECK ("%s" IN (%s))`, column, strings.Join(c.Params, ", ")))
+	}
+
+	return sb.String()
 }
 
-// AddReference generates a query that adds columns and foreign keys for the given table, foreign table, and schema.Reference
-func (a *adapter) AddReference(table string, dt schema.Table, i schema.Reference) string {
-	panic("implement me")
+// columnType returns the type of a column with its parameters. The parameters of an array apply to its elements, and
+// the parameter of a spatial column is its SRID.
+func (a *adapter) columnType(c schema.Column) string {
+	ts, _ := a.TypeString(c.Datatype)
+	switch {
+	case c.Datatype.IsSpatial() && c.SRID() != "":
+		// PostGIS takes the SRID as the second type modifier, after the subtype
+		return fmt.Sprintf("GEOMETRY(%s, %s)", c.Datatype, c.SRID())
+	case len(c.Params) == 0 || c.Datatype.IsSpatial() || c.Datatype == datatype.Enum:
+		// the values of an enum are in its CHECK
+		return ts
+	case c.Datatype.IsArray():
+		el, _ := a.TypeString(c.Datatype.Element())
+		return fmt.Sprintf("%s(%s)[]", el, strings.Join(c.Params, ", "))
+	default:
+		return fmt.Sprintf("%s(%s)", ts, strings.Join(c.Params, ", "))
+	}
 }
 
-// ListTables returns a list of tables on the selected database.
-func (a *adapter) ListTables() ([]string, error) {
-	panic("implement me")
+// defaultLiteral returns the DEFAULT value of a column of the given datatype, which is quoted unless it's a number, a
+// boolean or the current timestamp
+func defaultLiteral(dt datatype.Datatype, def string) string {
+	switch {
+	case dt.IsNumeric(), dt == datatype.Boolean:
+		return def
+	case dt.IsTime() && currentTimestamp.MatchString(def):
+		return def
+	default:
+		return quote(def)
+	}
 }
 
-// ListColumns returns a []string of column names for the given table
-// It does NOT return any columns which are foreign key columns. These will instead come from ListReferences
-func (a *adapter) ListColumns(table string) ([]string, error) {
-	panic("implement me")
+// AddIndex returns a string query which adds the specified index to a table. Spatial indices use GiST.
+func (a *adapter) AddIndex(table, index string, i schema.Index) string {
+	var unique, using string
+	if i.Unique {
+		unique = "UNIQUE "
+	}
+	if i.Type == schema.IndexTypeSpatial {
+		using = " USING GIST"
+	}
+	return fmt.Sprintf(`CREATE %sINDEX "%s" ON "%s"%s ("%s");`, unique, index, table, using, strings.Join(i.Columns, `", "`))
 }
 
-// ListIndices returns a []string of index names for the given table.
-// It will NOT return information referring to PrimaryKey or Foreign Keys, which will instead come from GetColumn and
-// ListReferences respectively
-func (a *adapter) ListIndices(table string) ([]string, error) {
-	panic("implement me")
+// AddCheck generates a query that adds the specified CHECK constraint to an existing table
+func (a *adapter) AddCheck(table string, c schema.Check) string {
+	return fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT "%s" CHECK (%s);`, table, c.Name, c.Expr)
 }
 
-// ListReferences returns a []string of tables referenced from the given table.
-func (a *adapter) ListReferences(table string) ([]string, error) {
-	panic("implement me")
+// AddUnique generates a query that adds the specified UNIQUE constraint to an existing table
+func (a *adapter) AddUnique(table string, u schema.Unique) string {
+	return fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT "%s" UNIQUE ("%s");`, table, u.Name, strings.Join(u.Columns, `", "`))
 }
 
-// GetColumn returns a schema.Column representing the given tableName and colName.
-func (a *adapter) GetColumn(table, column string) (schema.Column, error) {
-	panic("implement me")
+// AddComments generates COMMENT ON queries for a new table and its columns, since PostgreSQL can't declare them in
+// CREATE TABLE
+func (a *adapter) AddComments(table string, t schema.Table) string {
+	var comments []string
+	if t.Options.Comment != "" {
+		comments = append(comments, fmt.Sprintf(`COMMENT ON TABLE "%s" IS %s;`, table, quote(t.Options.Comment)))
+	}
+	for _, c := range t.Columns {
+		if c.Comment != "" {
+			comments = append(comments, columnComment(table, c.Name, c.Comment))
+		}
+	}
+	return strings.Join(comments, "\n")
 }
 
-// GetIndex returns a schema.Index representing the given tableName and indexName.
-func (a *adapter) GetIndex(table, column string) (schema.Index, error) {
-	panic("implement me")
+// columnComment generates a query that sets the comment of a column
+func columnComment(table, column, comment string) string {
+	return fmt.Sprintf(`COMMENT ON COLUMN "%s"."%s" IS %s;`, table, column, quote(comment))
 }
 
-// GetReference returns a schema.Reference representing the given tableName and indexName.
-func (a *adapter) GetReference(table, column string) (schema.Reference, error) {
-	panic("implement me")
+// AlterTableOptions generates queries that move a table to the schema's tablespace, switch it between logged and
+// unlogged, and set its comment
+func (a *adapter) AlterTableOptions(table string, from, to schema.TableOptions) string {
+	var (
+		alters []string
+		sb     = strings.Builder{}
+	)
+
+	if to.Tablespace != "" && to.Tablespace != from.Tablespace {
+		alters = append(alters, fmt.Sprintf(`SET TABLESPACE "%s"`, to.Tablespace))
+	}
+	if to.Unlogged && !from.Unlogged {
+		alters = append(alters, "SET UNLOGGED")
+	} else if !to.Unlogged && from.Unlogged {
+		alters = append(alters, "SET LOGGED")
+	}
+	if len(alters) > 0 {
+		sb.WriteString(fmt.Sprintf(`ALTER TABLE "%s" %s;`, table, strings.Join(alters, ", ")))
+	}
+
+	if to.Comment != "" && to.Comment != from.Comment {
+		if sb.Len() > 0 {
+			sb.WriteRune('\n')
+		}
+		sb.WriteString(fmt.Sprintf(`COMMENT ON TABLE "%s" IS %s;`, table, quote(to.Comment)))
+	}
+
+	return sb.String()
+}
+
+// AddReference generates a query that adds columns and foreign keys for the given table, foreign table, and schema.Reference
+func (a *adapter) AddReference(table string, ft schema.Table, r schema.Reference) string {
+	var (
+		fCols = ft.PKColNames()
+		lCols = r.ColNames(ft)
+		sb    = strings.Builder{}
+	)
+
+	for i, lCol := range lCols {
+		fCol, _ := ft.GetColumn(fCols[i])
+
+		// the foreign key column matches the definition of the primary key column, without its key properties or comment
+		fCol.AutoIncrement = false
+		fCol.Comment = ""
+		fCol.PrimaryKey = false
+		fCol.Generated = nil
+		fCol.Nullable = !r.Required
+
+		sb.WriteString(a.AddColumn(table, lCol, fCol))
+		sb.WriteRune('\n')
+	}
+
+	constraint := r.ConstraintName
+	if constraint == "" {
+		constraint = schema.ShortenName(fmt.Sprintf("reference_%s_%s_%s", table, ft.Name, strings.Join(fCols, "_")), a.IdentifierLimit())
+	}
+	sb.WriteString(fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT "%s" FOREIGN KEY ("%s") REFERENCES "%s" ("%s")`,
+		table, constraint, strings.Join(lCols, `", "`), ft.Name, strings.Join(fCols, `", "`)))
+
+	if r.OnDelete != "" {
+		sb.WriteString(fmt.Sprintf(" ON DELETE %s", r.OnDelete))
+	}
+	if r.OnUpdate != "" {
+		sb.WriteString(fmt.Sprintf(" ON UPDATE %s", r.OnUpdate))
+	}
+	sb.WriteRune(';')
+
+	return sb.String()
+}
+
+// quote returns s as a single-quoted string literal
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
 }
@@ -0,0 +1,270 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+func Test_adapter_TypeString(t *testing.T) {
+	tests := map[string]struct {
+		dt      datatype.Datatype
+		want    string
+		wantErr bool
+	}{
+		"integer":     {dt: datatype.Integer, want: "INTEGER"},
+		"text array":  {dt: datatype.ArrayOf(datatype.Text), want: "TEXT[]"},
+		"geometry":    {dt: datatype.Geometry, want: "GEOMETRY"},
+		"point":       {dt: datatype.Point, want: "GEOMETRY(POINT)"},
+		"mediumint":   {dt: datatype.MediumInt, want: "INTEGER"},
+		"longtext":    {dt: datatype.LongText, want: "TEXT"},
+		"blob":        {dt: datatype.Blob, want: "BYTEA"},
+		"enum":        {dt: datatype.Enum, want: "TEXT"},
+		"unsupported": {dt: datatype.TinyInt, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewAdapter().TypeString(tt.dt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TypeString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TypeString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adapter_CreateTable(t *testing.T) {
+	tests := map[string]struct {
+		t    schema.Table
+		want string
+	}{
+		"primary key": {
+			t: schema.Table{Columns: []schema.Column{
+				{Name: "id", Datatype: datatype.Integer, PrimaryKey: true},
+				{Name: "name", Datatype: datatype.Varchar, Params: []string{"32"}, Nullable: true},
+			}},
+			want: "CREATE TABLE \"table\" (\n" +
+				"    \"id\" INTEGER NOT NULL,\n" +
+				"    \"name\" VARCHAR(32) NULL,\n" +
+				"    PRIMARY KEY (\"id\")\n" +
+				");",
+		},
+		"compound primary key": {
+			t: schema.Table{Columns: []schema.Column{
+				{Name: "a", Datatype: datatype.Integer, PrimaryKey: true},
+				{Name: "b", Datatype: datatype.UUID, PrimaryKey: true},
+			}},
+			want: "CREATE TABLE \"table\" (\n" +
+				"    \"a\" INTEGER NOT NULL,\n" +
+				"    \"b\" UUID NOT NULL,\n" +
+				"    PRIMARY KEY (\"a\", \"b\")\n" +
+				");",
+		},
+		"no primary key": {
+			t: schema.Table{Columns: []schema.Column{
+				{Name: "location", Datatype: datatype.Point, Params: []string{"4326"}},
+			}},
+			want: "CREATE TABLE \"table\" (\n" +
+				"    \"location\" GEOMETRY(POINT, 4326) NOT NULL\n" +
+				");",
+		},
+		"options": {
+			t: schema.Table{
+				Columns: []schema.Column{{Name: "id", Datatype: datatype.Integer, PrimaryKey: true}},
+				Options: schema.TableOptions{Tablespace: "fast", Unlogged: true, Comment: "set by AddComments"},
+			},
+			want: "CREATE UNLOGGED TABLE \"table\" (\n" +
+				"    \"id\" INTEGER NOT NULL,\n" +
+				"    PRIMARY KEY (\"id\")\n" +
+				") TABLESPACE \"fast\";",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().CreateTable("table", tt.t); got != tt.want {
+				t.Errorf("CreateTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adapter_AddColumn(t *testing.T) {
+	def := func(s string) *string { return &s }
+	tests := map[string]struct {
+		c    schema.Column
+		want string
+	}{
+		"geometry": {
+			c:    schema.Column{Datatype: datatype.Geometry, Nullable: true},
+			want: `ALTER TABLE "table" ADD COLUMN "col" GEOMETRY NULL;`,
+		},
+		"spatial with srid": {
+			c:    schema.Column{Datatype: datatype.Polygon, Params: []string{"4326"}},
+			want: `ALTER TABLE "table" ADD COLUMN "col" GEOMETRY(POLYGON, 4326) NOT NULL;`,
+		},
+		"geometry with srid": {
+			c:    schema.Column{Datatype: datatype.Geometry, Params: []string{"3857"}},
+			want: `ALTER TABLE "table" ADD COLUMN "col" GEOMETRY(GEOMETRY, 3857) NOT NULL;`,
+		},
+		"decimal": {
+			c:    schema.Column{Datatype: datatype.Decimal, Params: []string{"10", "2"}, Default: def("0.5")},
+			want: `ALTER TABLE "table" ADD COLUMN "col" DECIMAL(10, 2) DEFAULT 0.5 NOT NULL;`,
+		},
+		"string default": {
+			c:    schema.Column{Datatype: datatype.Text, Default: def("it's"), Collation: "C"},
+			want: `ALTER TABLE "table" ADD COLUMN "col" TEXT COLLATE "C" DEFAULT 'it''s' NOT NULL;`,
+		},
+		"boolean default": {
+			c:    schema.Column{Datatype: datatype.Boolean, Default: def("true")},
+			want: `ALTER TABLE "table" ADD COLUMN "col" BOOLEAN DEFAULT true NOT NULL;`,
+		},
+		"expression default": {
+			c:    schema.Column{Datatype: datatype.UUID, DefaultExpr: "gen_random_uuid()"},
+			want: `ALTER TABLE "table" ADD COLUMN "col" UUID DEFAULT (gen_random_uuid()) NOT NULL;`,
+		},
+		"generated": {
+			c:    schema.Column{Datatype: datatype.Integer, Generated: &schema.Generated{Expr: "a + b", Stored: true}, Nullable: true},
+			want: `ALTER TABLE "table" ADD COLUMN "col" INTEGER GENERATED ALWAYS AS (a + b) STORED NULL;`,
+		},
+		"enum": {
+			c:    schema.Column{Datatype: datatype.Enum, Params: []string{"'red'", "'blue'"}, Default: def("red")},
+			want: `ALTER TABLE "table" ADD COLUMN "col" TEXT DEFAULT 'red' NOT NULL CHECK ("col" IN ('red', 'blue'));`,
+		},
+		"array with element params": {
+			c:    schema.Column{Datatype: datatype.ArrayOf(datatype.Varchar), Params: []string{"16"}, Nullable: true},
+			want: `ALTER TABLE "table" ADD COLUMN "col" VARCHAR(16)[] NULL;`,
+		},
+		"range": {
+			c:    schema.Column{Datatype: datatype.Int4Range},
+			want: `ALTER TABLE "table" ADD COLUMN "col" INT4RANGE NOT NULL;`,
+		},
+		"comment": {
+			c: schema.Column{Datatype: datatype.Text, Nullable: true, Comment: "the person's name"},
+			want: `ALTER TABLE "table" ADD COLUMN "col" TEXT NULL;` + "\n" +
+				`COMMENT ON COLUMN "table"."col" IS 'the person''s name';`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().AddColumn("table", "col", tt.c); got != tt.want {
+				t.Errorf("AddColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adapter_AddReference(t *testing.T) {
+	ft := schema.Table{Name: "person", Columns: []schema.Column{{Name: "id", Datatype: datatype.BigInt, PrimaryKey: true}}}
+	tests := map[string]struct {
+		r    schema.Reference
+		want string
+	}{
+		"optional": {
+			r: schema.Reference{TableName: "person", OnDelete: "SET NULL"},
+			want: `ALTER TABLE "post" ADD COLUMN "fk_person_id" BIGINT NULL;` + "\n" +
+				`ALTER TABLE "post" ADD CONSTRAINT "reference_post_person_id" FOREIGN KEY ("fk_person_id") REFERENCES "person" ("id") ON DELETE SET NULL;`,
+		},
+		"required with names": {
+			r: schema.Reference{TableName: "person", Required: true, ColumnNames: []string{"author_id"}, ConstraintName: "fk_post_author", OnUpdate: "CASCADE"},
+			want: `ALTER TABLE "post" ADD COLUMN "author_id" BIGINT NOT NULL;` + "\n" +
+				`ALTER TABLE "post" ADD CONSTRAINT "fk_post_author" FOREIGN KEY ("author_id") REFERENCES "person" ("id") ON UPDATE CASCADE;`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().AddReference("post", ft, tt.r); got != tt.want {
+				t.Errorf("AddReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adapter_AddIndex(t *testing.T) {
+	tests := map[string]struct {
+		i    schema.Index
+		want string
+	}{
+		"index": {
+			i:    schema.Index{Columns: []string{"a", "b"}},
+			want: `CREATE INDEX "idx" ON "table" ("a", "b");`,
+		},
+		"unique": {
+			i:    schema.Index{Columns: []string{"a"}, Unique: true},
+			want: `CREATE UNIQUE INDEX "idx" ON "table" ("a");`,
+		},
+		"spatial": {
+			i:    schema.Index{Columns: []string{"location"}, Type: schema.IndexTypeSpatial},
+			want: `CREATE INDEX "idx" ON "table" USING GIST ("location");`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().AddIndex("table", "idx", tt.i); got != tt.want {
+				t.Errorf("AddIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adapter_AlterTableOptions(t *testing.T) {
+	tests := map[string]struct {
+		from, to schema.TableOptions
+		want     string
+	}{
+		"nothing set": {},
+		"tablespace": {
+			to:   schema.TableOptions{Tablespace: "fast"},
+			want: `ALTER TABLE "table" SET TABLESPACE "fast";`,
+		},
+		"unlogged": {
+			to:   schema.TableOptions{Unlogged: true},
+			want: `ALTER TABLE "table" SET UNLOGGED;`,
+		},
+		"logged": {
+			from: schema.TableOptions{Unlogged: true},
+			want: `ALTER TABLE "table" SET LOGGED;`,
+		},
+		"tablespace, unlogged and comment": {
+			from: schema.TableOptions{Tablespace: "pg_default"},
+			to:   schema.TableOptions{Tablespace: "fast", Unlogged: true, Comment: "everyone's data"},
+			want: `ALTER TABLE "table" SET TABLESPACE "fast", SET UNLOGGED;` + "\n" +
+				`COMMENT ON TABLE "table" IS 'everyone''s data';`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().AlterTableOptions("table", tt.from, tt.to); got != tt.want {
+				t.Errorf("AlterTableOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adapter_AddComments(t *testing.T) {
+	tests := map[string]struct {
+		t    schema.Table
+		want string
+	}{
+		"no comments": {
+			t: schema.Table{Columns: []schema.Column{{Name: "id"}}},
+		},
+		"table and column comments": {
+			t: schema.Table{
+				Columns: []schema.Column{{Name: "id"}, {Name: "name", Comment: "the person's name"}},
+				Options: schema.TableOptions{Comment: "people"},
+			},
+			want: `COMMENT ON TABLE "table" IS 'people';` + "\n" +
+				`COMMENT ON COLUMN "table"."name" IS 'the person''s name';`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewAdapter().AddComments("table", tt.t); got != tt.want {
+				t.Errorf("AddComments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
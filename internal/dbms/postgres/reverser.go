@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/yoyo-project/yoyo/internal/reverse"
@@ -23,44 +24,82 @@ func InitReverserBuilder(open func(driver, dsn string) (*sql.DB, error)) func(ho
 	}
 }
 
+// errNotSupported is returned by every method of the reverser, because yoyo can't read PostgreSQL databases yet
+var errNotSupported = errors.New("reading postgresql databases isn't supported yet")
+
 type reverser struct {
 	db *sql.DB
 }
 
 // ListTables returns a list of tables on the selected database.
 func (r reverser) ListTables() ([]string, error) {
-	panic("implement me")
+	return nil, errNotSupported
 }
 
 // ListColumns returns a []string of column names for the given table
 // It does NOT return any columns which are foreign key columns. These will instead come from ListReferences
 func (r reverser) ListColumns(table string) ([]string, error) {
-	panic("implement me")
+	return nil, errNotSupported
 }
 
 // ListIndices returns a []string of index names for the given table.
 // It will NOT return information referring to PrimaryKey or Foreign Keys, which will instead come from GetColumn and
 // ListReferences respectively
 func (r reverser) ListIndices(table string) ([]string, error) {
-	panic("implement me")
+	return nil, errNotSupported
 }
 
 // ListReferences returns a []string of tables referenced from the given table.
 func (r reverser) ListReferences(table string) ([]string, error) {
-	panic("implement me")
+	return nil, errNotSupported
 }
 
 // GetColumn returns a schema.Column representing the given tableName and colName.
 func (r reverser) GetColumn(table, column string) (schema.Column, error) {
-	panic("implement me")
+	return schema.Column{}, errNotSupported
 }
 
 // GetIndex returns a schema.Index representing the given tableName and indexName.
 func (r reverser) GetIndex(table, column string) (schema.Index, error) {
-	panic("implement me")
+	return schema.Index{}, errNotSupported
 }
 
 // GetReference returns a schema.Reference representing the given tableName and indexName.
 func (r reverser) GetReference(table, column string) (schema.Reference, error) {
-	panic("implement me")
+	return schema.Reference{}, errNotSupported
+}
+
+// ListChecks returns a []string of CHECK constraint names for the given table
+func (r reverser) ListChecks(table string) ([]string, error) {
+	return nil, errNotSupported
+}
+
+// GetCheck returns a schema.Check representing the given CHECK constraint on the given table
+func (r reverser) GetCheck(table, check string) (schema.Check, error) {
+	return schema.Check{}, errNotSupported
+}
+
+// ListUniques returns a []string of UNIQUE constraint names for the given table
+func (r reverser) ListUniques(table string) ([]string, error) {
+	return nil, errNotSupported
+}
+
+// GetUnique returns a schema.Unique representing the given UNIQUE constraint on the given table
+func (r reverser) GetUnique(table, unique string) (schema.Unique, error) {
+	return schema.Unique{}, errNotSupported
+}
+
+// GetTableOptions returns the schema.TableOptions of the given table
+func (r reverser) GetTableOptions(table string) (schema.TableOptions, error) {
+	return schema.TableOptions{}, errNotSupported
+}
+
+// ListViews returns a []string of view names
+func (r reverser) ListViews() ([]string, error) {
+	return nil, errNotSupported
+}
+
+// GetView returns a schema.View with the definition of the given view
+func (r reverser) GetView(view string) (schema.View, error) {
+	return schema.View{}, errNotSupported
 }
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_reverser_notSupported(t *testing.T) {
+	r := reverser{}
+	for name, call := range map[string]func() error{
+		"ListTables":      func() error { _, err := r.ListTables(); return err },
+		"ListColumns":     func() error { _, err := r.ListColumns("table"); return err },
+		"ListIndices":     func() error { _, err := r.ListIndices("table"); return err },
+		"ListReferences":  func() error { _, err := r.ListReferences("table"); return err },
+		"GetColumn":       func() error { _, err := r.GetColumn("table", "column"); return err },
+		"GetIndex":        func() error { _, err := r.GetIndex("table", "index"); return err },
+		"GetReference":    func() error { _, err := r.GetReference("table", "reference"); return err },
+		"ListChecks":      func() error { _, err := r.ListChecks("table"); return err },
+		"GetCheck":        func() error { _, err := r.GetCheck("table", "check"); return err },
+		"ListUniques":     func() error { _, err := r.ListUniques("table"); return err },
+		"GetUnique":       func() error { _, err := r.GetUnique("table", "unique"); return err },
+		"GetTableOptions": func() error { _, err := r.GetTableOptions("table"); return err },
+		"ListViews":       func() error { _, err := r.ListViews(); return err },
+		"GetView":         func() error { _, err := r.GetView("view"); return err },
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); !errors.Is(err, errNotSupported) {
+				t.Errorf("%s() error = %v, want %v", name, err, errNotSupported)
+			}
+		})
+	}
+}
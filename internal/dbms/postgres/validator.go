@@ -1,11 +1,42 @@
 package postgres
 
-import "github.com/yoyo-project/yoyo/internal/datatype"
+import (
+	"strings"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
+)
 
 type validator struct {
 }
 
-func (*validator) SupportsDatatype(dt datatype.Datatype) bool {
+// IdentifierLimit returns the most characters PostgreSQL allows in an identifier
+func (v *validator) IdentifierLimit() int {
+	return dialect.IdentifierLimit(dialect.PostgreSQL)
+}
+
+// TruncatesIdentifiers returns true, because PostgreSQL truncates identifiers which are too long instead of rejecting
+// them
+func (v *validator) TruncatesIdentifiers() bool {
+	return true
+}
+
+// IsReservedWord returns true if word, in any case, is reserved by PostgreSQL
+func (v *validator) IsReservedWord(word string) bool {
+	return reservedWords[strings.ToUpper(word)]
+}
+
+func (v *validator) SupportsDatatype(dt datatype.Datatype) bool {
+	if dt.IsRange() || dt.IsSpatial() {
+		// Spatial types come from the PostGIS extension
+		return true
+	}
+	if dt.IsArray() {
+		// Arrays of binary, JSON and UUID values have no Go element type in the generated pg package
+		el := dt.Element()
+		return !el.IsBinary() && !el.IsJSON() && el != datatype.UUID && v.SupportsDatatype(el)
+	}
+
 	switch dt {
 	case datatype.Integer,
 		datatype.SmallInt,
@@ -20,7 +51,10 @@ func (*validator) SupportsDatatype(dt datatype.Datatype) bool {
 		datatype.Char,
 		datatype.Blob,
 		datatype.Enum,
-		datatype.Boolean:
+		datatype.Boolean,
+		datatype.JSON,
+		datatype.JSONB,
+		datatype.UUID:
 		return true
 	}
 
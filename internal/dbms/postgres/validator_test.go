@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
 func Test_validator_SupportsDatatype(t *testing.T) {
@@ -22,6 +23,34 @@ func Test_validator_SupportsDatatype(t *testing.T) {
 			args: args{dt: datatype.Integer},
 			want: true,
 		},
+		{
+			args: args{dt: datatype.JSON},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.JSONB},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.UUID},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.ArrayOf(datatype.Text)},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.ArrayOf(datatype.UUID)},
+			want: false,
+		},
+		{
+			args: args{dt: datatype.TsTzRange},
+			want: true,
+		},
+		{
+			args: args{dt: datatype.Point},
+			want: true,
+		},
 		{
 			args: args{dt: 0},
 			want: false,
@@ -36,3 +65,78 @@ func Test_validator_SupportsDatatype(t *testing.T) {
 		})
 	}
 }
+
+func Test_adapter_ValidateTable(t *testing.T) {
+	tests := map[string]struct {
+		table   schema.Table
+		wantErr bool
+	}{
+		"stored generated column": {
+			table: schema.Table{Columns: []schema.Column{
+				{Name: "col", Datatype: datatype.Integer, Generated: &schema.Generated{Expr: "a + b", Stored: true}},
+			}},
+		},
+		"virtual generated column": {
+			table: schema.Table{Columns: []schema.Column{
+				{Name: "col", Datatype: datatype.Integer, Generated: &schema.Generated{Expr: "a + b"}},
+			}},
+			wantErr: true,
+		},
+		"json primary key": {
+			table: schema.Table{Columns: []schema.Column{
+				{Name: "doc", Datatype: datatype.JSON, PrimaryKey: true},
+			}},
+			wantErr: true,
+		},
+		"native uuid": {
+			table: schema.Table{Columns: []schema.Column{
+				{Name: "id", Datatype: datatype.UUID, PrimaryKey: true, UUIDStorage: schema.UUIDStorageNative},
+			}},
+		},
+		"binary uuid": {
+			table: schema.Table{Columns: []schema.Column{
+				{Name: "id", Datatype: datatype.UUID, PrimaryKey: true, UUIDStorage: schema.UUIDStorageBinary},
+			}},
+			wantErr: true,
+		},
+		"indexed jsonb column": {
+			table: schema.Table{
+				Columns: []schema.Column{{Name: "doc", Datatype: datatype.JSONB}},
+				Indices: []schema.Index{{Name: "i", Columns: []string{"doc"}}},
+			},
+		},
+		"unlogged table in tablespace": {
+			table: schema.Table{Options: schema.TableOptions{Unlogged: true, Tablespace: "fast"}},
+		},
+		"engine": {
+			table:   schema.Table{Options: schema.TableOptions{Engine: "InnoDB"}},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := NewAdapter().ValidateTable(tt.table); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validator_IsReservedWord(t *testing.T) {
+	tests := map[string]bool{
+		"order":  true,
+		"user":   true,
+		"USER":   true,
+		"join":   true,
+		"status": false,
+		"person": false,
+	}
+	for word, want := range tests {
+		t.Run(word, func(t *testing.T) {
+			v := &validator{}
+			if got := v.IsReservedWord(word); got != want {
+				t.Errorf("IsReservedWord() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -16,6 +16,9 @@ type Adapter interface {
 	// CreateTable returns a string query which creates a full table with columns columns and primary key
 	CreateTable(table string, t schema.Table) string
 
+	// CreateView returns a string query which creates a view, or replaces it if it already exists
+	CreateView(view string, v schema.View) string
+
 	// AddColumn returns a string query which adds the specified column to a table
 	AddColumn(table, column string, c schema.Column) string
 
@@ -24,6 +27,20 @@ type Adapter interface {
 
 	// AddReference returns a string query which adds the specified index to a table
 	AddReference(table string, dt schema.Table, i schema.Reference) string
+
+	// AddCheck returns a string query which adds the specified CHECK constraint to a table
+	AddCheck(table string, c schema.Check) string
+
+	// AddUnique returns a string query which adds the specified UNIQUE constraint to a table
+	AddUnique(table string, u schema.Unique) string
+
+	// AddComments returns a string query which sets the comments of a new table and its columns, for dialects which
+	// can't declare them in CreateTable, or an empty string if there's nothing to set
+	AddComments(table string, t schema.Table) string
+
+	// AlterTableOptions returns a string query which changes a table's options from the existing ones to the ones in
+	// the schema, or an empty string if nothing needs to change
+	AlterTableOptions(table string, from, to schema.TableOptions) string
 }
 
 // LoadAdapter loads and returns an implementation of Adapter corresponding to the given name string
@@ -24,6 +24,10 @@ type TableGenerator func(table schema.Table, sw io.StringWriter) error
 // to generate SQL for working with references
 type RefGenerator func(localTable string, refs []schema.Reference, sw io.StringWriter) error
 
+// ViewGenerator functions take a schema.View and io.StringWriter. Implementations will use them to generate SQL for
+// creating or replacing views
+type ViewGenerator func(view schema.View, sw io.StringWriter) error
+
 // StringSearcher functions take a string and return true if the matching entity (table, column, etc) exists.
 type StringSearcher func(string) (bool, error)
 
@@ -41,6 +45,7 @@ func NewGenerator(
 	hasTable StringSearcher,
 	addMissingRefs RefGenerator,
 	addAllRefs RefGenerator,
+	createView ViewGenerator,
 ) Generator {
 	return func(db schema.Database, w io.StringWriter) error {
 		hasTables := make(map[string]bool)
@@ -91,6 +96,14 @@ func NewGenerator(
 			}
 		}
 
+		// Views select from tables, so they're generated last. The database's copy of a view's definition is usually
+		// rewritten and can't be compared to the schema's, so every view is replaced.
+		for _, v := range db.Views {
+			if err := createView(v, w); err != nil {
+				return fmt.Errorf("unable to generate view create query: %w", err)
+			}
+		}
+
 		return nil
 	}
 }
@@ -104,6 +117,45 @@ func NewTableAdder(
 		if err != nil {
 			return fmt.Errorf("unable to generate migration: %sw", err)
 		}
+		if comments := a.AddComments(t.Name, t); comments != "" {
+			if _, err = sw.WriteString(comments + "\n"); err != nil {
+				return fmt.Errorf("unable to generate migration: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+// NewViewCreator returns a ViewGenerator that creates or replaces a view
+func NewViewCreator(
+	a Adapter,
+) ViewGenerator {
+	return func(v schema.View, sw io.StringWriter) error {
+		_, err := sw.WriteString(a.CreateView(v.Name, v) + "\n")
+		if err != nil {
+			return fmt.Errorf("unable to generate migration: %w", err)
+		}
+		return nil
+	}
+}
+
+// NewOptionsAlterer returns a TableGenerator that changes the options of an existing table to the ones in the
+// schema.Table
+func NewOptionsAlterer(
+	a Adapter,
+	getOptions func(table string) (schema.TableOptions, error),
+) TableGenerator {
+	return func(t schema.Table, sw io.StringWriter) error {
+		existing, err := getOptions(t.Name)
+		if err != nil {
+			return fmt.Errorf("unable to get table options: %w", err)
+		}
+
+		if q := a.AlterTableOptions(t.Name, existing, t.Options); q != "" {
+			if _, err = sw.WriteString(q + "\n"); err != nil {
+				return fmt.Errorf("unable to generate migration: %w", err)
+			}
+		}
 		return nil
 	}
 }
@@ -154,6 +206,48 @@ func NewIndexAdder(
 	}
 }
 
+// NewConstraintAdder returns a TableGenerator that adds CHECK and UNIQUE constraints from a schema.Table.
+func NewConstraintAdder(
+	a Adapter,
+	options uint8,
+	hasCheck reverse.TableSearcher,
+	hasUnique reverse.TableSearcher,
+) TableGenerator {
+	return func(t schema.Table, sw io.StringWriter) error {
+		for _, c := range t.Checks {
+			if options&AddMissing > 0 && hasCheck(t.Name, c.Name) {
+				continue
+			}
+			_, err := sw.WriteString(a.AddCheck(t.Name, c) + "\n")
+			if err != nil {
+				return fmt.Errorf("unable to generate migration: %w", err)
+			}
+		}
+		for _, u := range t.Uniques {
+			if options&AddMissing > 0 && hasUnique(t.Name, u.Name) {
+				continue
+			}
+			_, err := sw.WriteString(a.AddUnique(t.Name, u) + "\n")
+			if err != nil {
+				return fmt.Errorf("unable to generate migration: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+// joinTableGenerators returns a TableGenerator which runs each of the given TableGenerators in order
+func joinTableGenerators(gs ...TableGenerator) TableGenerator {
+	return func(t schema.Table, sw io.StringWriter) error {
+		for _, g := range gs {
+			if err := g(t, sw); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // NewRefAdder returns a RefGenerator that adds references to a given table.
 func NewRefAdder(
 	a Adapter,
@@ -192,7 +286,7 @@ func NewRefAdder(
 func InitGeneratorLoader(
 	initReverseAdapter func(dia string) (adapter reverse.Adapter, err error),
 	initMigrationAdapter func(dia string) (a Adapter, err error),
-	newGenerator func(TableGenerator, TableGenerator, TableGenerator, TableGenerator, StringSearcher, RefGenerator, RefGenerator) Generator,
+	newGenerator func(TableGenerator, TableGenerator, TableGenerator, TableGenerator, StringSearcher, RefGenerator, RefGenerator, ViewGenerator) Generator,
 ) GeneratorLoader {
 	return func(config yoyo.Config) (Generator, error) {
 		var (
@@ -211,12 +305,22 @@ func InitGeneratorLoader(
 
 		return newGenerator(
 			NewTableAdder(migrator),
-			NewColumnAdder(migrator, AddMissing, reverse.InitHasColumn(reverser.GetColumn)),
-			NewIndexAdder(migrator, AddMissing, reverse.InitHasIndex(reverser.GetIndex)),
-			NewIndexAdder(migrator, AddAll, nil),
+			joinTableGenerators(
+				NewOptionsAlterer(migrator, reverser.GetTableOptions),
+				NewColumnAdder(migrator, AddMissing, reverse.InitHasColumn(reverser.GetColumn)),
+			),
+			joinTableGenerators(
+				NewIndexAdder(migrator, AddMissing, reverse.InitHasIndex(reverser.GetIndex)),
+				NewConstraintAdder(migrator, AddMissing, reverse.InitHasCheck(reverser.GetCheck), reverse.InitHasUnique(reverser.GetUnique)),
+			),
+			joinTableGenerators(
+				NewIndexAdder(migrator, AddAll, nil),
+				NewConstraintAdder(migrator, AddAll, nil, nil),
+			),
 			reverse.InitHasTable(reverser.ListTables),
 			NewRefAdder(migrator, config.Schema, AddMissing, reverse.InitHasReference(reverser.GetReference)),
 			NewRefAdder(migrator, config.Schema, AddAll, nil),
+			NewViewCreator(migrator),
 		), nil
 	}
 }
@@ -2,6 +2,7 @@ package migration
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/yoyo-project/yoyo/internal/datatype"
 	"github.com/yoyo-project/yoyo/internal/schema"
@@ -39,10 +40,36 @@ func (a *mockAdapter) CreateTable(table string, t schema.Table) string {
 	return ""
 }
 
+func (a *mockAdapter) CreateView(view string, v schema.View) string {
+	return fmt.Sprintf("view %s", view)
+}
+
 func (a *mockAdapter) AddIndex(table, index string, i schema.Index) string {
 	return ""
 }
 
+func (a *mockAdapter) AddCheck(table string, c schema.Check) string {
+	return fmt.Sprintf("check %s %s", table, c.Name)
+}
+
+func (a *mockAdapter) AddUnique(table string, u schema.Unique) string {
+	return fmt.Sprintf("unique %s %s", table, u.Name)
+}
+
+func (a *mockAdapter) AddComments(table string, t schema.Table) string {
+	if t.Options.Comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("comment %s", table)
+}
+
+func (a *mockAdapter) AlterTableOptions(table string, from, to schema.TableOptions) string {
+	if from == to {
+		return ""
+	}
+	return fmt.Sprintf("options %s %s", table, to.Engine)
+}
+
 func (a *mockAdapter) AddColumn(table, column string, c schema.Column) string {
 	return ""
 }
@@ -89,3 +116,31 @@ func (m mockReverseAdapter) GetIndex(table, column string) (schema.Index, error)
 func (m mockReverseAdapter) GetReference(table, column string) (schema.Reference, error) {
 	panic("implement me")
 }
+
+func (m mockReverseAdapter) ListChecks(table string) ([]string, error) {
+	panic("implement me")
+}
+
+func (m mockReverseAdapter) GetCheck(table, check string) (schema.Check, error) {
+	panic("implement me")
+}
+
+func (m mockReverseAdapter) ListUniques(table string) ([]string, error) {
+	panic("implement me")
+}
+
+func (m mockReverseAdapter) GetUnique(table, unique string) (schema.Unique, error) {
+	panic("implement me")
+}
+
+func (m mockReverseAdapter) GetTableOptions(table string) (schema.TableOptions, error) {
+	panic("implement me")
+}
+
+func (m mockReverseAdapter) ListViews() ([]string, error) {
+	panic("implement me")
+}
+
+func (m mockReverseAdapter) GetView(view string) (schema.View, error) {
+	panic("implement me")
+}
@@ -115,6 +115,105 @@ func TestNewIndexAdder(t *testing.T) {
 	}
 }
 
+func TestNewConstraintAdder(t *testing.T) {
+	table := schema.Table{
+		Name:    "myTable",
+		Checks:  []schema.Check{{Name: "chk", Expr: "a > 0"}},
+		Uniques: []schema.Unique{{Name: "uq", Columns: []string{"a"}}},
+	}
+	tests := []struct {
+		name     string
+		options  uint8
+		existing []string
+		want     string
+	}{
+		{
+			name:    "AddAll",
+			options: AddAll,
+			want:    "check myTable chk\nunique myTable uq\n",
+		},
+		{
+			name:     "AddMissing with existing check",
+			options:  AddMissing,
+			existing: []string{"chk"},
+			want:     "unique myTable uq\n",
+		},
+		{
+			name:     "AddMissing with all existing",
+			options:  AddMissing,
+			existing: []string{"chk", "uq"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := strings.Builder{}
+			exists := func(_, name string) bool {
+				for _, s := range tt.existing {
+					if s == name {
+						return true
+					}
+				}
+				return false
+			}
+			f := NewConstraintAdder(&mockAdapter{}, tt.options, exists, exists)
+
+			if err := f(table, &sb); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Fatalf("Wanted string '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewOptionsAlterer(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing schema.TableOptions
+		getErr   error
+		options  schema.TableOptions
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "unchanged options",
+			existing: schema.TableOptions{Engine: "InnoDB"},
+			options:  schema.TableOptions{Engine: "InnoDB"},
+		},
+		{
+			name:     "changed options",
+			existing: schema.TableOptions{Engine: "InnoDB"},
+			options:  schema.TableOptions{Engine: "MyISAM"},
+			want:     "options myTable MyISAM\n",
+		},
+		{
+			name:    "error getting options",
+			getErr:  errors.New("oh no"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := strings.Builder{}
+			getOptions := func(string) (schema.TableOptions, error) {
+				return tt.existing, tt.getErr
+			}
+			f := NewOptionsAlterer(&mockAdapter{}, getOptions)
+
+			err := f(schema.Table{Name: "myTable", Options: tt.options}, &sb)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Fatalf("Wanted string '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestNewColumnAdder(t *testing.T) {
 	type fields struct {
 		options         uint8
@@ -393,6 +492,7 @@ func TestNewGenerator(t *testing.T) {
 		callHasTable          = "callHasTable"
 		callAddMissingRefs    = "callAddMissingRefs"
 		callAddAllRefs        = "callAddAllRefs"
+		callCreateView        = "callCreateView"
 	)
 
 	type args struct {
@@ -581,6 +681,46 @@ func TestNewGenerator(t *testing.T) {
 			wantErr:     true,
 			errorOnCall: 4,
 		},
+		{
+			name: "view after tables",
+			args: args{db: schema.Database{
+				Tables: []schema.Table{
+					{
+						Name: "table",
+					},
+				},
+				Views: []schema.View{
+					{
+						Table:      schema.Table{Name: "view"},
+						Definition: "SELECT 1",
+					},
+				},
+			}},
+			existingTables: []string{"table"},
+			wantCallsInOrder: []string{
+				callHasTable,
+				callAddMissingColumns,
+				callAddMissingIndices,
+				callAddMissingRefs,
+				callCreateView,
+			},
+		},
+		{
+			name: "error creating view",
+			args: args{db: schema.Database{
+				Views: []schema.View{
+					{
+						Table:      schema.Table{Name: "view"},
+						Definition: "SELECT 1",
+					},
+				},
+			}},
+			wantCallsInOrder: []string{
+				callCreateView,
+			},
+			wantErr:     true,
+			errorOnCall: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -643,6 +783,13 @@ func TestNewGenerator(t *testing.T) {
 				}
 				return err
 			}
+			createView ViewGenerator = func(view schema.View, sw io.StringWriter) (err error) {
+				gotCallsInOrder = append(gotCallsInOrder, callCreateView)
+				if tt.errorOnCall == len(gotCallsInOrder) {
+					err = errors.New("err")
+				}
+				return err
+			}
 		)
 		t.Run(tt.name, func(t *testing.T) {
 			f := NewGenerator(
@@ -653,6 +800,7 @@ func TestNewGenerator(t *testing.T) {
 				hasTable,
 				addMissingRefs,
 				addAllRefs,
+				createView,
 			)
 
 			gotErr := f(tt.args.db, w)
@@ -717,6 +865,7 @@ func TestInitGeneratorLoader(t *testing.T) {
 				StringSearcher,
 				RefGenerator,
 				RefGenerator,
+				ViewGenerator,
 			) Generator {
 				return nil
 			}
@@ -767,6 +916,13 @@ func TestNewTableAdder1(t *testing.T) {
 			},
 			want: "\n",
 		},
+		{
+			name: "create table with comments",
+			args: args{
+				t: schema.Table{Name: "myTable", Options: schema.TableOptions{Comment: "mine"}},
+			},
+			want: "\ncomment myTable\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -784,3 +940,31 @@ func TestNewTableAdder1(t *testing.T) {
 		})
 	}
 }
+
+func TestNewViewCreator(t *testing.T) {
+	tests := []struct {
+		name string
+		view schema.View
+		want string
+	}{
+		{
+			name: "create view",
+			view: schema.View{Table: schema.Table{Name: "myView"}, Definition: "SELECT 1"},
+			want: "view myView\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewViewCreator(&mockAdapter{})
+			sb := strings.Builder{}
+
+			if err := f(tt.view, &sb); err != nil {
+				t.Errorf("NewViewCreator() unexpected error %#v", err)
+			}
+
+			if got := sb.String(); got != tt.want {
+				t.Errorf("NewViewCreator()\nwant %#v\n got %#v", tt.want, got)
+			}
+		})
+	}
+}
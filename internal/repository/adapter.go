@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 
+	"github.com/yoyo-project/yoyo/internal/datatype"
 	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
 	"github.com/yoyo-project/yoyo/internal/dbms/mysql"
 	"github.com/yoyo-project/yoyo/internal/dbms/postgres"
@@ -17,6 +18,14 @@ type AdapterBuilder func(host, userName, dbName, password, port string) (Adapter
 // Adapter is the yoyo interface for creating repository code
 type Adapter interface {
 	PreparedStatementPlaceholders(count int) []string
+	// JSONPathEquals returns the SQL format of a condition comparing the text at a path within a JSON column to a
+	// parameter. The format is given the column name, and its parameters are the path, in the dialect's own path syntax,
+	// and the value. If the dialect doesn't support it, ok is false.
+	JSONPathEquals() (format string, ok bool)
+	// JSONContains returns the SQL format of a condition matching JSON columns of the given datatype which contain the
+	// JSON document given as its parameter. The format is given the column name. If the dialect doesn't support it, ok
+	// is false.
+	JSONContains(dt datatype.Datatype) (format string, ok bool)
 }
 
 func LoadAdapter(dia string) (adapter Adapter, err error) {
@@ -7,8 +7,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/yoyo-project/yoyo/internal/file"
-
 	"github.com/yoyo-project/yoyo/internal/yoyo"
 
 	"github.com/yoyo-project/yoyo/internal/schema"
@@ -25,77 +23,25 @@ type Finder func(string) (string, error)
 func NewGenerator(
 	generateEntity EntityGenerator,
 	generateRepository EntityGenerator,
+	generateViewRepository EntityGenerator,
 	generateQueryFile EntityGenerator,
 	generateRepositoriesFile WriteGenerator,
 	generateQueryNodeFile SimpleWriteGenerator,
 	generateNullableTypesFile SimpleWriteGenerator,
+	generateUUIDFile WriteGenerator,
+	generatePGFile SimpleWriteGenerator,
+	generateGeoFile WriteGenerator,
 	create FileOpener,
 ) Generator {
 	return func(db schema.Database, repositoriesPath string) error {
 		for _, t := range db.Tables {
-			err := func() error {
-				fName := filepath.Join(repositoriesPath, fmt.Sprintf("entity_%s.go", t.QueryPackageName()))
-				f, err := create(fName)
-				defer func() {
-					if f != nil {
-						_ = f.Close()
-					}
-				}()
-				if err != nil {
-					return fmt.Errorf("unable to create entity file %s for %s: %w", fName, t.QueryPackageName(), err)
-				}
-
-				err = generateEntity(t, f)
-				if err != nil {
-					return fmt.Errorf("unable to write to entity file %s for %s: %w", fName, t.QueryPackageName(), err)
-				}
-				return nil
-			}()
-			if err != nil {
+			if err := writeTableFiles(t, repositoriesPath, generateEntity, generateRepository, generateQueryFile, create); err != nil {
 				return err
 			}
+		}
 
-			err = func() error {
-				fName := filepath.Join(repositoriesPath, fmt.Sprintf("repository_%s.go", t.QueryPackageName()))
-				f, err := create(fName)
-				defer func() {
-					if f != nil {
-						_ = f.Close()
-					}
-				}()
-				if err != nil {
-					return fmt.Errorf("unable to create repository file %s for %s: %w", fName, t.QueryPackageName(), err)
-				}
-
-				err = generateRepository(t, f)
-				if err != nil {
-					return fmt.Errorf("unable to write to repository file %s for %s: %w", fName, t.QueryPackageName(), err)
-				}
-				return nil
-			}()
-			if err != nil {
-				return err
-			}
-
-			err = func() error {
-				fName := filepath.Join(repositoriesPath, "query", t.QueryPackageName(), "query.go")
-				f, err := create(fName)
-				defer func() {
-					if f != nil {
-						_ = f.Close()
-					}
-				}()
-				if err != nil {
-					return fmt.Errorf("unable to create query file %s for %s: %w", fName, t.QueryPackageName(), err)
-				}
-
-				err = generateQueryFile(t, f)
-				if err != nil {
-					return fmt.Errorf("unable to write to query file %s for %s: %w", fName, t.QueryPackageName(), err)
-				}
-				return nil
-			}()
-			if err != nil {
+		for _, v := range db.Views {
+			if err := writeTableFiles(v.Table, repositoriesPath, generateEntity, generateViewRepository, generateQueryFile, create); err != nil {
 				return err
 			}
 		}
@@ -162,31 +108,179 @@ func NewGenerator(
 			}
 			return nil
 		}()
-
 		if err != nil {
 			return err
 		}
+
+		if db.HasUUIDColumns() {
+			err = func() error {
+				fName := filepath.Join(repositoriesPath, "/uuid/uuid.go")
+				f, err := create(fName)
+				defer func() {
+					if f != nil {
+						_ = f.Close()
+					}
+				}()
+				if err != nil {
+					return fmt.Errorf("unable to create uuid file %s: %w", fName, err)
+				}
+
+				err = generateUUIDFile(db, f)
+				if err != nil {
+					return fmt.Errorf("unable to write to uuid file %s: %w", fName, err)
+				}
+				return nil
+			}()
+			if err != nil {
+				return err
+			}
+		}
+
+		if db.HasPGTypeColumns() {
+			err = func() error {
+				fName := filepath.Join(repositoriesPath, "/pg/pg.go")
+				f, err := create(fName)
+				defer func() {
+					if f != nil {
+						_ = f.Close()
+					}
+				}()
+				if err != nil {
+					return fmt.Errorf("unable to create pg file %s: %w", fName, err)
+				}
+
+				err = generatePGFile(f)
+				if err != nil {
+					return fmt.Errorf("unable to write to pg file %s: %w", fName, err)
+				}
+				return nil
+			}()
+			if err != nil {
+				return err
+			}
+		}
+
+		if !db.HasGeoColumns() {
+			return nil
+		}
+
+		return func() error {
+			fName := filepath.Join(repositoriesPath, "/geo/geo.go")
+			f, err := create(fName)
+			defer func() {
+				if f != nil {
+					_ = f.Close()
+				}
+			}()
+			if err != nil {
+				return fmt.Errorf("unable to create geo file %s: %w", fName, err)
+			}
+
+			err = generateGeoFile(db, f)
+			if err != nil {
+				return fmt.Errorf("unable to write to geo file %s: %w", fName, err)
+			}
+			return nil
+		}()
+	}
+}
+
+// writeTableFiles writes the entity, repository and query files of a table or view
+func writeTableFiles(
+	t schema.Table,
+	repositoriesPath string,
+	generateEntity EntityGenerator,
+	generateRepository EntityGenerator,
+	generateQueryFile EntityGenerator,
+	create FileOpener,
+) error {
+	err := func() error {
+		fName := filepath.Join(repositoriesPath, fmt.Sprintf("entity_%s.go", t.QueryPackageName()))
+		f, err := create(fName)
+		defer func() {
+			if f != nil {
+				_ = f.Close()
+			}
+		}()
+		if err != nil {
+			return fmt.Errorf("unable to create entity file %s for %s: %w", fName, t.QueryPackageName(), err)
+		}
+
+		err = generateEntity(t, f)
+		if err != nil {
+			return fmt.Errorf("unable to write to entity file %s for %s: %w", fName, t.QueryPackageName(), err)
+		}
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	err = func() error {
+		fName := filepath.Join(repositoriesPath, fmt.Sprintf("repository_%s.go", t.QueryPackageName()))
+		f, err := create(fName)
+		defer func() {
+			if f != nil {
+				_ = f.Close()
+			}
+		}()
+		if err != nil {
+			return fmt.Errorf("unable to create repository file %s for %s: %w", fName, t.QueryPackageName(), err)
+		}
+
+		err = generateRepository(t, f)
+		if err != nil {
+			return fmt.Errorf("unable to write to repository file %s for %s: %w", fName, t.QueryPackageName(), err)
+		}
 		return nil
+	}()
+	if err != nil {
+		return err
 	}
+
+	err = func() error {
+		fName := filepath.Join(repositoriesPath, "query", t.QueryPackageName(), "query.go")
+		f, err := create(fName)
+		defer func() {
+			if f != nil {
+				_ = f.Close()
+			}
+		}()
+		if err != nil {
+			return fmt.Errorf("unable to create query file %s for %s: %w", fName, t.QueryPackageName(), err)
+		}
+
+		err = generateQueryFile(t, f)
+		if err != nil {
+			return fmt.Errorf("unable to write to query file %s for %s: %w", fName, t.QueryPackageName(), err)
+		}
+		return nil
+	}()
+	return err
 }
 
 func InitGeneratorLoader(
-	newGenerator func(EntityGenerator, EntityGenerator, EntityGenerator, WriteGenerator, SimpleWriteGenerator, SimpleWriteGenerator, FileOpener) Generator,
+	newGenerator func(EntityGenerator, EntityGenerator, EntityGenerator, EntityGenerator, WriteGenerator, SimpleWriteGenerator, SimpleWriteGenerator, WriteGenerator, SimpleWriteGenerator, WriteGenerator, FileOpener) Generator,
 	loadAdapter AdapterLoader,
 	findPackagePath Finder,
+	create FileOpener,
 ) GeneratorLoader {
 	return func(config yoyo.Config) Generator {
 		adapter, _ := loadAdapter(config.Schema.Dialect)
 		reposPath := strings.TrimRight(config.Paths.Repositories, "/\\")
 		_, packageName := filepath.Split(strings.Trim(config.Paths.Repositories, "/\\"))
 		return newGenerator(
-			NewEntityGenerator(packageName, config.Schema, findPackagePath, reposPath),
+			NewEntityGenerator(packageName, config.Schema, findPackagePath, reposPath, config.Repositories),
 			NewEntityRepositoryGenerator(packageName, adapter, reposPath, findPackagePath, config.Schema),
-			NewQueryFileGenerator(reposPath, findPackagePath, config.Schema),
+			NewViewRepositoryGenerator(packageName, reposPath, findPackagePath, config.Schema),
+			NewQueryFileGenerator(reposPath, findPackagePath, config.Schema, adapter),
 			NewRepositoriesGenerator(packageName),
 			NewQueryNodeGenerator(),
-			NewNullTypesFileGenerator(),
-			file.CreateWithDirs,
+			NewNullTypesFileGenerator(config.Repositories.GenericNullables),
+			NewUUIDFileGenerator(),
+			NewPGFileGenerator(),
+			NewGeoFileGenerator(),
+			create,
 		)
 	}
 }
@@ -3,18 +3,30 @@ package repository
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"slices"
+	"strings"
 	goTemplate "text/template"
 
 	"github.com/yoyo-project/yoyo/internal/repository/template"
 	"github.com/yoyo-project/yoyo/internal/schema"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
 )
 
 type Field struct {
 	Name    string
 	IsSlice bool
+	// IsGenericNullable is true if the field is a nullable.Value[T], which must be compared through its V and Valid
+	// fields when T is a slice
+	IsGenericNullable bool
+	// HasEqual is true if the field's type has an Equal method, which must be used because == either doesn't
+	// compile or doesn't give the right answer for it
+	HasEqual bool
 }
 
 type EntityFileParams struct {
+	// Doc is the doc comment of the entity, from the table's comment
+	Doc             string
 	EntityFields    []string
 	Fields          []Field
 	Imports         []string
@@ -23,39 +35,111 @@ type EntityFileParams struct {
 	PackageName     string
 }
 
-func NewEntityGenerator(packageName string, db schema.Database, packagePath Finder, reposPath string) EntityGenerator {
+func NewEntityGenerator(packageName string, db schema.Database, packagePath Finder, reposPath string, options yoyo.Repositories) EntityGenerator {
 	return func(t schema.Table, w io.Writer) error {
+		is := db.Initialisms()
 		ps := EntityFileParams{
 			PackageName: packageName,
-			EntityName:  t.ExportedGoName(),
+			EntityName:  t.ExportedGoName(is),
+			Doc:         docComment(t.Options.Comment, ""),
 		}
 		nullPackagePath, err := packagePath(reposPath + "/nullable")
 		if err != nil {
 			return fmt.Errorf("couldn't generate entity file: %w", err)
 		}
+		queryPackagePath, err := packagePath(fmt.Sprintf("%s/query/%s", reposPath, t.QueryPackageName()))
+		if err != nil {
+			return fmt.Errorf("couldn't generate entity file: %w", err)
+		}
+		uuidPackagePath, err := packagePath(reposPath + "/uuid")
+		if err != nil {
+			return fmt.Errorf("couldn't generate entity file: %w", err)
+		}
+		pgPackagePath, err := packagePath(reposPath + "/pg")
+		if err != nil {
+			return fmt.Errorf("couldn't generate entity file: %w", err)
+		}
+		geoPackagePath, err := packagePath(reposPath + "/geo")
+		if err != nil {
+			return fmt.Errorf("couldn't generate entity file: %w", err)
+		}
 		for _, c := range t.Columns {
-			ps.EntityFields = append(ps.EntityFields, fmt.Sprintf("%s %s", c.ExportedGoName(), c.GoTypeString()))
-			ps.Fields = append(ps.Fields, Field{
-				Name:    c.ExportedGoName(),
-				IsSlice: c.Datatype.IsBinary(),
-			})
-			if imp := c.RequiredImport(nullPackagePath); imp != "" {
+			goType := c.GoTypeString(is)
+			if c.IsUUID() {
+				ps.Imports = append(ps.Imports, uuidImport(uuidPackagePath))
+			}
+			if c.IsPGType() {
+				ps.Imports = append(ps.Imports, `"`+pgPackagePath+`"`)
+			}
+			if c.IsGeo() {
+				ps.Imports = append(ps.Imports, `"`+geoPackagePath+`"`)
+			}
+			if c.IsEnum() {
+				// Enum types live in the table's query package so that query methods can accept them
+				goType = fmt.Sprintf("%s.%s", t.QueryPackageName(), goType)
+				ps.Imports = append(ps.Imports, `"`+queryPackagePath+`"`)
+			}
+
+			if c.HasJSONGoType() {
+				// JSON columns with a custom type are always wrapped in nullable.JSONOf, which does the marshalling
+				ps.Imports = append(ps.Imports, `"`+nullPackagePath+`"`)
+				if imp := c.BaseTypeImport(); imp != "" {
+					ps.Imports = append(ps.Imports, imp)
+				}
+			} else if c.Nullable && options.GenericNullables && !c.IsPGType() && !c.IsGeo() {
+				goType = c.BaseType(is)
+				if c.IsEnum() {
+					goType = fmt.Sprintf("%s.%s", t.QueryPackageName(), goType)
+				}
+				goType = fmt.Sprintf("nullable.Value[%s]", goType)
+				ps.Imports = append(ps.Imports, `"`+nullPackagePath+`"`)
+				if imp := c.BaseTypeImport(); imp != "" {
+					ps.Imports = append(ps.Imports, imp)
+				}
+			} else if imp := c.RequiredImport(nullPackagePath); imp != "" {
 				ps.Imports = append(ps.Imports, imp)
 			}
+
+			tags, err := structTags(c, options, is)
+			if err != nil {
+				return fmt.Errorf("couldn't generate entity file: %w", err)
+			}
+
+			ps.EntityFields = append(ps.EntityFields, fmt.Sprintf("%s%s %s%s", docComment(c.Comment, "\t"), c.ExportedGoName(is), goType, tags))
+			ps.Fields = append(ps.Fields, Field{
+				Name:              c.ExportedGoName(is),
+				IsSlice:           c.IsGoSlice(),
+				IsGenericNullable: c.Nullable && options.GenericNullables && !c.HasJSONGoType() && !c.IsPGType() && !c.IsGeo(),
+				HasEqual:          c.HasJSONGoType() || c.IsPGType() || c.IsGeo(),
+			})
 		}
 
 		for _, r := range t.References {
 			if r.HasOne {
 				ft, _ := db.GetTable(r.TableName)
-				for _, cn := range ft.PKColNames() {
+				fkNames := r.ColNames(ft)
+				for i, cn := range ft.PKColNames() {
 					c, _ := ft.GetColumn(cn)
 
-					goName := fmt.Sprintf("%s%s", ft.ExportedGoName(), c.ExportedGoName())
+					goName := fmt.Sprintf("%s%s", ft.ExportedGoName(is), c.ExportedGoName(is))
 					ps.Fields = append(ps.Fields, Field{
 						Name:    goName,
-						IsSlice: c.Datatype.IsBinary(),
+						IsSlice: c.IsGoSlice(),
 					})
-					ps.ReferenceFields = append(ps.ReferenceFields, fmt.Sprintf("%s %s", goName, c.GoTypeString()))
+					if imp := c.RequiredImport(nullPackagePath); imp != "" {
+						ps.Imports = append(ps.Imports, imp)
+					}
+					if c.IsUUID() {
+						ps.Imports = append(ps.Imports, uuidImport(uuidPackagePath))
+					}
+
+					// the tags are named from the words of the Go name, which initialisms would run together
+					tagName := ft.ExportedGoName(nil) + c.ExportedGoName(nil)
+					tags, err := structTags(schema.Column{Name: fkNames[i], GoName: tagName}, options, is)
+					if err != nil {
+						return fmt.Errorf("couldn't generate entity file: %w", err)
+					}
+					ps.ReferenceFields = append(ps.ReferenceFields, fmt.Sprintf("%s %s%s", goName, c.GoTypeString(is), tags))
 				}
 			}
 		}
@@ -63,18 +147,36 @@ func NewEntityGenerator(packageName string, db schema.Database, packagePath Find
 		for _, t2 := range db.Tables {
 			for _, r := range t2.References {
 				if r.HasMany && r.TableName == t.Name {
-					for _, c := range t2.PKColumns() {
+					fkNames := r.ColNames(t2)
+					for i, c := range t2.PKColumns() {
+						goName := t2.ExportedGoName(is) + c.ExportedGoName(is)
 						ps.Fields = append(ps.Fields, Field{
-							Name: t2.ExportedGoName()+c.ExportedGoName(),
-							IsSlice: c.Datatype.IsBinary(),
+							Name:    goName,
+							IsSlice: c.IsGoSlice(),
 						})
-						ps.ReferenceFields = append(ps.ReferenceFields, fmt.Sprintf("%s %s", t2.ExportedGoName()+c.ExportedGoName(), c.GoTypeString()))
+						if imp := c.RequiredImport(nullPackagePath); imp != "" {
+							ps.Imports = append(ps.Imports, imp)
+						}
+						if c.IsUUID() {
+							ps.Imports = append(ps.Imports, uuidImport(uuidPackagePath))
+						}
+
+						tagName := t2.ExportedGoName(nil) + c.ExportedGoName(nil)
+						tags, err := structTags(schema.Column{Name: fkNames[i], GoName: tagName}, options, is)
+						if err != nil {
+							return fmt.Errorf("couldn't generate entity file: %w", err)
+						}
+						ps.ReferenceFields = append(ps.ReferenceFields, fmt.Sprintf("%s %s%s", goName, c.GoTypeString(is), tags))
 					}
 				}
 			}
 		}
 
+		// The template always imports database/sql and fmt
 		ps.Imports = sortedUnique(ps.Imports)
+		ps.Imports = slices.DeleteFunc(ps.Imports, func(imp string) bool {
+			return imp == `"database/sql"` || imp == `"fmt"`
+		})
 
 		tpl := goTemplate.Must(goTemplate.New("EntityFile").Parse(template.EntityFile))
 
@@ -83,3 +185,101 @@ func NewEntityGenerator(packageName string, db schema.Database, packagePath Find
 		return err
 	}
 }
+
+// docComment returns a schema comment as Go line comments, each followed by a newline and indent, so that it can be
+// written directly before the declaration it documents. An empty comment returns an empty string.
+func docComment(comment, indent string) string {
+	if comment == "" {
+		return ""
+	}
+
+	sb := strings.Builder{}
+	for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+		sb.WriteString(strings.TrimRight("// "+line, " "))
+		sb.WriteString("\n" + indent)
+	}
+	return sb.String()
+}
+
+const (
+	tagJSON = "json"
+	tagDB   = "db"
+	tagYAML = "yaml"
+
+	namingColumn = "column"
+	namingSnake  = "snake"
+	namingCamel  = "camel"
+	namingPascal = "pascal"
+)
+
+var (
+	upperFinder   = regexp.MustCompile("[A-Z]")
+	wordSeparator = regexp.MustCompile("[-_]+")
+)
+
+// structTags returns the struct tags configured in options for the entity field of the given column, including the
+// leading space. If no tags are configured, an empty string is returned. Snake and camel case tags are built from the
+// words of the column's name, so initialisms don't change them. Pascal case tags are the field's Go name, with the
+// initialisms upper-cased.
+func structTags(c schema.Column, options yoyo.Repositories, is schema.Initialisms) (string, error) {
+	if len(options.Tags) == 0 {
+		return "", nil
+	}
+
+	var name string
+	words := tagWords(c)
+	switch options.TagNaming {
+	case "", namingColumn:
+		name = c.Name
+	case namingSnake:
+		name = strings.ToLower(strings.Join(words, "_"))
+	case namingCamel:
+		rest := schema.Column{Name: strings.Join(words[1:], "_")}
+		name = strings.ToLower(words[0]) + rest.ExportedGoName(nil)
+	case namingPascal:
+		field := schema.Column{Name: strings.Join(words, "_")}
+		name = field.ExportedGoName(is)
+	default:
+		return "", fmt.Errorf("unknown tag naming strategy `%s`", options.TagNaming)
+	}
+
+	tags := make([]string, 0, len(options.Tags))
+	for _, tag := range options.Tags {
+		var val string
+		switch tag {
+		case tagJSON:
+			switch {
+			case c.Omit:
+				val = "-"
+			case c.JSONName != "":
+				val = c.JSONName
+			default:
+				val = name
+			}
+		case tagYAML:
+			val = name
+			if c.Omit {
+				val = "-"
+			}
+		case tagDB:
+			val = c.Name
+		default:
+			return "", fmt.Errorf("unknown struct tag `%s`", tag)
+		}
+		tags = append(tags, fmt.Sprintf(`%s:"%s"`, tag, val))
+	}
+
+	return fmt.Sprintf(" `%s`", strings.Join(tags, " ")), nil
+}
+
+// tagWords returns the words of the column's GoName, or of its Name if it has none, which are separated by dashes,
+// underscores or upper-case letters
+func tagWords(c schema.Column) []string {
+	name := c.GoName
+	if name == "" {
+		name = c.Name
+	}
+
+	name = upperFinder.ReplaceAllString(name, "_$0")
+	return wordSeparator.Split(strings.Trim(name, "-_"), -1)
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+
+	"github.com/yoyo-project/yoyo/internal/schema"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
+)
+
+func Test_structTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  schema.Column
+		options yoyo.Repositories
+		is      schema.Initialisms
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "no tags",
+			column: schema.Column{Name: "favorite_color"},
+		},
+		{
+			name:    "column naming",
+			column:  schema.Column{Name: "favoriteColor"},
+			options: yoyo.Repositories{Tags: []string{"json", "db", "yaml"}},
+			want:    " `json:\"favoriteColor\" db:\"favoriteColor\" yaml:\"favoriteColor\"`",
+		},
+		{
+			name:    "snake naming",
+			column:  schema.Column{Name: "favoriteColor"},
+			options: yoyo.Repositories{Tags: []string{"json", "db"}, TagNaming: "snake"},
+			want:    " `json:\"favorite_color\" db:\"favoriteColor\"`",
+		},
+		{
+			name:    "camel naming",
+			column:  schema.Column{Name: "favorite_color"},
+			options: yoyo.Repositories{Tags: []string{"json"}, TagNaming: "camel"},
+			want:    " `json:\"favoriteColor\"`",
+		},
+		{
+			name:    "pascal naming with go_name",
+			column:  schema.Column{Name: "favorite_color", GoName: "color"},
+			options: yoyo.Repositories{Tags: []string{"yaml"}, TagNaming: "pascal"},
+			want:    " `yaml:\"Color\"`",
+		},
+		{
+			name:    "json_name override",
+			column:  schema.Column{Name: "favorite_color", JSONName: "color"},
+			options: yoyo.Repositories{Tags: []string{"json", "yaml"}},
+			want:    " `json:\"color\" yaml:\"favorite_color\"`",
+		},
+		{
+			name:    "omit",
+			column:  schema.Column{Name: "secret", JSONName: "s", Omit: true},
+			options: yoyo.Repositories{Tags: []string{"json", "yaml", "db"}},
+			want:    " `json:\"-\" yaml:\"-\" db:\"secret\"`",
+		},
+		{
+			name:    "snake naming with initialisms",
+			column:  schema.Column{Name: "avatar_url"},
+			options: yoyo.Repositories{Tags: []string{"json", "yaml"}, TagNaming: "snake"},
+			is:      schema.Initialisms{"ID": true, "URL": true},
+			want:    " `json:\"avatar_url\" yaml:\"avatar_url\"`",
+		},
+		{
+			name:    "camel naming with initialisms",
+			column:  schema.Column{Name: "city_id"},
+			options: yoyo.Repositories{Tags: []string{"json"}, TagNaming: "camel"},
+			is:      schema.Initialisms{"ID": true, "URL": true},
+			want:    " `json:\"cityId\"`",
+		},
+		{
+			name:    "reference naming with initialisms",
+			column:  schema.Column{Name: "fk_city_id", GoName: "CityId"},
+			options: yoyo.Repositories{Tags: []string{"json", "yaml", "db"}, TagNaming: "snake"},
+			is:      schema.Initialisms{"ID": true, "URL": true},
+			want:    " `json:\"city_id\" yaml:\"city_id\" db:\"fk_city_id\"`",
+		},
+		{
+			name:    "pascal naming with initialisms",
+			column:  schema.Column{Name: "id"},
+			options: yoyo.Repositories{Tags: []string{"json"}, TagNaming: "pascal"},
+			is:      schema.Initialisms{"ID": true, "URL": true},
+			want:    " `json:\"ID\"`",
+		},
+		{
+			name:    "unknown tag",
+			column:  schema.Column{Name: "col"},
+			options: yoyo.Repositories{Tags: []string{"xml"}},
+			wantErr: "unknown struct tag `xml`",
+		},
+		{
+			name:    "unknown naming",
+			column:  schema.Column{Name: "col"},
+			options: yoyo.Repositories{Tags: []string{"json"}, TagNaming: "kebab"},
+			wantErr: "unknown tag naming strategy `kebab`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := structTags(tt.column, tt.options, tt.is)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("wanted error '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("structTags() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_docComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		indent  string
+		want    string
+	}{
+		{
+			name: "no comment",
+		},
+		{
+			name:    "single line",
+			comment: "The person's full name",
+			indent:  "\t",
+			want:    "// The person's full name\n\t",
+		},
+		{
+			name:    "multiple lines",
+			comment: "The person's full name\n\nAs they write it\n",
+			want:    "// The person's full name\n//\n// As they write it\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := docComment(tt.comment, tt.indent); got != tt.want {
+				t.Errorf("docComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEntityGenerator_genericNullables(t *testing.T) {
+	table := schema.Table{
+		Name: "person",
+		Columns: []schema.Column{
+			{Name: "id", Datatype: datatype.Integer, PrimaryKey: true},
+			{Name: "nickname", Datatype: datatype.Varchar, Params: []string{"32"}, Nullable: true},
+			{Name: "favorite_color", Datatype: datatype.Enum, Params: []string{"'red'", "'blue'"}, Nullable: true},
+			{Name: "avatar", Datatype: datatype.Blob, Nullable: true},
+			{Name: "age", Datatype: datatype.Integer, Nullable: false},
+		},
+	}
+	packagePath := func(path string) (string, error) {
+		return "example.com/app/" + strings.TrimPrefix(path, "/repositories/"), nil
+	}
+
+	tests := []struct {
+		name           string
+		generic        bool
+		wantFields     map[string]string
+		wantImports    []string
+		wantHasChanged []string
+	}{
+		{
+			name: "wrapper types",
+			wantFields: map[string]string{
+				"Id":            "int32",
+				"Nickname":      "nullable.String",
+				"FavoriteColor": "person.NullFavoriteColorEnum",
+				"Avatar":        "[]byte",
+				"Age":           "int32",
+			},
+			wantImports: []string{"database/sql", "fmt", "example.com/app/nullable", "example.com/app/query/person"},
+			wantHasChanged: []string{
+				"e.Nickname == e.persisted.Nickname",
+				"e.FavoriteColor == e.persisted.FavoriteColor",
+				"equal(e.Avatar, e.persisted.Avatar)",
+			},
+		},
+		{
+			name:    "generic value",
+			generic: true,
+			wantFields: map[string]string{
+				"Id":            "int32",
+				"Nickname":      "nullable.Value[string]",
+				"FavoriteColor": "nullable.Value[person.FavoriteColorEnum]",
+				"Avatar":        "nullable.Value[[]byte]",
+				"Age":           "int32",
+			},
+			wantImports: []string{"database/sql", "fmt", "example.com/app/nullable", "example.com/app/query/person"},
+			wantHasChanged: []string{
+				"e.Nickname == e.persisted.Nickname",
+				"e.FavoriteColor == e.persisted.FavoriteColor",
+				"e.Avatar.Valid == e.persisted.Avatar.Valid && equal(e.Avatar.V, e.persisted.Avatar.V)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			generate := NewEntityGenerator("repositories", schema.Database{Tables: []schema.Table{table}}, packagePath, "/repositories", yoyo.Repositories{GenericNullables: tt.generic})
+			if err := generate(table, &buf); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			fields, imports, hasChanged := parseEntity(t, buf.String(), "Person")
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(imports, tt.wantImports) {
+				t.Errorf("imports = %v, want %v", imports, tt.wantImports)
+			}
+			for _, want := range tt.wantHasChanged {
+				if !strings.Contains(hasChanged, want) {
+					t.Errorf("HasChanged doesn't compare with %q:\n%s", want, hasChanged)
+				}
+			}
+		})
+	}
+}
+
+func TestNewEntityGenerator_uuidImports(t *testing.T) {
+	table := schema.Table{
+		Name: "api_key",
+		Columns: []schema.Column{
+			{Name: "id", Datatype: datatype.UUID, PrimaryKey: true},
+			{Name: "replaced_by", Datatype: datatype.UUID, Nullable: true},
+			{Name: "external_id", Datatype: datatype.Binary, Params: []string{"16"}, GoType: "github.com/google/uuid.UUID"},
+		},
+	}
+	packagePath := func(path string) (string, error) {
+		return "example.com/app/" + strings.TrimPrefix(path, "/repositories/"), nil
+	}
+
+	var buf bytes.Buffer
+	generate := NewEntityGenerator("repositories", schema.Database{Tables: []schema.Table{table}}, packagePath, "/repositories", yoyo.Repositories{})
+	if err := generate(table, &buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fields, imports, _ := parseEntity(t, buf.String(), "ApiKey")
+	wantFields := map[string]string{
+		"Id":         "yoyouuid.UUID",
+		"ReplacedBy": "yoyouuid.NullUUID",
+		"ExternalId": "uuid.UUID",
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("fields = %v, want %v", fields, wantFields)
+	}
+	wantImports := []string{"database/sql", "fmt", "github.com/google/uuid", "yoyouuid example.com/app/uuid"}
+	if !reflect.DeepEqual(imports, wantImports) {
+		t.Errorf("imports = %v, want %v", imports, wantImports)
+	}
+}
+
+func TestNewEntityGenerator_initialisms(t *testing.T) {
+	// the table is built rather than unmarshalled, like the columns yoyo adds, and still gets the Database's initialisms
+	table := schema.Table{
+		Name: "api_key",
+		Columns: []schema.Column{
+			{Name: "id", Datatype: datatype.Integer, PrimaryKey: true},
+			{Name: "callback_url", Datatype: datatype.Enum, Params: []string{"'http'", "'https'"}},
+		},
+	}
+	db := schema.Database{Tables: []schema.Table{table}, Naming: schema.Naming{Initialisms: []string{"api", "id", "url"}}}
+	packagePath := func(path string) (string, error) {
+		return "example.com/app/" + strings.TrimPrefix(path, "/repositories/"), nil
+	}
+
+	var buf bytes.Buffer
+	generate := NewEntityGenerator("repositories", db, packagePath, "/repositories", yoyo.Repositories{})
+	if err := generate(table, &buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fields, _, _ := parseEntity(t, buf.String(), "APIKey")
+	wantFields := map[string]string{
+		"ID":          "int32",
+		"CallbackURL": "api_key.CallbackURLEnum",
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("fields = %v, want %v", fields, wantFields)
+	}
+}
+
+// parseEntity parses a generated entity file and returns the types of the exported fields of the named entity, the
+// imported paths preceded by their names if they're named, and the body of its HasChanged method
+func parseEntity(t *testing.T, src, entity string) (fields map[string]string, imports []string, hasChanged string) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "entity.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated entity doesn't parse: %s\n%s", err, src)
+	}
+
+	node := func(n ast.Node) string {
+		var sb strings.Builder
+		_ = printer.Fprint(&sb, fset, n)
+		return sb.String()
+	}
+
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil {
+			path = imp.Name.Name + " " + path
+		}
+		imports = append(imports, path)
+	}
+
+	fields = make(map[string]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeSpec:
+			if st, ok := n.Type.(*ast.StructType); ok && n.Name.Name == entity {
+				for _, field := range st.Fields.List {
+					for _, name := range field.Names {
+						if name.IsExported() {
+							fields[name.Name] = node(field.Type)
+						}
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if n.Name.Name == "HasChanged" {
+				hasChanged = node(n.Body)
+			}
+		}
+		return true
+	})
+
+	return fields, imports, hasChanged
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/file"
+	"github.com/yoyo-project/yoyo/internal/yoyo"
+	"gopkg.in/yaml.v3"
+)
+
+// examples are the directories of the example projects, whose generated repositories are tested in place
+var examples = []string{"../../example/mysql"}
+
+// TestExamples regenerates the repositories of every example and compares them with the committed files, so that the
+// tests of the examples' generated code test what the generator writes today. After changing a template, run
+// `yoyo generate repos` in each example.
+func TestExamples(t *testing.T) {
+	for _, example := range examples {
+		t.Run(filepath.Base(example), func(t *testing.T) {
+			dir, err := filepath.Abs(example)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := os.ReadFile(filepath.Join(dir, "yoyo.yml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var config yoyo.Config
+			if err = yaml.Unmarshal(f, &config); err != nil {
+				t.Fatalf("unable to load config: %s", err)
+			}
+			config.Paths.Repositories = filepath.Join(dir, config.Paths.Repositories)
+
+			out := t.TempDir()
+			var files []string
+			create := func(name string) (*os.File, error) {
+				rel, err := filepath.Rel(config.Paths.Repositories, name)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, rel)
+				return file.CreateWithDirs(filepath.Join(out, rel))
+			}
+
+			generate := InitGeneratorLoader(NewGenerator, LoadAdapter, file.FindPackagePath, create)(config)
+			if err = generate(config.Schema, config.Paths.Repositories); err != nil {
+				t.Fatalf("unable to generate: %s", err)
+			}
+
+			for _, name := range files {
+				want, err := os.ReadFile(filepath.Join(out, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := os.ReadFile(filepath.Join(config.Paths.Repositories, name))
+				if err != nil {
+					t.Errorf("%s isn't generated: %s", name, err)
+					continue
+				}
+				if string(got) != string(want) {
+					t.Errorf("%s is out of date, run `yoyo generate repos` in %s", name, example)
+				}
+			}
+		})
+	}
+}
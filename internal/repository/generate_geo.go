@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"io"
+	goTemplate "text/template"
+
+	"github.com/yoyo-project/yoyo/internal/repository/template"
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+type GeoFileParams struct {
+	// Dialect decides the binary format of geometries in the database, MySQL's internal format or PostGIS' EWKB
+	Dialect string
+}
+
+// NewGeoFileGenerator returns a WriteGenerator for the geo package, whose types convert geometries to and from the
+// binary format of the database's dialect
+func NewGeoFileGenerator() WriteGenerator {
+	return func(db schema.Database, w io.Writer) error {
+		ps := GeoFileParams{
+			Dialect: db.Dialect,
+		}
+		tpl := goTemplate.Must(goTemplate.New("GeoFile").Parse(template.GeoFile))
+		return tpl.Execute(w, ps)
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+func TestNewGeoFileGenerator(t *testing.T) {
+	tests := []struct {
+		name      string
+		db        schema.Database
+		wantValue string
+	}{
+		{
+			name:      "mysql",
+			db:        schema.Database{Dialect: "mysql"},
+			wantValue: "return append(binary.LittleEndian.AppendUint32(nil, s.SRID), b...), nil",
+		},
+		{
+			name:      "postgresql",
+			db:        schema.Database{Dialect: "postgresql"},
+			wantValue: "return hex.EncodeToString(append(e, b[5:]...)), nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := strings.Builder{}
+			if err := NewGeoFileGenerator()(tt.db, &sb); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := sb.String(); !strings.Contains(got, tt.wantValue) {
+				t.Errorf("want Value containing %q, got:\n%s", tt.wantValue, got)
+			}
+
+			testGenerated(t, "geo", sb.String(), "testdata/geo/geo_test.go", "testdata/geo/"+tt.name+"_test.go")
+		})
+	}
+}
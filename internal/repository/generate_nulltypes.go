@@ -1,13 +1,20 @@
 package repository
 
 import (
-	"github.com/yoyo-project/yoyo/internal/repository/template"
 	"io"
+
+	"github.com/yoyo-project/yoyo/internal/repository/template"
 )
 
-func NewNullTypesFileGenerator() SimpleWriteGenerator {
+// NewNullTypesFileGenerator returns a SimpleWriteGenerator for the nullable package. If generic is true, the package
+// contains the generic nullable.Value[T] instead of the fixed wrapper types.
+func NewNullTypesFileGenerator(generic bool) SimpleWriteGenerator {
 	return func(w io.StringWriter) error {
-		_, err := w.WriteString(template.NullTypeFile)
+		file := template.NullTypeFile
+		if generic {
+			file = template.NullValueFile
+		}
+		_, err := w.WriteString(file + template.JSONOfFile)
 		return err
 	}
-}
\ No newline at end of file
+}
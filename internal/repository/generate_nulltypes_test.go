@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/repository/template"
+)
+
+func TestNewNullTypesFileGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		generic bool
+		want    string
+	}{
+		{
+			name: "wrapper types",
+			want: template.NullTypeFile + template.JSONOfFile,
+		},
+		{
+			name:    "generic value",
+			generic: true,
+			want:    template.NullValueFile + template.JSONOfFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := strings.Builder{}
+			err := NewNullTypesFileGenerator(tt.generic)(&sb)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("want:%s\n got:%s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"io"
+
+	"github.com/yoyo-project/yoyo/internal/repository/template"
+)
+
+// NewPGFileGenerator returns a SimpleWriteGenerator for the pg package, whose Array and Range types convert
+// PostgreSQL arrays and ranges to and from Go
+func NewPGFileGenerator() SimpleWriteGenerator {
+	return func(w io.StringWriter) error {
+		_, err := w.WriteString(template.PGFile)
+		return err
+	}
+}
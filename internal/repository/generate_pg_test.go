@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/repository/template"
+)
+
+func TestNewPGFileGenerator(t *testing.T) {
+	sb := strings.Builder{}
+	if err := NewPGFileGenerator()(&sb); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := sb.String(); got != template.PGFile {
+		t.Errorf("want:%s\n got:%s", template.PGFile, got)
+	}
+
+	testGenerated(t, "pg", sb.String(), "testdata/pg/pg_test.go")
+}
+
+// testGenerated runs the given test files against the source of a generated package, which only uses the standard
+// library, in a module of its own
+func testGenerated(t *testing.T, pkg, src string, testFiles ...string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping the tests of the generated package in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("skipping the tests of the generated package without a go command")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module generated\n\ngo 1.21\n",
+		pkg + "/" + pkg + ".go": src,
+	}
+	for _, name := range testFiles {
+		test, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[pkg+"/"+filepath.Base(name)] = string(test)
+	}
+	for name, content := range files {
+		name = filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("the tests of the generated %s package fail: %s\n%s", pkg, err, out)
+	}
+}
@@ -7,24 +7,56 @@ import (
 	goTemplate "text/template"
 
 	_ "embed"
+	"github.com/yoyo-project/yoyo/internal/datatype"
 	"github.com/yoyo-project/yoyo/internal/repository/template"
 	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
-func NewQueryFileGenerator(reposPath string, findPackagePath Finder, db schema.Database) EntityGenerator {
+func NewQueryFileGenerator(reposPath string, findPackagePath Finder, db schema.Database, adapter Adapter) EntityGenerator {
 	return func(t schema.Table, w io.Writer) error {
 		// We always need fmt because we use it for Query.SQL()
 		imports := []string{`"fmt"`}
 
+		uuidPackagePath, err := findPackagePath(reposPath + "/uuid")
+		if err != nil {
+			return fmt.Errorf("unable to generate query file: %w", err)
+		}
+		pgPackagePath, err := findPackagePath(reposPath + "/pg")
+		if err != nil {
+			return fmt.Errorf("unable to generate query file: %w", err)
+		}
+
+		initialisms := db.Initialisms()
 		ps := QueryFileParams{}
 		for _, c := range t.Columns {
 			ops, is := buildOptsAndImports(c)
+			jsonOps, jsonIs := buildJSONOperations(c, adapter)
+			pgOps, pgIs := buildPGOperations(c, initialisms)
 			ps.Columns = append(ps.Columns, ColumnParams{
-				Column:     c,
-				Operations: ops,
+				Column:           c,
+				Operations:       ops,
+				FormatOperations: append(jsonOps, pgOps...),
+				Initialisms:      initialisms,
 			})
 
 			imports = append(imports, is...)
+			imports = append(imports, jsonIs...)
+			imports = append(imports, pgIs...)
+			if c.IsUUID() {
+				imports = append(imports, uuidImport(uuidPackagePath))
+			}
+			if c.IsPGType() {
+				imports = append(imports, `"`+pgPackagePath+`"`)
+			}
+
+			if c.IsEnum() {
+				// Enum types implement sql.Scanner and driver.Valuer
+				imports = append(imports, `"database/sql/driver"`)
+				if c.Nullable {
+					// Nullable enum types implement json.Marshaler and json.Unmarshaler
+					imports = append(imports, `"encoding/json"`)
+				}
+			}
 		}
 
 		for _, r := range t.References {
@@ -40,20 +72,27 @@ func NewQueryFileGenerator(reposPath string, findPackagePath Finder, db schema.D
 			for i, n := range r.ColNames(ft) {
 				c := ft.PKColumns()[i]
 				// Override the GoName in order to generate correct method/function names
-				c.GoName = r.ExportedGoName() + c.ExportedGoName()
+				c.GoName = r.ExportedGoName(initialisms) + c.ExportedGoName(initialisms)
 				// Override the name - use the fk name
 				c.Name = n
 				ops, is := buildOptsAndImports(c)
 				imports = append(imports, is...)
+				if c.IsUUID() {
+					imports = append(imports, uuidImport(uuidPackagePath))
+				}
 
 				ps.Columns = append(ps.Columns, ColumnParams{
-					Column:     c,
-					Operations: ops,
+					Column:      c,
+					Operations:  ops,
+					Initialisms: initialisms,
 				})
 			}
 		}
 
-		var err error
+		if c, ok := t.GetColumn(t.SoftDeleteColumn); ok && t.SoftDeleteColumn != "" {
+			ps.SoftDeleteColumn = c.ExportedGoName(initialisms)
+		}
+
 		ps.Imports = sortedUnique(imports)
 		ps.RepositoriesPackage, err = findPackagePath(reposPath + "/")
 		ps.PackageName = t.QueryPackageName()
@@ -94,6 +133,23 @@ func buildOptsAndImports(column schema.Column) (operations []Operation, imports
 		ops []Operation
 	)
 	switch {
+	case column.Datatype.IsJSON():
+		// JSON documents aren't compared as a whole, their operations come from buildJSONOperations
+	case column.Datatype.IsArray() || column.Datatype.IsRange():
+		// Arrays and ranges are compared by their elements, their operations come from buildPGOperations
+	case column.Datatype.IsSpatial():
+		// Geometries can't be compared with plain operators, so they only get the null checks
+	case column.GoType != "" && (column.Datatype.IsString() || column.Datatype.IsBinary()):
+		// Pattern matching doesn't make sense for custom types, so only allow comparing them
+		ops = []Operation{
+			{Name: Equals},
+			{Name: Not},
+		}
+	case column.IsEnum():
+		ops = []Operation{
+			{Name: Equals},
+			{Name: Not},
+		}
 	case column.Datatype.IsTime():
 		ops = []Operation{
 			{Name: Equals},
@@ -123,7 +179,7 @@ func buildOptsAndImports(column schema.Column) (operations []Operation, imports
 			{Name: EndsWith},
 			{Name: EndsWithNot},
 		}
-	case column.Datatype.IsBinary():
+	case column.Datatype.IsBinary(), column.Datatype == datatype.UUID:
 		ops = []Operation{
 			{Name: Equals},
 			{Name: Not},
@@ -134,6 +190,14 @@ func buildOptsAndImports(column schema.Column) (operations []Operation, imports
 		ops = append(ops, Operation{Name: IsNull, NullCheck: true}, Operation{Name: IsNotNull, NullCheck: true})
 	}
 
+	if column.GoType != "" && !column.Datatype.IsJSON() {
+		// Operation imports assume the default Go type of the datatype, custom types bring their own
+		if imp := column.BaseTypeImport(); imp != "" {
+			imports = append(imports, imp)
+		}
+		return ops, imports
+	}
+
 	for _, op := range ops {
 		imports = append(imports, op.imports()...)
 	}
@@ -158,13 +222,52 @@ type QueryFileParams struct {
 	RepositoriesPackage string
 	PackageName         string
 	Imports             []string
+	// SoftDeleteColumn is the exported Go name of the table's soft delete column, if it has one
+	SoftDeleteColumn string
 }
 
 type ColumnParams struct {
 	schema.Column
-	Operations []Operation
+	Operations       []Operation
+	FormatOperations []FormatOperation
+	// Initialisms are the Database's initialisms, which the Go name methods of ColumnParams pass to the Column's
+	Initialisms schema.Initialisms
 }
 
+// ExportedGoName returns the exported Go name of the column, with the Database's initialisms
+func (c ColumnParams) ExportedGoName() string {
+	return c.Column.ExportedGoName(c.Initialisms)
+}
+
+// BaseType returns the Go type of the column, ignoring nullability, with the Database's initialisms
+func (c ColumnParams) BaseType() string {
+	return c.Column.BaseType(c.Initialisms)
+}
+
+// EnumTypeName returns the name of the Go type of an ENUM column, with the Database's initialisms
+func (c ColumnParams) EnumTypeName() string {
+	return c.Column.EnumTypeName(c.Initialisms)
+}
+
+// EnumConstName returns the name of the Go constant of the given value of an ENUM column, with the Database's
+// initialisms
+func (c ColumnParams) EnumConstName(val string) string {
+	return c.Column.EnumConstName(c.Initialisms, val)
+}
+
+// Doc returns the doc comment of the query function for the column's operation with the given name, which includes the
+// column's comment, or an empty string if the column has no comment
+func (c ColumnParams) Doc(operation string) string {
+	if c.Comment == "" {
+		return ""
+	}
+
+	name := c.ExportedGoName()
+	if operation != Equals {
+		name += operation
+	}
+	return fmt.Sprintf("// %s filters on the %s column.\n//\n%s", name, c.Name, docComment(c.Comment, ""))
+}
 
 type Operation struct {
 	Name      string
@@ -237,3 +340,82 @@ func sortedUnique(in []string) (out []string) {
 	sort.Strings(out)
 	return out
 }
+
+const (
+	PathEquals   = "PathEquals"
+	JSONContains = "Contains"
+	Overlaps     = "Overlaps"
+	AnyEquals    = "AnyEquals"
+)
+
+// rangeElementTypes are the PostgreSQL types of the bounds of each range Datatype. A value checked against a range
+// is cast to it, because a query parameter of unknown type would otherwise be taken for a range.
+var rangeElementTypes = map[datatype.Datatype]string{
+	datatype.Int4Range: "integer",
+	datatype.Int8Range: "bigint",
+	datatype.NumRange:  "numeric",
+	datatype.TsRange:   "timestamp",
+	datatype.TsTzRange: "timestamptz",
+	datatype.DateRange: "date",
+}
+
+// buildJSONOperations returns the operations a JSON column supports in the adapter's dialect, and their imports
+func buildJSONOperations(column schema.Column, adapter Adapter) (ops []FormatOperation, imports []string) {
+	if !column.Datatype.IsJSON() {
+		return nil, nil
+	}
+
+	if format, ok := adapter.JSONPathEquals(); ok {
+		ops = append(ops, FormatOperation{Name: PathEquals, Format: format, Params: "path, val string", Args: "path, val", Values: "path, val"})
+	}
+	if format, ok := adapter.JSONContains(column.Datatype); ok {
+		// The value is passed as a string, because some drivers send []byte as a binary string, which isn't valid JSON
+		// to the database
+		ops = append(ops, FormatOperation{Name: JSONContains, Format: format, Params: "val json.RawMessage", Args: "val", Values: "string(val)"})
+		imports = append(imports, `"encoding/json"`)
+	}
+
+	return ops, imports
+}
+
+// buildPGOperations returns the operations of an array or range column. These types only exist in PostgreSQL, so the
+// SQL doesn't come from an adapter.
+func buildPGOperations(column schema.Column, is schema.Initialisms) (ops []FormatOperation, imports []string) {
+	if !column.IsPGType() {
+		return nil, nil
+	}
+
+	element := column.Datatype.Element().GoTypeString()
+	if column.Datatype.IsArray() {
+		ops = []FormatOperation{
+			{Name: Contains, Format: "%s @> ?", Params: "val " + column.BaseType(is), Args: "val", Values: "val"},
+			{Name: Overlaps, Format: "%s && ?", Params: "val " + column.BaseType(is), Args: "val", Values: "val"},
+			{Name: AnyEquals, Format: "? = ANY(%s)", Params: "val " + element, Args: "val", Values: "val"},
+		}
+	} else {
+		ops = []FormatOperation{
+			{Name: Contains, Format: "%s @> ?::" + rangeElementTypes[column.Datatype], Params: "val " + element, Args: "val", Values: "val"},
+			{Name: Overlaps, Format: "%s && ?", Params: "val " + column.BaseType(is), Args: "val", Values: "val"},
+		}
+	}
+
+	if column.Datatype.Element().IsTime() {
+		imports = append(imports, `"time"`)
+	}
+
+	return ops, imports
+}
+
+// FormatOperation is a query operation whose condition is SQL formatted with the column name, instead of an operator
+// like those of an Operation
+type FormatOperation struct {
+	Name string
+	// Format is the SQL of the condition, which is given the column name
+	Format string
+	// Params is the parameter list of the operation's generated functions
+	Params string
+	// Args passes the operation's parameters on to another of its functions
+	Args string
+	// Values are the parameters of the operation's condition
+	Values string
+}
@@ -3,6 +3,11 @@ package repository
 import (
 	"reflect"
 	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/dbms/mysql"
+	"github.com/yoyo-project/yoyo/internal/dbms/postgres"
+	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
 func Test_sortedUnique(t *testing.T) {
@@ -38,3 +43,131 @@ func Test_sortedUnique(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildJSONOperations(t *testing.T) {
+	tests := []struct {
+		name        string
+		column      schema.Column
+		adapter     Adapter
+		wantOps     []FormatOperation
+		wantImports []string
+	}{
+		{
+			name:    "not json",
+			column:  schema.Column{Datatype: datatype.Text},
+			adapter: mysql.NewAdapter(),
+		},
+		{
+			name:    "mysql json",
+			column:  schema.Column{Datatype: datatype.JSON},
+			adapter: mysql.NewAdapter(),
+			wantOps: []FormatOperation{
+				{Name: PathEquals, Format: "JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) = ?", Params: "path, val string", Args: "path, val", Values: "path, val"},
+				{Name: JSONContains, Format: "JSON_CONTAINS(%s, ?)", Params: "val json.RawMessage", Args: "val", Values: "string(val)"},
+			},
+			wantImports: []string{`"encoding/json"`},
+		},
+		{
+			name:    "postgresql jsonb",
+			column:  schema.Column{Datatype: datatype.JSONB},
+			adapter: postgres.NewAdapter(),
+			wantOps: []FormatOperation{
+				{Name: PathEquals, Format: "%s #>> ? = ?", Params: "path, val string", Args: "path, val", Values: "path, val"},
+				{Name: JSONContains, Format: "%s @> ?", Params: "val json.RawMessage", Args: "val", Values: "string(val)"},
+			},
+			wantImports: []string{`"encoding/json"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOps, gotImports := buildJSONOperations(tt.column, tt.adapter)
+			if !reflect.DeepEqual(gotOps, tt.wantOps) {
+				t.Errorf("buildJSONOperations() ops = %v, want %v", gotOps, tt.wantOps)
+			}
+			if !reflect.DeepEqual(gotImports, tt.wantImports) {
+				t.Errorf("buildJSONOperations() imports = %v, want %v", gotImports, tt.wantImports)
+			}
+		})
+	}
+}
+
+func Test_buildPGOperations(t *testing.T) {
+	tests := []struct {
+		name        string
+		column      schema.Column
+		wantOps     []FormatOperation
+		wantImports []string
+	}{
+		{
+			name:   "not array or range",
+			column: schema.Column{Datatype: datatype.Integer},
+		},
+		{
+			name:   "custom go type",
+			column: schema.Column{Datatype: datatype.ArrayOf(datatype.Text), GoType: "github.com/lib/pq.StringArray"},
+		},
+		{
+			name:   "text array",
+			column: schema.Column{Datatype: datatype.ArrayOf(datatype.Text)},
+			wantOps: []FormatOperation{
+				{Name: Contains, Format: "%s @> ?", Params: "val pg.Array[string]", Args: "val", Values: "val"},
+				{Name: Overlaps, Format: "%s && ?", Params: "val pg.Array[string]", Args: "val", Values: "val"},
+				{Name: AnyEquals, Format: "? = ANY(%s)", Params: "val string", Args: "val", Values: "val"},
+			},
+		},
+		{
+			name:   "timestamp range",
+			column: schema.Column{Datatype: datatype.TsTzRange},
+			wantOps: []FormatOperation{
+				{Name: Contains, Format: "%s @> ?::timestamptz", Params: "val time.Time", Args: "val", Values: "val"},
+				{Name: Overlaps, Format: "%s && ?", Params: "val pg.Range[time.Time]", Args: "val", Values: "val"},
+			},
+			wantImports: []string{`"time"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOps, gotImports := buildPGOperations(tt.column, nil)
+			if !reflect.DeepEqual(gotOps, tt.wantOps) {
+				t.Errorf("buildPGOperations() ops = %v, want %v", gotOps, tt.wantOps)
+			}
+			if !reflect.DeepEqual(gotImports, tt.wantImports) {
+				t.Errorf("buildPGOperations() imports = %v, want %v", gotImports, tt.wantImports)
+			}
+		})
+	}
+}
+
+func TestColumnParams_Doc(t *testing.T) {
+	tests := []struct {
+		name      string
+		column    schema.Column
+		operation string
+		want      string
+	}{
+		{
+			name:      "no comment",
+			column:    schema.Column{Name: "name"},
+			operation: Equals,
+		},
+		{
+			name:      "equals",
+			column:    schema.Column{Name: "name", Comment: "The person's full name"},
+			operation: Equals,
+			want:      "// Name filters on the name column.\n//\n// The person's full name\n",
+		},
+		{
+			name:      "other operation",
+			column:    schema.Column{Name: "name", Comment: "The person's full name"},
+			operation: StartsWith,
+			want:      "// NameStartsWith filters on the name column.\n//\n// The person's full name\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ColumnParams{Column: tt.column}).Doc(tt.operation); got != tt.want {
+				t.Errorf("Doc() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,8 @@ import (
 type RepositoriesFileParams struct {
 	schema.Database
 	PackageName string
+	// Initialisms are the Database's initialisms, for the Go names of its tables and views
+	Initialisms schema.Initialisms
 }
 
 func NewRepositoriesGenerator(packageName string) WriteGenerator {
@@ -19,6 +21,7 @@ func NewRepositoriesGenerator(packageName string) WriteGenerator {
 		ps := RepositoriesFileParams{
 			Database:    db,
 			PackageName: packageName,
+			Initialisms: db.Initialisms(),
 		}
 		tpl := goTemplate.Must(goTemplate.New("RepositoriesFile").Parse(template.RepositoriesFile))
 		err = tpl.Execute(w, ps)
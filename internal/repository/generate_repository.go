@@ -6,6 +6,7 @@ import (
 	"strings"
 	goTemplate "text/template"
 
+	"github.com/yoyo-project/yoyo/internal/datatype"
 	"github.com/yoyo-project/yoyo/internal/repository/template"
 	"github.com/yoyo-project/yoyo/internal/schema"
 )
@@ -18,9 +19,11 @@ type RepositoryParams struct {
 	PackageName   string
 	PKNames       []string
 	InsertColumns []string
+	UpdateColumns []string
 	SelectColumns []string
 	ScanFields    []string
-	InFields      []string
+	InsertFields  []string
+	UpdateFields  []string
 	PKFields      []string
 
 	QueryImportPath   string
@@ -29,13 +32,32 @@ type RepositoryParams struct {
 	PKCapture string
 	PKQuery   string
 
+	// VersionField is the name of the entity field for the table's version column, if it has one
+	VersionField string
+
+	// SoftDeleteColumn is the name of the table's soft delete column, if it has one
+	SoftDeleteColumn      string
+	SoftDeletePlaceholder string
+
+	// InsertTimestamps and UpdateTimestamps are statements setting auto_now_add and auto_now fields to `now`
+	InsertTimestamps []string
+	UpdateTimestamps []string
+
+	// InsertUUIDs are statements giving UUID primary key fields a new UUID on insert, unless they're already set
+	InsertUUIDs []string
+	UUIDImport  string
+
 	StatementPlaceholders []string
+
+	// ReadOnly repositories, like those of views, only have FetchOne and Search
+	ReadOnly bool
 }
 
 func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath string, packagePath Finder, db schema.Database) EntityGenerator {
 	return func(t schema.Table, w io.Writer) (err error) {
+		is := db.Initialisms()
 		ps := RepositoryParams{
-			ExportedGoName:   t.ExportedGoName(),
+			ExportedGoName:   t.ExportedGoName(is),
 			QueryPackageName: t.QueryPackageName(),
 			Table:            t,
 			PackageName: packageName,
@@ -43,15 +65,35 @@ func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath
 
 		for _, col := range t.Columns {
 			if col.PrimaryKey {
-				ps.PKFields = append(ps.PKFields, strings.ReplaceAll(template.PKFieldTemplate, template.FieldName, col.ExportedGoName()))
+				ps.PKFields = append(ps.PKFields, strings.ReplaceAll(template.PKFieldTemplate, template.FieldName, col.ExportedGoName(is)))
 				ps.PKNames = append(ps.PKNames, col.Name)
+				if col.IsUUID() && !col.Nullable {
+					ps.InsertUUIDs = append(ps.InsertUUIDs, fmt.Sprintf("if in.%[1]s.IsZero() {\n\t\tin.%[1]s = %[2]s.New()\n\t}", col.ExportedGoName(is), datatype.UUIDPackage))
+				}
+			}
+			ps.SelectColumns = append(ps.SelectColumns, col.Name)
+			ps.ScanFields = append(ps.ScanFields, fmt.Sprintf("&ent.%s", col.ExportedGoName(is)))
+			if col.Generated != nil {
+				// generated columns are computed by the database, so they're only ever read
+				continue
 			}
 			if !col.AutoIncrement {
 				ps.InsertColumns = append(ps.InsertColumns, col.Name)
+				ps.InsertFields = append(ps.InsertFields, fmt.Sprintf("in.%s", col.ExportedGoName(is)))
+			}
+			ps.UpdateColumns = append(ps.UpdateColumns, col.Name)
+			ps.UpdateFields = append(ps.UpdateFields, fmt.Sprintf("in.%s", col.ExportedGoName(is)))
+
+			if col.AutoNowAdd || col.AutoNow {
+				set := fmt.Sprintf("in.%s = now", col.ExportedGoName(is))
+				if col.Nullable {
+					set = fmt.Sprintf("in.%s.Set(now)", col.ExportedGoName(is))
+				}
+				ps.InsertTimestamps = append(ps.InsertTimestamps, set)
+				if col.AutoNow {
+					ps.UpdateTimestamps = append(ps.UpdateTimestamps, set)
+				}
 			}
-			ps.SelectColumns = append(ps.SelectColumns, col.Name)
-			ps.ScanFields = append(ps.ScanFields, fmt.Sprintf("&ent.%s", col.ExportedGoName()))
-			ps.InFields = append(ps.InFields, fmt.Sprintf("in.%s", col.ExportedGoName()))
 		}
 
 		for _, r := range t.References {
@@ -60,12 +102,14 @@ func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath
 				for _, cn := range r.ColNames(ft) {
 					ps.SelectColumns = append(ps.SelectColumns, cn)
 					ps.InsertColumns = append(ps.InsertColumns, cn)
+					ps.UpdateColumns = append(ps.UpdateColumns, cn)
 				}
 				for _, cn := range ft.PKColNames() {
 					c, _ := ft.GetColumn(cn)
-					goName := fmt.Sprintf("%s%s", ft.ExportedGoName(), c.ExportedGoName())
+					goName := fmt.Sprintf("%s%s", ft.ExportedGoName(is), c.ExportedGoName(is))
 					ps.ScanFields = append(ps.ScanFields, fmt.Sprintf("&ent.%s", goName))
-					ps.InFields = append(ps.InFields, fmt.Sprintf("in.%s", goName))
+					ps.InsertFields = append(ps.InsertFields, fmt.Sprintf("in.%s", goName))
+					ps.UpdateFields = append(ps.UpdateFields, fmt.Sprintf("in.%s", goName))
 				}
 			}
 		}
@@ -76,24 +120,49 @@ func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath
 					for _, col := range t2.PKColumns() {
 						ps.SelectColumns = append(ps.SelectColumns, col.Name)
 						ps.InsertColumns = append(ps.InsertColumns, col.Name)
-						ps.ScanFields = append(ps.ScanFields, fmt.Sprintf("&ent.%s", t2.ExportedGoName()+col.ExportedGoName()))
-						ps.InFields = append(ps.InFields, fmt.Sprintf("in.%s", col.ExportedGoName()))
+						ps.UpdateColumns = append(ps.UpdateColumns, col.Name)
+						goName := t2.ExportedGoName(is) + col.ExportedGoName(is)
+						ps.ScanFields = append(ps.ScanFields, fmt.Sprintf("&ent.%s", goName))
+						ps.InsertFields = append(ps.InsertFields, fmt.Sprintf("in.%s", goName))
+						ps.UpdateFields = append(ps.UpdateFields, fmt.Sprintf("in.%s", goName))
 					}
 				}
 			}
 		}
 
+		if vc, ok := t.GetColumn(t.VersionColumn); ok && t.VersionColumn != "" {
+			// updates only match the row if its version hasn't changed since it was fetched
+			ps.VersionField = vc.ExportedGoName(is)
+			ps.PKFields = append(ps.PKFields, strings.ReplaceAll(template.PKFieldTemplate, template.FieldName, ps.VersionField))
+		}
+
+		if _, ok := t.GetColumn(t.SoftDeleteColumn); ok && t.SoftDeleteColumn != "" {
+			ps.SoftDeleteColumn = t.SoftDeleteColumn
+			ps.SoftDeletePlaceholder = adapter.PreparedStatementPlaceholders(1)[0]
+			// updates must still find the row of a soft deleted entity
+			ps.PKFields = append(ps.PKFields, "WithDeleted().")
+		}
+
 		ps.QueryImportPath, err = packagePath(fmt.Sprintf("%s/query/%s", reposPath, t.QueryPackageName()))
 		if err != nil {
 			return fmt.Errorf("unable to generate repository: %w", err)
 		}
 
+		if len(ps.InsertUUIDs) > 0 {
+			var uuidPackagePath string
+			uuidPackagePath, err = packagePath(reposPath + "/uuid")
+			if err != nil {
+				return fmt.Errorf("unable to generate repository: %w", err)
+			}
+			ps.UUIDImport = uuidImport(uuidPackagePath)
+		}
+
 		var pkCapTemplate string
 		pkReplacer := strings.NewReplacer()
 
 		switch len(t.PKColumns()) {
 		case 0:
-			// Do nothing
+			pkCapTemplate = template.NoPKCapture
 		case 1:
 			col := t.PKColumns()[0]
 			switch col.AutoIncrement {
@@ -104,9 +173,9 @@ func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath
 			}
 			pkReplacer = strings.NewReplacer(
 				template.FieldName,
-				col.ExportedGoName(),
+				col.ExportedGoName(is),
 				template.Type,
-				col.GoTypeString(),
+				col.GoTypeString(is),
 			)
 		default:
 			pkCapTemplate = template.MultiPKCaptureTemplate
@@ -124,9 +193,10 @@ func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath
 
 		ps.PKQuery = pkQueryReplacer.Replace(template.PKQueryTemplate)
 
-		ps.StatementPlaceholders = adapter.PreparedStatementPlaceholders(len(ps.SelectColumns))
-		for i, colName := range ps.SelectColumns {
-			ps.ColumnAssignments = append(ps.ColumnAssignments, fmt.Sprintf("%s = %s", colName, ps.StatementPlaceholders[i]))
+		ps.StatementPlaceholders = adapter.PreparedStatementPlaceholders(len(ps.InsertColumns))
+		updatePlaceholders := adapter.PreparedStatementPlaceholders(len(ps.UpdateColumns))
+		for i, colName := range ps.UpdateColumns {
+			ps.ColumnAssignments = append(ps.ColumnAssignments, fmt.Sprintf("%s = %s", colName, updatePlaceholders[i]))
 		}
 
 		tpl := goTemplate.Must(
@@ -140,6 +210,38 @@ func NewEntityRepositoryGenerator(packageName string, adapter Adapter, reposPath
 	}
 }
 
+// NewViewRepositoryGenerator returns an EntityGenerator for the read-only repositories of views, which is given the
+// embedded schema.Table of a schema.View
+func NewViewRepositoryGenerator(packageName string, reposPath string, packagePath Finder, db schema.Database) EntityGenerator {
+	return func(t schema.Table, w io.Writer) (err error) {
+		is := db.Initialisms()
+		ps := RepositoryParams{
+			ExportedGoName:   t.ExportedGoName(is),
+			QueryPackageName: t.QueryPackageName(),
+			Table:            t,
+			PackageName:      packageName,
+			ReadOnly:         true,
+		}
+
+		for _, col := range t.Columns {
+			ps.SelectColumns = append(ps.SelectColumns, col.Name)
+			ps.ScanFields = append(ps.ScanFields, fmt.Sprintf("&ent.%s", col.ExportedGoName(is)))
+		}
+
+		ps.QueryImportPath, err = packagePath(fmt.Sprintf("%s/query/%s", reposPath, t.QueryPackageName()))
+		if err != nil {
+			return fmt.Errorf("unable to generate repository: %w", err)
+		}
+
+		tpl := goTemplate.Must(
+			goTemplate.New("RepositoryFile").
+				Funcs(goTemplate.FuncMap{"join": Join}).
+				Parse(template.RepositoryFile),
+		)
+		return tpl.Execute(w, ps)
+	}
+}
+
 func Join(d string, ss []string) string {
 	return strings.Join(ss, d)
 }
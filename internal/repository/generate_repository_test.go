@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+func TestNewViewRepositoryGenerator(t *testing.T) {
+	view := schema.Table{
+		Name: "city_population",
+		Columns: []schema.Column{
+			{Name: "city_id", Datatype: datatype.Integer},
+			{Name: "population", Datatype: datatype.BigInt},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		packagePath Finder
+		want        []string
+		wantMissing []string
+		wantErr     bool
+	}{
+		{
+			name:        "read-only repository",
+			packagePath: func(s string) (string, error) { return "example.com/" + s, nil },
+			want: []string{
+				`const selectCityPopulation = "SELECT city_id, population FROM city_population %s;"`,
+				`"example.com/repositories/query/city_population"`,
+				"func (r *CityPopulationRepository) FetchOne(query city_population.Query)",
+				"func (r *CityPopulationRepository) Search(query city_population.Query)",
+				"err = row.Scan(&ent.CityId, &ent.Population)",
+			},
+			wantMissing: []string{"Save(", "Delete(", "insertCityPopulation", "\"context\"", "BeforeSave"},
+		},
+		{
+			name:        "package path error",
+			packagePath: func(s string) (string, error) { return "", errors.New("not found") },
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := strings.Builder{}
+			err := NewViewRepositoryGenerator("repositories", "repositories", tt.packagePath, schema.Database{})(view, &sb)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			got := sb.String()
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("want output containing %q, got:\n%s", w, got)
+				}
+			}
+			for _, w := range tt.wantMissing {
+				if strings.Contains(got, w) {
+					t.Errorf("want output without %q, got:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"io"
+	goTemplate "text/template"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/repository/template"
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+type UUIDFileParams struct {
+	// Storage is how UUIDs are stored in the database, one of the schema.UUIDStorage constants
+	Storage string
+}
+
+// NewUUIDFileGenerator returns a WriteGenerator for the uuid package, whose types convert UUIDs to and from the
+// database's EffectiveUUIDStorage
+func NewUUIDFileGenerator() WriteGenerator {
+	return func(db schema.Database, w io.Writer) error {
+		ps := UUIDFileParams{
+			Storage: db.EffectiveUUIDStorage(),
+		}
+		tpl := goTemplate.Must(goTemplate.New("UUIDFile").Parse(template.UUIDFile))
+		return tpl.Execute(w, ps)
+	}
+}
+
+// uuidImport returns the import of the uuid package at path, aliased to datatype.UUIDPackage so it doesn't collide with
+// another package named uuid, like that of a `github.com/google/uuid.UUID` go_type
+func uuidImport(path string) string {
+	return datatype.UUIDPackage + ` "` + path + `"`
+}
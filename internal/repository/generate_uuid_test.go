@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+func TestNewUUIDFileGenerator(t *testing.T) {
+	tests := []struct {
+		name        string
+		db          schema.Database
+		wantValue   string
+		wantSwapped bool
+	}{
+		{
+			name:      "mysql default",
+			db:        schema.Database{Dialect: "mysql"},
+			wantValue: "return u[:], nil",
+		},
+		{
+			name:        "binary swapped",
+			db:          schema.Database{Dialect: "mysql", UUIDStorage: schema.UUIDStorageBinarySwapped},
+			wantValue:   "s := swap(u)",
+			wantSwapped: true,
+		},
+		{
+			name:      "char",
+			db:        schema.Database{Dialect: "mysql", UUIDStorage: schema.UUIDStorageChar},
+			wantValue: "return u.String(), nil",
+		},
+		{
+			name:      "postgresql native",
+			db:        schema.Database{Dialect: "postgresql"},
+			wantValue: "return u.String(), nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := strings.Builder{}
+			if err := NewUUIDFileGenerator()(tt.db, &sb); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := sb.String()
+			if !strings.Contains(got, tt.wantValue) {
+				t.Errorf("want Value containing %q, got:\n%s", tt.wantValue, got)
+			}
+			if hasSwap := strings.Contains(got, "func swap("); hasSwap != tt.wantSwapped {
+				t.Errorf("want swap %v, got %v", tt.wantSwapped, hasSwap)
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ const (
 	Type                  = "$TYPE$"
 )
 
+// NoPKCapture returns the saved input from insert when there's no auto_increment primary key to capture
 const NoPKCapture = `
 	_ = res
+	e = in
 `
 
 var SinglePKCaptureTemplate = `
@@ -7,6 +7,14 @@ import (
 //go:embed null_types.gotpl
 var NullTypeFile string
 
+//go:embed null_value.gotpl
+var NullValueFile string
+
+// JSONOfFile is appended to both NullTypeFile and NullValueFile, so the nullable package has the same JSONOf[T] either way
+//
+//go:embed json_of.gotpl
+var JSONOfFile string
+
 //go:embed entity.gotpl
 var EntityFile string
 
@@ -20,4 +28,13 @@ var QueryFile string
 var RepositoriesFile string
 
 //go:embed repository.gotpl
-var RepositoryFile string
\ No newline at end of file
+var RepositoryFile string
+
+//go:embed uuid.gotpl
+var UUIDFile string
+
+//go:embed pg.gotpl
+var PGFile string
+
+//go:embed geo.gotpl
+var GeoFile string
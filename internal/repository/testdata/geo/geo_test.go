@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// square returns a closed ring around the square from (x, y) to (x+size, y+size)
+func square(x, y, size float64) LineString {
+	return LineString{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}, {x, y}}
+}
+
+func TestShape_roundTrip(t *testing.T) {
+	roundTrip(t, "point", New(Point{1.5, -2}, 4326))
+	roundTrip(t, "empty point", New(Point{math.NaN(), math.NaN()}, 0))
+	roundTrip(t, "line string", New(LineString{{0, 0}, {1, 1}, {2, 0}}, 3857))
+	roundTrip(t, "polygon with a hole", New(Polygon{square(0, 0, 10), square(2, 2, 2)}, 4326))
+	roundTrip(t, "multi point", New(MultiPoint{{1, 2}, {3, 4}}, 4326))
+	roundTrip(t, "multi line string", New(MultiLineString{{{0, 0}, {1, 1}}, {{2, 2}, {3, 3}}}, 4326))
+	roundTrip(t, "multi polygon", New(MultiPolygon{{square(0, 0, 1)}, {square(5, 5, 1), square(5.25, 5.25, 0.5)}}, 4326))
+	roundTrip(t, "collection", New(Collection{Point{1, 2}, LineString{{0, 0}, {1, 1}}, Collection{Point{3, 4}}}, 4326))
+	roundTrip(t, "empty collection", New(Collection{}, 4326))
+	roundTrip(t, "any geometry", New[Geometry](Polygon{square(0, 0, 1)}, 4326))
+	roundTrip(t, "null", Shape[Point]{})
+}
+
+// roundTrip checks that scanning the Value of want gives a Shape equal to want
+func roundTrip[T Geometry](t *testing.T, name string, want Shape[T]) {
+	t.Run(name, func(t *testing.T) {
+		v, err := want.Value()
+		if err != nil {
+			t.Fatalf("Value() error = %s", err)
+		}
+
+		var got Shape[T]
+		if err = got.Scan(v); err != nil {
+			t.Fatalf("Scan(%x) error = %s", v, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("Scan(Value()) = %+v, want %+v", got, want)
+		}
+	})
+}
+
+func TestShape_Value_nilGeometry(t *testing.T) {
+	tests := map[string]Shape[Geometry]{
+		"nil geometry":                 New[Geometry](nil, 0),
+		"nil in collection":            New[Geometry](Collection{Point{1, 2}, nil}, 0),
+		"nil in a collection's member": New[Geometry](Collection{Collection{nil}}, 0),
+	}
+	for name, s := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.Value(); err == nil {
+				t.Errorf("Value() error = nil, want an error")
+			}
+			if s.Equal(s) {
+				t.Errorf("Equal() = true, want false for a geometry without WKB")
+			}
+		})
+	}
+
+	if _, err := New(Collection{Point{1, 2}, nil}, 0).Value(); err == nil || !strings.Contains(err.Error(), "geometry 1 of collection") {
+		t.Errorf("Value() error = %v, want it to name the nil geometry", err)
+	}
+}
+
+// bigEndianPoint returns the big-endian WKB of a point
+func bigEndianPoint(x, y float64) []byte {
+	b := binary.BigEndian.AppendUint32([]byte{0}, wkbPoint)
+	b = binary.BigEndian.AppendUint64(b, math.Float64bits(x))
+	return binary.BigEndian.AppendUint64(b, math.Float64bits(y))
+}
+
+// mixedEndianCollection is the WKB of a big-endian collection of a little-endian multi point and a big-endian point
+func mixedEndianCollection() []byte {
+	b := binary.BigEndian.AppendUint32([]byte{0}, wkbCollection)
+	b = binary.BigEndian.AppendUint32(b, 2)
+	b = MultiPoint{{1, 2}, {3, 4}}.appendWKB(b)
+	return append(b, bigEndianPoint(5, 6)...)
+}
+
+var wantMixedEndian = Collection{MultiPoint{{1, 2}, {3, 4}}, Point{5, 6}}
+
+func TestShape_Scan_invalid(t *testing.T) {
+	point, err := New(Point{1, 2}, 4326).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("wrong geometry type", func(t *testing.T) {
+		var s Shape[LineString]
+		if err := s.Scan(point); err == nil {
+			t.Errorf("Scan() error = nil, want an error")
+		}
+	})
+
+	t.Run("unsupported source", func(t *testing.T) {
+		var s Shape[Point]
+		if err := s.Scan(42); err == nil {
+			t.Errorf("Scan() error = nil, want an error")
+		}
+	})
+}
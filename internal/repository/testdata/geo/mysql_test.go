@@ -0,0 +1,58 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// mysqlPoint is POINT(1 2) with SRID 4326, as MySQL stores it
+const mysqlPoint = "e6100000" + "01" + "01000000" + "000000000000f03f" + "0000000000000040"
+
+func TestShape_mysql(t *testing.T) {
+	want, _ := hex.DecodeString(mysqlPoint)
+
+	got, err := New(Point{1, 2}, 4326).Value()
+	if err != nil {
+		t.Fatalf("Value() error = %s", err)
+	}
+	if b, ok := got.([]byte); !ok || !bytes.Equal(b, want) {
+		t.Errorf("Value() = %x, want %s", got, mysqlPoint)
+	}
+
+	var s Shape[Point]
+	if err = s.Scan(want); err != nil {
+		t.Fatalf("Scan() error = %s", err)
+	}
+	if !s.Equal(New(Point{1, 2}, 4326)) {
+		t.Errorf("Scan() = %+v, want POINT(1 2) with SRID 4326", s)
+	}
+}
+
+func TestShape_mysql_mixedEndian(t *testing.T) {
+	var s Shape[Collection]
+	if err := s.Scan(append([]byte{0xe6, 0x10, 0, 0}, mixedEndianCollection()...)); err != nil {
+		t.Fatalf("Scan() error = %s", err)
+	}
+	if !s.Equal(New(wantMixedEndian, 4326)) {
+		t.Errorf("Scan() = %+v, want %+v", s, wantMixedEndian)
+	}
+}
+
+func TestShape_mysql_invalid(t *testing.T) {
+	valid, _ := hex.DecodeString(mysqlPoint)
+	tests := map[string][]byte{
+		"shorter than an SRID": {0xe6, 0x10},
+		"truncated":            valid[:len(valid)-1],
+		"invalid byte order":   append(append([]byte{}, valid[:4]...), append([]byte{2}, valid[5:]...)...),
+		"huge count":           append(append([]byte{}, valid[:4]...), 1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff),
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			var s Shape[Geometry]
+			if err := s.Scan(src); err == nil {
+				t.Errorf("Scan() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package geo
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// These are SRID=4326;POINT(1 2) as hex encoded little-endian and big-endian EWKB, like PostGIS returns it
+const (
+	ewkbPoint          = "01" + "01000020" + "e6100000" + "000000000000f03f" + "0000000000000040"
+	ewkbPointBigEndian = "00" + "20000001" + "000010e6" + "3ff0000000000000" + "4000000000000000"
+)
+
+func TestShape_postgresql(t *testing.T) {
+	got, err := New(Point{1, 2}, 4326).Value()
+	if err != nil {
+		t.Fatalf("Value() error = %s", err)
+	}
+	if got != ewkbPoint {
+		t.Errorf("Value() = %v, want %s", got, ewkbPoint)
+	}
+
+	binary, _ := hex.DecodeString(ewkbPoint)
+	tests := map[string]interface{}{
+		"hex text":       ewkbPoint,
+		"hex bytes":      []byte(ewkbPoint),
+		"upper case hex": []byte("0101000020E6100000000000000000F03F0000000000000040"),
+		"big-endian hex": ewkbPointBigEndian,
+		"binary":         binary,
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			var s Shape[Point]
+			if err := s.Scan(src); err != nil {
+				t.Fatalf("Scan() error = %s", err)
+			}
+			if !s.Equal(New(Point{1, 2}, 4326)) {
+				t.Errorf("Scan() = %+v, want POINT(1 2) with SRID 4326", s)
+			}
+		})
+	}
+}
+
+func TestShape_postgresql_mixedEndian(t *testing.T) {
+	var s Shape[Collection]
+	if err := s.Scan(hex.EncodeToString(mixedEndianCollection())); err != nil {
+		t.Fatalf("Scan() error = %s", err)
+	}
+	if !s.Equal(New(wantMixedEndian, 0)) {
+		t.Errorf("Scan() = %+v, want %+v", s, wantMixedEndian)
+	}
+}
+
+func TestShape_postgresql_invalid(t *testing.T) {
+	tests := map[string]string{
+		"not hex":        "zz",
+		"truncated":      ewkbPoint[:len(ewkbPoint)-2],
+		"3D":             "01" + "010000a0" + "e6100000" + "000000000000f03f" + "0000000000000040" + "0000000000000840",
+		"unknown type":   "01" + "08000000",
+		"wrong srid len": "01" + "01000020" + "e610",
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			var s Shape[Geometry]
+			if err := s.Scan(src); err == nil {
+				t.Errorf("Scan() error = nil, want an error")
+			}
+		})
+	}
+}
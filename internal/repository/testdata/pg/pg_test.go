@@ -0,0 +1,295 @@
+package pg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArray_Scan(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		tests := []struct {
+			src     string
+			want    Array[string]
+			wantErr bool
+		}{
+			{src: `{}`, want: Array[string]{}},
+			{src: `{a,b}`, want: Array[string]{"a", "b"}},
+			{src: `{"a \"b\"","c\\d"}`, want: Array[string]{`a "b"`, `c\d`}},
+			{src: `{"x,y","{z}"," "}`, want: Array[string]{"x,y", "{z}", " "}},
+			{src: `{"NULL",""}`, want: Array[string]{"NULL", ""}},
+			{src: `{ a , "b" }`, want: Array[string]{"a", "b"}},
+			{src: `{NULL}`, wantErr: true},
+			{src: `{a,null}`, wantErr: true},
+			{src: `{a,}`, wantErr: true},
+			{src: `{,a}`, wantErr: true},
+			{src: `{"a}`, wantErr: true},
+			{src: `{"a"b}`, wantErr: true},
+			{src: `{{a},{b}}`, wantErr: true},
+			{src: `a,b`, wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.src, func(t *testing.T) {
+				var got Array[string]
+				err := got.Scan([]byte(tt.src))
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Scan() = %#v, want %#v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		var got Array[int32]
+		if err := got.Scan("{1,-2,3}"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (Array[int32]{1, -2, 3}); !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan() = %v, want %v", got, want)
+		}
+		if err := got.Scan("{1,}"); err == nil {
+			t.Errorf("Scan({1,}) error = nil, want an error")
+		}
+		if err := got.Scan("{1,x}"); err == nil {
+			t.Errorf("Scan({1,x}) error = nil, want an error")
+		}
+	})
+
+	t.Run("booleans", func(t *testing.T) {
+		var got Array[bool]
+		if err := got.Scan("{t,f,true}"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (Array[bool]{true, false, true}); !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		got := Array[string]{"a"}
+		if err := got.Scan(nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != nil {
+			t.Errorf("Scan(nil) = %#v, want nil", got)
+		}
+	})
+}
+
+func TestArray_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		arr  Array[string]
+		want interface{}
+	}{
+		{name: "null", arr: nil, want: nil},
+		{name: "empty", arr: Array[string]{}, want: "{}"},
+		{name: "quoted", arr: Array[string]{`a "b"`, `c\d`, "NULL"}, want: `{"a \"b\"","c\\d","NULL"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arr.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArray_roundTrip(t *testing.T) {
+	strs := []Array[string]{
+		nil,
+		{},
+		{""},
+		{"NULL", "null", "Null"},
+		{`"`, `\`, `\"`, `""`},
+		{"a,b", "{c}", "(d)", " e ", "f\tg"},
+		{"ünïcødé", "日本"},
+	}
+	for _, want := range strs {
+		roundTrip(t, want)
+	}
+
+	roundTrip(t, Array[int16]{-32768, 0, 32767})
+	roundTrip(t, Array[int64]{-1 << 63, 1<<63 - 1})
+	roundTrip(t, Array[float64]{0.1, -2.5e-10, 1e300})
+	roundTrip(t, Array[bool]{true, false})
+	roundTrip(t, Array[time.Time]{
+		time.Date(2024, 3, 1, 12, 30, 0, 500, time.FixedZone("", 3600)),
+		time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+	})
+}
+
+// roundTrip checks that scanning the Value of want gives an Array equal to want
+func roundTrip[T Element](t *testing.T, want Array[T]) {
+	t.Helper()
+
+	v, err := want.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %s", err)
+	}
+
+	var got Array[T]
+	if err = got.Scan(v); err != nil {
+		t.Fatalf("Scan(%#v) error = %s", v, err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("Scan(Value()) = %#v, want %#v, through %#v", got, want, v)
+	}
+}
+
+func TestRange_Scan(t *testing.T) {
+	t.Run("integers", func(t *testing.T) {
+		tests := []struct {
+			src     string
+			want    Range[int32]
+			wantErr bool
+		}{
+			{src: `[1,10)`, want: NewRange[int32](1, 10)},
+			{src: `(1,10]`, want: Range[int32]{Lower: 1, Upper: 10, UpperInclusive: true, Valid: true}},
+			{src: `[5,)`, want: Range[int32]{Lower: 5, LowerInclusive: true, UpperUnbounded: true, Valid: true}},
+			{src: `(,5)`, want: Range[int32]{Upper: 5, LowerUnbounded: true, Valid: true}},
+			{src: `(,)`, want: Range[int32]{LowerUnbounded: true, UpperUnbounded: true, Valid: true}},
+			{src: `empty`, want: Range[int32]{Empty: true, Valid: true}},
+			{src: `EMPTY`, want: Range[int32]{Empty: true, Valid: true}},
+			{src: `[1,2,3)`, wantErr: true},
+			{src: `[1;2)`, wantErr: true},
+			{src: `[1,2`, wantErr: true},
+			{src: `1,2`, wantErr: true},
+			{src: `[a,2)`, wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.src, func(t *testing.T) {
+				var got Range[int32]
+				err := got.Scan(tt.src)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if !tt.wantErr && got != tt.want {
+					t.Errorf("Scan() = %+v, want %+v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("timestamps with time zone", func(t *testing.T) {
+		var got Range[time.Time]
+		if err := got.Scan([]byte(`["2024-03-01 12:00:00+01","2024-03-02 00:00:00.25+05:30")`)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		lower := time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC)
+		upper := time.Date(2024, 3, 1, 18, 30, 0, 250000000, time.UTC)
+		if !got.Lower.Equal(lower) || !got.Upper.Equal(upper) || !got.LowerInclusive || got.UpperInclusive {
+			t.Errorf("Scan() = %+v, want [%s,%s)", got, lower, upper)
+		}
+	})
+
+	t.Run("dates", func(t *testing.T) {
+		var got Range[time.Time]
+		if err := got.Scan(`[2024-03-01,2024-04-01)`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC); !got.Lower.Equal(want) {
+			t.Errorf("Scan() Lower = %s, want %s", got.Lower, want)
+		}
+	})
+
+	t.Run("quoted bounds", func(t *testing.T) {
+		var got Range[string]
+		if err := got.Scan(`["a""b","c\\d")`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Lower != `a"b` || got.Upper != `c\d` {
+			t.Errorf("Scan() = %+v, want [a\"b,c\\d)", got)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		got := NewRange[int32](1, 2)
+		if err := got.Scan(nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Valid {
+			t.Errorf("Scan(nil) = %+v, want null", got)
+		}
+	})
+}
+
+func TestRange_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Range[int64]
+		want interface{}
+	}{
+		{name: "null", r: Range[int64]{}, want: nil},
+		{name: "empty", r: Range[int64]{Empty: true, Valid: true}, want: "empty"},
+		{name: "half open", r: NewRange[int64](1, 10), want: "[1,10)"},
+		{name: "unbounded", r: Range[int64]{LowerUnbounded: true, UpperUnbounded: true, Valid: true}, want: "(,)"},
+		{
+			name: "unbounded ignores inclusive",
+			r:    Range[int64]{Lower: 3, LowerInclusive: true, UpperUnbounded: true, UpperInclusive: true, Valid: true},
+			want: "[3,)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_roundTrip(t *testing.T) {
+	t1 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("", -7*3600))
+	t2 := time.Date(2024, 3, 2, 0, 0, 0, 123456000, time.UTC)
+
+	tests := []interface {
+		roundTrip(t *testing.T)
+	}{
+		rangeTest[int32]{},
+		rangeTest[int32]{NewRange[int32](-5, 5)},
+		rangeTest[int32]{Range[int32]{Empty: true, Valid: true}},
+		rangeTest[int32]{Range[int32]{LowerUnbounded: true, UpperUnbounded: true, Valid: true}},
+		rangeTest[float64]{Range[float64]{Lower: 0.5, Upper: 1e10, UpperInclusive: true, Valid: true}},
+		rangeTest[time.Time]{NewRange(t1, t2)},
+		rangeTest[time.Time]{Range[time.Time]{Lower: t1, LowerInclusive: true, UpperUnbounded: true, Valid: true}},
+		rangeTest[string]{NewRange(`a"b`, `c\d,e`)},
+	}
+	for _, tt := range tests {
+		tt.roundTrip(t)
+	}
+}
+
+type rangeTest[T Element] struct {
+	want Range[T]
+}
+
+// roundTrip checks that scanning the Value of the Range gives an equal Range
+func (rt rangeTest[T]) roundTrip(t *testing.T) {
+	t.Helper()
+
+	v, err := rt.want.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %s", err)
+	}
+
+	var got Range[T]
+	if err = got.Scan(v); err != nil {
+		t.Fatalf("Scan(%#v) error = %s", v, err)
+	}
+	if !got.Equal(rt.want) {
+		t.Errorf("Scan(Value()) = %+v, want %+v, through %#v", got, rt.want, v)
+	}
+}
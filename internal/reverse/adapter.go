@@ -16,8 +16,16 @@ type AdapterBuilder func(host, userName, dbName, password, port string) (Adapter
 // Adapter is the yoyo interface for reverse-engineering databases to a schema.Database for creating diff migrations
 // and for creating a yoyo.yml from an existing database.
 type Adapter interface {
+	// ListTables returns a []string of table names. It MUST NOT return any views, which will instead come from ListViews
 	ListTables() ([]string, error)
 
+	// ListViews returns a []string of view names
+	ListViews() ([]string, error)
+
+	// GetView returns a schema.View with the definition of the given view. Its columns come from ListColumns and
+	// GetColumn.
+	GetView(view string) (schema.View, error)
+
 	// ListColumns returns a []string of column names for the given table
 	// It MUST NOT return any columns which are foreign key columns. These will instead come from ListReferences
 	ListColumns(table string) ([]string, error)
@@ -38,6 +46,21 @@ type Adapter interface {
 
 	// GetReference returns a schema.Reference representing the given tableName and indexName.
 	GetReference(table, column string) (schema.Reference, error)
+
+	// ListChecks returns a []string of CHECK constraint names for the given table
+	ListChecks(table string) ([]string, error)
+
+	// GetCheck returns a schema.Check representing the given CHECK constraint on the given table
+	GetCheck(table, check string) (schema.Check, error)
+
+	// ListUniques returns a []string of UNIQUE constraint names for the given table
+	ListUniques(table string) ([]string, error)
+
+	// GetUnique returns a schema.Unique representing the given UNIQUE constraint on the given table
+	GetUnique(table, unique string) (schema.Unique, error)
+
+	// GetTableOptions returns the schema.TableOptions of the given table
+	GetTableOptions(table string) (schema.TableOptions, error)
 }
 
 func InitAdapterSelector(newMysqlReverser, newPostgresReverser AdapterBuilder) func(dia string) (adapter Adapter, err error) {
@@ -55,14 +55,103 @@ func InitDatabaseReader(loadAdapter AdapterLoader) DatabaseReader {
 				} else {
 					return db, fmt.Errorf("%w in ListReferences", err)
 				}
+				if table.Checks, err = readChecks(adapter, tableName); err != nil {
+					return db, err
+				}
+				if table.Uniques, err = readUniques(adapter, tableName); err != nil {
+					return db, err
+				}
+				table.Indices = withoutUniques(table.Indices, table.Uniques)
+				if table.Options, err = adapter.GetTableOptions(tableName); err != nil {
+					return db, fmt.Errorf("%w in GetTableOptions", err)
+				}
 
 				table.Name = tableName
 				db.Tables = append(db.Tables, table)
 			}
 		} else {
-			err = fmt.Errorf("%w in ListTables", err)
+			return db, fmt.Errorf("%w in ListTables", err)
 		}
 
+		db.Views, err = readViews(adapter)
+
 		return db, err
 	}
 }
+
+func readViews(adapter Adapter) (views []schema.View, err error) {
+	names, err := adapter.ListViews()
+	if err != nil {
+		return nil, fmt.Errorf("%w in ListViews", err)
+	}
+	for _, name := range names {
+		view, err := adapter.GetView(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in GetView", err)
+		}
+		columns, err := adapter.ListColumns(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in ListColumns", err)
+		}
+		for _, colName := range columns {
+			column, err := adapter.GetColumn(name, colName)
+			if err != nil {
+				return nil, fmt.Errorf("%w in GetColumn", err)
+			}
+			column.Name = colName
+			view.Columns = append(view.Columns, column)
+		}
+		view.Name = name
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func readChecks(adapter Adapter, tableName string) (checks []schema.Check, err error) {
+	names, err := adapter.ListChecks(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("%w in ListChecks", err)
+	}
+	for _, name := range names {
+		check, err := adapter.GetCheck(tableName, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in GetCheck", err)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func readUniques(adapter Adapter, tableName string) (uniques []schema.Unique, err error) {
+	names, err := adapter.ListUniques(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("%w in ListUniques", err)
+	}
+	for _, name := range names {
+		unique, err := adapter.GetUnique(tableName, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in GetUnique", err)
+		}
+		uniques = append(uniques, unique)
+	}
+	return uniques, nil
+}
+
+// withoutUniques removes indices which are also UNIQUE constraints, for DBMSs which report the keys of UNIQUE
+// constraints as both
+func withoutUniques(indices []schema.Index, uniques []schema.Unique) []schema.Index {
+	var filtered []schema.Index
+	for _, i := range indices {
+		isUnique := false
+		for _, u := range uniques {
+			if u.Name == i.Name {
+				isUnique = true
+				break
+			}
+		}
+		if !isUnique {
+			filtered = append(filtered, i)
+		}
+	}
+	return filtered
+}
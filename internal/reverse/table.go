@@ -32,6 +32,20 @@ func InitHasReference(getReference func(table, reference string) (schema.Referen
 	}
 }
 
+func InitHasCheck(getCheck func(table, check string) (schema.Check, error)) TableSearcher {
+	return func(table, check string) bool {
+		_, err := getCheck(table, check)
+		return err == nil
+	}
+}
+
+func InitHasUnique(getUnique func(table, unique string) (schema.Unique, error)) TableSearcher {
+	return func(table, unique string) bool {
+		_, err := getUnique(table, unique)
+		return err == nil
+	}
+}
+
 func InitHasTable(listTables func() ([]string, error)) func(table string) (bool, error) {
 	return func(table string) (bool, error) {
 		tables, err := listTables()
@@ -8,8 +8,47 @@ import (
 type Database struct {
 	Dialect string
 	Tables  []Table
+	Views   []View
+	// GoTypes maps datatypes, optionally with params like `binary(16)`, to custom Go types for all matching columns
+	// which don't set their own GoType
+	GoTypes map[string]string
+	// UUIDStorage is how UUID columns are stored, one of the UUIDStorage constants. It defaults to the dialect's
+	// native UUID type if it has one, or to BINARY(16) otherwise.
+	UUIDStorage string
+	// Include is a list of glob patterns, relative to yoyo.yml, of files which contribute more tables and views
+	Include []string
+	// Strict rejects keys which aren't in the DatabaseJSONSchema, instead of ignoring them
+	Strict bool
+	// Naming are the conventions for generated names
+	Naming Naming
+
+	// positions and pending are only kept until the database is validated, which waits for included files to be loaded
+	positions *positions
+	pending   ValidationErrors
 }
 
+// These are the ways UUID columns can be stored
+const (
+	// UUIDStorageNative uses the dialect's own UUID type
+	UUIDStorageNative = "native"
+	// UUIDStorageBinary stores the 16 bytes of the UUID in a BINARY(16) column
+	UUIDStorageBinary = "binary"
+	// UUIDStorageBinarySwapped stores the 16 bytes in a BINARY(16) column with the time fields swapped, like MySQL's
+	// UUID_TO_BIN(uuid, 1)
+	UUIDStorageBinarySwapped = "binary_swapped"
+	// UUIDStorageChar stores the 36 character text form of the UUID in a CHAR(36) column
+	UUIDStorageChar = "char"
+)
+
+// DefaultSoftDeleteColumn is the column used by tables with `soft_delete: true`
+const DefaultSoftDeleteColumn = "deleted_at"
+
+// DefaultCreatedAtColumn and DefaultUpdatedAtColumn are the columns used by tables with `timestamps: true`
+const (
+	DefaultCreatedAtColumn = "created_at"
+	DefaultUpdatedAtColumn = "updated_at"
+)
+
 // Table represents a table in a database
 type Table struct {
 	Name       string
@@ -17,6 +56,38 @@ type Table struct {
 	Columns    []Column
 	Indices    []Index
 	References []Reference
+	Checks     []Check
+	Uniques    []Unique
+	// VersionColumn is the name of an integer column used for optimistic locking. If the table doesn't declare it, an
+	// unsigned INT column with a default of 0 is added.
+	VersionColumn string
+	// SoftDeleteColumn is the name of a nullable time column which is set instead of deleting rows. If the table doesn't
+	// declare it, a nullable TIMESTAMP column is added.
+	SoftDeleteColumn string
+	Options          TableOptions
+	// Position is where the table is declared, if it was unmarshalled from YAML
+	Position Position
+}
+
+// View represents a view in a database. Its columns are declared like a table's, because yoyo can't derive them from
+// the view's definition. Only the Name, GoName, Columns and Options.Comment of the embedded Table are used.
+type View struct {
+	Table
+	// Definition is the SELECT statement of the view
+	Definition string
+}
+
+// TableOptions are the table-level options of a Table. Empty options are left to the database's defaults.
+type TableOptions struct {
+	// Engine, Charset, Collation and RowFormat are MySQL options, like InnoDB, utf8mb4, utf8mb4_unicode_ci and DYNAMIC
+	Engine    string
+	Charset   string
+	Collation string
+	RowFormat string
+	Comment   string
+	// Tablespace and Unlogged are PostgreSQL options
+	Tablespace string
+	Unlogged   bool
 }
 
 // Column represents a column in a table
@@ -32,6 +103,32 @@ type Column struct {
 	Collation     string
 	PrimaryKey    bool
 	AutoIncrement bool
+	JSONName      string
+	Omit          bool
+	// DefaultExpr is a SQL expression, like `UUID()`, used as the default value instead of Default
+	DefaultExpr string
+	// Generated makes the column a computed column, which can't be written to
+	Generated *Generated
+	// AutoNowAdd columns are set to the current time when a row is inserted
+	AutoNowAdd bool
+	// AutoNow columns are set to the current time whenever a row is inserted or updated
+	AutoNow bool
+	// GoType is a custom Go type, like `github.com/google/uuid.UUID`, which must implement sql.Scanner and driver.Valuer
+	GoType string
+	// UUIDStorage is how a UUID column is stored. It is set on every UUID column from the Database's UUIDStorage.
+	UUIDStorage string
+	// Comment is stored in the database catalogue and used as the doc comment of the entity's field
+	Comment string
+	// Position is where the column is declared, if it was unmarshalled from YAML. Columns which yoyo adds, like the
+	// version and timestamp columns, have none.
+	Position Position
+}
+
+// Generated represents the expression of a generated (computed) column
+type Generated struct {
+	Expr string
+	// Stored columns are computed on write and stored, otherwise they're virtual and computed on read
+	Stored bool
 }
 
 // Reference represents a relationship between tables.
@@ -45,6 +142,9 @@ type Reference struct {
 	ColumnNames []string
 	OnDelete    string
 	OnUpdate    string
+	// ConstraintName is the name of the foreign key constraint, set from the Database's Naming. If it's empty, the
+	// dialect's default name is used.
+	ConstraintName string
 }
 
 // Index represents a simple index on a column or columns
@@ -52,4 +152,34 @@ type Index struct {
 	Name    string
 	Columns []string
 	Unique  bool
+	// Type is the kind of index, one of the IndexType constants. An empty Type is the dialect's default kind of index.
+	Type string
+
+	// unnamed indices didn't set their own Name, so it's generated by the Database's Naming
+	unnamed bool
+}
+
+// These are the kinds of index other than the default
+const (
+	// IndexTypeSpatial indexes spatial columns, a SPATIAL INDEX in MySQL or a GiST index in PostgreSQL
+	IndexTypeSpatial = "spatial"
+)
+
+// Check represents a named CHECK constraint with a SQL expression which every row must satisfy
+type Check struct {
+	Name string
+	Expr string
+
+	// unnamed checks didn't set their own Name, so it's generated by the Database's Naming
+	unnamed bool
+}
+
+// Unique represents a named UNIQUE constraint on a column or columns. Unlike a unique Index, it's declared as a table
+// constraint.
+type Unique struct {
+	Name    string
+	Columns []string
+
+	// unnamed uniques didn't set their own Name, so it's generated by the Database's Naming
+	unnamed bool
 }
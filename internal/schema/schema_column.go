@@ -2,36 +2,67 @@ package schema
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+)
+
+var enumValueSplitter = regexp.MustCompile("[^a-zA-Z\\d]+")
+var goTypeMatcher = regexp.MustCompile("^([\\w./-]+\\.)?[a-zA-Z_]\\w*$")
+
+// majorVersion, versionSuffix and nonIdentifier find the parts of an import path element which aren't in the package name
+var (
+	majorVersion  = regexp.MustCompile(`^v\d+$`)
+	versionSuffix = regexp.MustCompile(`\.v\d+$`)
+	nonIdentifier = regexp.MustCompile(`\W`)
 )
 
-// ExportedGoName returns the string that will be used for naming Exported types, functions, etc in generated Go code
-func (c *Column) ExportedGoName() string {
+// ExportedGoName returns the string that will be used for naming Exported types, functions, etc in generated Go code,
+// with the words which are in is upper-cased
+func (c *Column) ExportedGoName(is Initialisms) string {
 	if c.GoName != "" {
-		return pascal(c.GoName)
+		return pascal(c.GoName, is)
 	}
 
-	return pascal(c.Name)
+	return pascal(c.Name, is)
 }
 
-// GoTypeString returns the string keyword of the column type's corresponding Go type
-func (c *Column) GoTypeString() string {
+// GoTypeString returns the string keyword of the column type's corresponding Go type. The initialisms are used for the
+// names of enum types.
+func (c *Column) GoTypeString(is Initialisms) string {
 	var s string
-	if c.Nullable {
+	switch {
+	case c.HasJSONGoType():
+		s = fmt.Sprintf("nullable.JSONOf[%s]", c.customGoType())
+	case c.GoType != "":
+		s = c.customGoType()
+	case c.IsEnum() && c.Nullable:
+		s = "Null" + c.EnumTypeName(is)
+	case c.IsEnum():
+		s = c.EnumTypeName(is)
+	case c.Nullable:
 		s = c.Datatype.GoNullableTypeString()
-	} else {
+	default:
 		s = c.Datatype.GoTypeString()
 		if c.Unsigned && c.Datatype.IsSignable() && c.Datatype.HasGoUnsigned() {
 			s = fmt.Sprintf("u%s", s)
 		}
 	}
 
-
 	return s
 }
 
 // BaseType works like GoTypeString but doesn't care about nullable types
-func (c *Column) BaseType() string {
+func (c *Column) BaseType(is Initialisms) string {
+	if c.GoType != "" {
+		return c.customGoType()
+	}
+
+	if c.IsEnum() {
+		return c.EnumTypeName(is)
+	}
+
 	s := c.Datatype.GoTypeString()
 	if c.Unsigned && c.Datatype.IsSignable() && c.Datatype.HasGoUnsigned() {
 		s = fmt.Sprintf("u%s", s)
@@ -41,13 +72,151 @@ func (c *Column) BaseType() string {
 
 // RequiredImport returns any packages that need to be imported to support the Go type of a column in generated  Go code
 func (c *Column) RequiredImport(nullPath string) string {
-	if c.Datatype.IsTime() && !c.Nullable {
+	if c.HasJSONGoType() {
+		// The import of the custom type itself is given by BaseTypeImport
+		return `"` + nullPath + `"`
+	}
+
+	if c.GoType != "" {
+		return c.BaseTypeImport()
+	}
+
+	if c.Datatype.IsTime() && !c.Nullable || c.IsPGType() && c.Datatype.Element().IsTime() {
 		return `"time"`
 	}
 
-	if c.Nullable && strings.HasPrefix(c.Datatype.GoNullableTypeString(), "nullable") {
+	if c.Datatype.IsJSON() && !c.Nullable {
+		return `"encoding/json"`
+	}
+
+	if c.Nullable && !c.IsEnum() && strings.HasPrefix(c.Datatype.GoNullableTypeString(), "nullable") {
 		return `"` + nullPath + `"`
 	}
 
 	return ""
 }
+
+// BaseTypeImport returns the package, if any, that needs to be imported to support the BaseType of a column in
+// generated Go code
+func (c *Column) BaseTypeImport() string {
+	if c.GoType != "" {
+		path, name := c.goTypePackage()
+		if path == "" {
+			return ""
+		}
+		if name != path[strings.LastIndex(path, "/")+1:] {
+			// the package isn't named like the last element of its path, so it's imported by the name it's used with
+			return name + ` "` + path + `"`
+		}
+		return `"` + path + `"`
+	}
+
+	if c.Datatype.IsTime() || c.IsPGType() && c.Datatype.Element().IsTime() {
+		return `"time"`
+	}
+
+	if c.Datatype.IsJSON() {
+		return `"encoding/json"`
+	}
+
+	return ""
+}
+
+// IsGoSlice returns true if the column's Go type is a slice, which can't be compared with ==
+func (c *Column) IsGoSlice() bool {
+	return (c.Datatype.IsBinary() || c.Datatype.IsJSON() || c.Datatype.IsArray()) && c.GoType == ""
+}
+
+// HasJSONGoType returns true if the column is a JSON column with a custom GoType. The custom type is wrapped in a
+// nullable.JSONOf, which marshals it to and from the JSON stored in the column.
+func (c *Column) HasJSONGoType() bool {
+	return c.Datatype.IsJSON() && c.GoType != ""
+}
+
+// IsEnum returns true if the column is an ENUM column, which gets its own named type in generated Go code.
+// ENUM columns with a custom GoType use that type instead.
+func (c *Column) IsEnum() bool {
+	return c.Datatype == datatype.Enum && c.GoType == ""
+}
+
+// IsUUID returns true if the column is a UUID column, which uses the types of the generated uuid package in Go code.
+// UUID columns with a custom GoType use that type instead.
+func (c *Column) IsUUID() bool {
+	return c.Datatype == datatype.UUID && c.GoType == ""
+}
+
+// IsPGType returns true if the column is an array or range column, which uses the types of the generated pg package in
+// Go code. These types are null by themselves, so they're never wrapped in a nullable type. Array and range columns
+// with a custom GoType use that type instead.
+func (c *Column) IsPGType() bool {
+	return (c.Datatype.IsArray() || c.Datatype.IsRange()) && c.GoType == ""
+}
+
+// SRID returns the spatial reference system identifier of a spatial column, or an empty string if it has none
+func (c *Column) SRID() string {
+	if !c.Datatype.IsSpatial() || len(c.Params) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(c.Params[0])
+}
+
+// IsGeo returns true if the column is a spatial column, which uses the geo.Shape type of the generated geo package in
+// Go code. Like the pg types, a geo.Shape is null by itself. Spatial columns with a custom GoType use that type instead.
+func (c *Column) IsGeo() bool {
+	return c.Datatype.IsSpatial() && c.GoType == ""
+}
+
+// customGoType returns the package-qualified name of the column's GoType, e.g. `uuid.UUID` for
+// `github.com/google/uuid.UUID`
+func (c *Column) customGoType() string {
+	_, name := c.goTypePackage()
+	if name == "" {
+		return c.GoType
+	}
+
+	return name + c.GoType[strings.LastIndex(c.GoType, "."):]
+}
+
+// goTypePackage returns the import path of the column's GoType, and the name the package is used with. Major version
+// suffixes, like the `/v2` of `github.com/acme/foo/v2` or the `.v3` of `gopkg.in/yaml.v3`, aren't part of the name, and
+// other characters which can't be in an identifier are replaced with underscores. Builtin types have neither.
+func (c *Column) goTypePackage() (path, name string) {
+	i := strings.LastIndex(c.GoType, ".")
+	if i < 0 {
+		return "", ""
+	}
+
+	path = c.GoType[:i]
+	elems := strings.Split(path, "/")
+	name = elems[len(elems)-1]
+	if len(elems) > 1 && majorVersion.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	name = versionSuffix.ReplaceAllString(name, "")
+
+	return path, nonIdentifier.ReplaceAllString(name, "_")
+}
+
+// EnumTypeName returns the name of the Go type generated for an ENUM column. The type is declared in the query package
+// of the column's table.
+func (c *Column) EnumTypeName(is Initialisms) string {
+	return c.ExportedGoName(is) + "Enum"
+}
+
+// EnumValues returns the allowed values of an ENUM column, with any SQL quoting removed
+func (c *Column) EnumValues() (vals []string) {
+	for _, p := range c.Params {
+		vals = append(vals, strings.Trim(strings.TrimSpace(p), `'"`))
+	}
+	return vals
+}
+
+// EnumConstName returns the name of the Go constant generated for the given value of an ENUM column
+func (c *Column) EnumConstName(is Initialisms, val string) string {
+	ss := enumValueSplitter.Split(val, -1)
+	for i := range ss {
+		ss[i] = title(ss[i])
+	}
+
+	return c.EnumTypeName(is) + strings.Join(ss, "")
+}
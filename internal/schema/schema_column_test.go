@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/yoyo-project/yoyo/internal/datatype"
@@ -45,7 +46,7 @@ func TestColumn_ExportedGoName(t *testing.T) {
 				Name:   tt.fields.Name,
 				GoName: tt.fields.GoName,
 			}
-			if got := c.ExportedGoName(); got != tt.want {
+			if got := c.ExportedGoName(nil); got != tt.want {
 				t.Errorf("ExportedGoName() = %v, want %v", got, tt.want)
 			}
 		})
@@ -56,6 +57,8 @@ func TestColumn_GoTypeString(t *testing.T) {
 	type fields struct {
 		Datatype datatype.Datatype
 		Unsigned bool
+		Nullable bool
+		GoType   string
 	}
 	tests := []struct {
 		name   string
@@ -72,14 +75,82 @@ func TestColumn_GoTypeString(t *testing.T) {
 			fields: fields{Datatype: datatype.Integer},
 			want:   "uint32",
 		},
+		{
+			name:   "enum",
+			fields: fields{Datatype: datatype.Enum},
+			want:   "ColorEnum",
+		},
+		{
+			name:   "nullable enum",
+			fields: fields{Datatype: datatype.Enum, Nullable: true},
+			want:   "NullColorEnum",
+		},
+		{
+			name:   "custom go type",
+			fields: fields{Datatype: datatype.Binary, GoType: "github.com/google/uuid.UUID"},
+			want:   "uuid.UUID",
+		},
+		{
+			name:   "nullable custom go type",
+			fields: fields{Datatype: datatype.Decimal, Nullable: true, GoType: "github.com/shopspring/decimal.NullDecimal"},
+			want:   "decimal.NullDecimal",
+		},
+		{
+			name:   "custom go type from a major version path",
+			fields: fields{Datatype: datatype.Binary, GoType: "github.com/acme/foo/v2.Type"},
+			want:   "foo.Type",
+		},
+		{
+			name:   "custom go type from a gopkg.in path",
+			fields: fields{Datatype: datatype.JSON, GoType: "gopkg.in/yaml.v3.Node"},
+			want:   "nullable.JSONOf[yaml.Node]",
+		},
+		{
+			name:   "custom go type from a path with a dash",
+			fields: fields{Datatype: datatype.Binary, GoType: "github.com/acme/go-money.Money"},
+			want:   "go_money.Money",
+		},
+		{
+			name:   "custom enum go type",
+			fields: fields{Datatype: datatype.Enum, GoType: "string"},
+			want:   "string",
+		},
+		{
+			name:   "json",
+			fields: fields{Datatype: datatype.JSON},
+			want:   "json.RawMessage",
+		},
+		{
+			name:   "nullable json",
+			fields: fields{Datatype: datatype.JSONB, Nullable: true},
+			want:   "nullable.JSON",
+		},
+		{
+			name:   "json custom go type",
+			fields: fields{Datatype: datatype.JSON, GoType: "github.com/acme/settings.Settings"},
+			want:   "nullable.JSONOf[settings.Settings]",
+		},
+		{
+			name:   "nullable array",
+			fields: fields{Datatype: datatype.ArrayOf(datatype.Text), Nullable: true},
+			want:   "pg.Array[string]",
+		},
+		{
+			name:   "range",
+			fields: fields{Datatype: datatype.Int4Range},
+			want:   "pg.Range[int32]",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Column{
+				Name:     "color",
 				Datatype: tt.fields.Datatype,
 				Unsigned: tt.fields.Unsigned,
+				Nullable: tt.fields.Nullable,
+				GoType:   tt.fields.GoType,
 			}
-			if got := c.GoTypeString(); got != tt.want {
+			if got := c.GoTypeString(nil); got != tt.want {
 				t.Errorf("GoTypeString() = %v, want %v", got, tt.want)
 			}
 		})
@@ -90,6 +161,7 @@ func TestColumn_RequiredImport(t *testing.T) {
 	type fields struct {
 		Datatype datatype.Datatype
 		Nullable bool
+		GoType   string
 	}
 	tests := []struct {
 		name   string
@@ -111,12 +183,63 @@ func TestColumn_RequiredImport(t *testing.T) {
 			fields: fields{Datatype: datatype.Date},
 			want:   `"time"`,
 		},
+		{
+			name:   "custom go type",
+			fields: fields{Datatype: datatype.Binary, GoType: "github.com/google/uuid.UUID"},
+			want:   `"github.com/google/uuid"`,
+		},
+		{
+			name:   "custom go type from a major version path",
+			fields: fields{Datatype: datatype.Binary, GoType: "github.com/acme/foo/v2.Type"},
+			want:   `foo "github.com/acme/foo/v2"`,
+		},
+		{
+			name:   "custom go type from a gopkg.in path",
+			fields: fields{Datatype: datatype.Binary, GoType: "gopkg.in/yaml.v3.Node"},
+			want:   `yaml "gopkg.in/yaml.v3"`,
+		},
+		{
+			name:   "custom go type from a path with a dash",
+			fields: fields{Datatype: datatype.Binary, GoType: "github.com/acme/go-money.Money"},
+			want:   `go_money "github.com/acme/go-money"`,
+		},
+		{
+			name:   "builtin custom go type",
+			fields: fields{Datatype: datatype.Enum, GoType: "string"},
+			want:   "",
+		},
+		{
+			name:   "json",
+			fields: fields{Datatype: datatype.JSON},
+			want:   `"encoding/json"`,
+		},
+		{
+			name:   "nullable json",
+			fields: fields{Datatype: datatype.JSON, Nullable: true},
+			want:   `"nullable"`,
+		},
+		{
+			name:   "json custom go type",
+			fields: fields{Datatype: datatype.JSON, GoType: "github.com/acme/settings.Settings"},
+			want:   `"nullable"`,
+		},
+		{
+			name:   "nullable array",
+			fields: fields{Datatype: datatype.ArrayOf(datatype.Integer), Nullable: true},
+			want:   "",
+		},
+		{
+			name:   "timestamp range",
+			fields: fields{Datatype: datatype.TsTzRange, Nullable: true},
+			want:   `"time"`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Column{
 				Datatype: tt.fields.Datatype,
 				Nullable: tt.fields.Nullable,
+				GoType:   tt.fields.GoType,
 			}
 			if got := c.RequiredImport("nullable"); got != tt.want {
 				t.Errorf("RequiredImport() = %v, want %v", got, tt.want)
@@ -124,3 +247,71 @@ func TestColumn_RequiredImport(t *testing.T) {
 		})
 	}
 }
+
+func TestColumn_EnumValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []string
+		want   []string
+	}{
+		{
+			name:   "single quoted",
+			params: []string{"'blue'", "'red'"},
+			want:   []string{"blue", "red"},
+		},
+		{
+			name:   "double quoted",
+			params: []string{`"blue"`, `"red"`},
+			want:   []string{"blue", "red"},
+		},
+		{
+			name: "no params",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Column{
+				Datatype: datatype.Enum,
+				Params:   tt.params,
+			}
+			if got := c.EnumValues(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnumValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumn_EnumConstName(t *testing.T) {
+	tests := []struct {
+		name string
+		val  string
+		want string
+	}{
+		{
+			name: "simple",
+			val:  "blue",
+			want: "FavoriteColorEnumBlue",
+		},
+		{
+			name: "with spaces and punctuation",
+			val:  "light-blue or teal",
+			want: "FavoriteColorEnumLightBlueOrTeal",
+		},
+		{
+			name: "leading digit",
+			val:  "1st",
+			want: "FavoriteColorEnum1st",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Column{
+				Name:     "favorite_color",
+				Datatype: datatype.Enum,
+			}
+			if got := c.EnumConstName(nil, tt.val); got != tt.want {
+				t.Errorf("EnumConstName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package schema
 
+import (
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
+)
+
 // GetTable returns a table matching the given name if present. If a matching table is found, the returned bool is true.
 // If a matching table is not found, the returned bool is false.
 func (db *Database) GetTable(name string) (Table, bool) {
@@ -10,3 +14,80 @@ func (db *Database) GetTable(name string) (Table, bool) {
 	}
 	return Table{}, false
 }
+
+// GetView returns a view matching the given name if present. If a matching view is found, the returned bool is true.
+// If a matching view is not found, the returned bool is false.
+func (db *Database) GetView(name string) (View, bool) {
+	for _, v := range db.Views {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return View{}, false
+}
+
+// EffectiveUUIDStorage returns the UUIDStorage of the Database, or the default for its dialect if none is set.
+// PostgreSQL has a native UUID type, other dialects store UUIDs as BINARY(16).
+func (db *Database) EffectiveUUIDStorage() string {
+	switch {
+	case db.UUIDStorage != "":
+		return db.UUIDStorage
+	case db.Dialect == dialect.PostgreSQL:
+		return UUIDStorageNative
+	default:
+		return UUIDStorageBinary
+	}
+}
+
+// Initialisms returns the initialisms of the Database's Naming, to pass to the Go name methods of its tables, columns
+// and references
+func (db *Database) Initialisms() Initialisms {
+	return db.Naming.initialisms()
+}
+
+// allTables returns the tables of the Database followed by the tables of its views. Their Columns share the backing
+// arrays of the originals, so columns can be modified through them.
+func (db *Database) allTables() []Table {
+	ts := make([]Table, 0, len(db.Tables)+len(db.Views))
+	ts = append(ts, db.Tables...)
+	for _, v := range db.Views {
+		ts = append(ts, v.Table)
+	}
+	return ts
+}
+
+// HasPGTypeColumns returns true if any table or view has an array or range column using the generated pg package's types
+func (db *Database) HasPGTypeColumns() bool {
+	for _, t := range db.allTables() {
+		for _, c := range t.Columns {
+			if c.IsPGType() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasGeoColumns returns true if any table or view has a spatial column using the generated geo package's types
+func (db *Database) HasGeoColumns() bool {
+	for _, t := range db.allTables() {
+		for _, c := range t.Columns {
+			if c.IsGeo() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasUUIDColumns returns true if any table or view has a UUID column using the generated uuid package's types
+func (db *Database) HasUUIDColumns() bool {
+	for _, t := range db.allTables() {
+		for _, c := range t.Columns {
+			if c.IsUUID() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -71,3 +71,34 @@ func TestDatabase_GetTable(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabase_EffectiveUUIDStorage(t *testing.T) {
+	tests := []struct {
+		name string
+		db   Database
+		want string
+	}{
+		{
+			name: "mysql default",
+			db:   Database{Dialect: "mysql"},
+			want: UUIDStorageBinary,
+		},
+		{
+			name: "postgresql default",
+			db:   Database{Dialect: "postgresql"},
+			want: UUIDStorageNative,
+		},
+		{
+			name: "configured",
+			db:   Database{Dialect: "mysql", UUIDStorage: UUIDStorageChar},
+			want: UUIDStorageChar,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.db.EffectiveUUIDStorage(); got != tt.want {
+				t.Errorf("EffectiveUUIDStorage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
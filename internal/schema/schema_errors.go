@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is where a part of the schema is declared. An empty File is yoyo.yml itself.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	file := p.File
+	if file == "" {
+		file = "yoyo.yml"
+	}
+	return fmt.Sprintf("%s:%d:%d", file, p.Line, p.Column)
+}
+
+// ValidationError is a single problem with the schema, at the position of the YAML it was found in
+type ValidationError struct {
+	Position
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors are all the problems found in a schema, one per line
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	ss := make([]string, len(es))
+	for i, e := range es {
+		ss[i] = e.Error()
+	}
+	return strings.Join(ss, "\n")
+}
+
+// problems collects ValidationErrors while unmarshalling and validating, so that every problem is reported at once
+// instead of only the first
+type problems struct {
+	// file is the included file being read, or empty for yoyo.yml
+	file string
+	errs ValidationErrors
+}
+
+// add records err at the position of n. Errors which already are ValidationErrors keep their own positions.
+func (p *problems) add(n *yaml.Node, err error) {
+	if err == nil {
+		return
+	}
+
+	var (
+		es ValidationErrors
+		e  *ValidationError
+	)
+	switch {
+	case errors.As(err, &es):
+		for _, e := range es {
+			p.addError(e)
+		}
+	case errors.As(err, &e):
+		p.addError(e)
+	default:
+		var pos Position
+		if n != nil {
+			pos.Line, pos.Column = n.Line, n.Column
+		}
+		p.addError(&ValidationError{Position: pos, Err: err})
+	}
+}
+
+// addAt records err at the given position
+func (p *problems) addAt(pos Position, err error) {
+	p.addError(&ValidationError{Position: pos, Err: err})
+}
+
+func (p *problems) addError(e *ValidationError) {
+	if e.File == "" {
+		e.File = p.file
+	}
+	p.errs = append(p.errs, e)
+}
+
+// err returns the collected ValidationErrors, or nil if there are none
+func (p *problems) err() error {
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return p.errs
+}
+
+// parts maps the parts of a table or view, like `column:id` or `index:idx_name`, to the YAML nodes they're declared at,
+// so that validation errors can be reported at their position
+type parts struct {
+	self  *yaml.Node
+	nodes map[string]*yaml.Node
+}
+
+func newParts(self *yaml.Node) parts {
+	return parts{self: self, nodes: make(map[string]*yaml.Node)}
+}
+
+// at returns the node of the given part, or of the table or view itself if the part wasn't declared in YAML
+func (ps parts) at(part string) *yaml.Node {
+	if n, ok := ps.nodes[part]; ok {
+		return n
+	}
+	return ps.self
+}
+
+// positions are where the tables, views and options of a Database are declared. Tables and views are in the same order
+// as in the Database.
+type positions struct {
+	tables  []Position
+	views   []Position
+	options map[string]Position
+	// invalid are the names of tables and views which were left out of the Database because of their problems
+	invalid map[string]bool
+}
+
+// positionsOf returns the positions of the Database, creating them if it has none yet
+func (db *Database) positionsOf() *positions {
+	if db.positions == nil {
+		db.positions = &positions{options: make(map[string]Position), invalid: make(map[string]bool)}
+	}
+	return db.positions
+}
+
+// position returns the Position of n in the given file
+func position(file string, n *yaml.Node) Position {
+	return Position{File: file, Line: n.Line, Column: n.Column}
+}
@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDatabase_UnmarshalYAML_validationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yml  string
+		want []string
+	}{
+		{
+			name: "valid",
+			yml: `
+dialect: mysql
+tables:
+  person:
+    columns:
+      id:
+        type: int
+        primary_key: true`,
+		},
+		{
+			name: "every problem of a table",
+			yml: `
+dialect: mysql
+tables:
+  person:
+    columns:
+      id:
+        type: nope
+      created:
+        type: int
+        auto_now_add: true
+    indices:
+      - name: idx_missing
+        columns: [missing]`,
+			want: []string{
+				"yoyo.yml:6:7: column 'id' validation error: unable to unmarshal column: invalid datatype",
+				"yoyo.yml:8:7: column 'created' validation error: auto_now_add or auto_now is set but 'INTEGER' is not a DATETIME or TIMESTAMP",
+				"yoyo.yml:12:9: index 'idx_missing' validation error: column 'missing' referenced but doesn't exist in table def",
+			},
+		},
+		{
+			name: "problems across tables",
+			yml: `
+dialect: mysql
+tables:
+  person:
+    columns:
+      id:
+        type: int
+    references:
+      city:
+        has_one: true
+  place:
+    columns:
+      id:
+        type: nope
+    references:
+      person:
+        has_one: true`,
+			want: []string{
+				"yoyo.yml:13:7: column 'id' validation error: unable to unmarshal column: invalid datatype",
+				"yoyo.yml:4:3: reference table `city` does not exist in schema yaml",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := yaml.Unmarshal([]byte(tt.yml), &Database{})
+
+			var got []string
+			var es ValidationErrors
+			if errors.As(err, &es) {
+				for _, e := range es {
+					got = append(got, e.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("UnmarshalYAML() error = %v, want ValidationErrors", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalYAML() errors =\n%q,\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabase_UnmarshalYAML_positions(t *testing.T) {
+	db := Database{}
+	err := yaml.Unmarshal([]byte(`
+dialect: mysql
+include:
+  - people.yml
+tables:
+  city:
+    columns:
+      id:
+        type: int
+        primary_key: true
+views:
+  big_city:
+    definition: SELECT id FROM city
+    columns:
+      id:
+        type: int`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.LoadIncludes(fstest.MapFS{"people.yml": {Data: []byte(`
+tables:
+  person:
+    timestamps: true
+    columns:
+      id:
+        type: int
+        primary_key: true`)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	city, _ := db.GetTable("city")
+	person, _ := db.GetTable("person")
+	bigCity, _ := db.GetView("big_city")
+	tests := []struct {
+		name      string
+		got, want Position
+	}{
+		{name: "table", got: city.Position, want: Position{Line: 6, Column: 3}},
+		{name: "column", got: city.Columns[0].Position, want: Position{Line: 8, Column: 7}},
+		{name: "view", got: bigCity.Position, want: Position{Line: 12, Column: 3}},
+		{name: "view column", got: bigCity.Columns[0].Position, want: Position{Line: 15, Column: 7}},
+		{name: "included table", got: person.Position, want: Position{File: "people.yml", Line: 3, Column: 3}},
+		{name: "included column", got: person.Columns[0].Position, want: Position{File: "people.yml", Line: 6, Column: 7}},
+		{name: "added column", got: person.Columns[1].Position},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s Position = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
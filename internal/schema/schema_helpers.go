@@ -1,17 +1,49 @@
 package schema
 
 import (
+	"fmt"
+	"hash/fnv"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 var splitter = regexp.MustCompile("[-_]")
 
-func pascal(in string) string {
+// ShortenName returns name if it has at most limit characters. Otherwise, it's truncated and ends with a hash of the
+// whole name instead, so that long names which only differ at the end stay unique. Characters are runes, like MySQL
+// counts them, so multi-byte characters are never split.
+func ShortenName(name string, limit int) string {
+	if utf8.RuneCountInString(name) <= limit {
+		return name
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	suffix := fmt.Sprintf("_%08x", h.Sum32())
+	return string([]rune(name)[:limit-len(suffix)]) + suffix
+}
+
+// pascal converts a snake or kebab case name to PascalCase, with the words which are initialisms upper-cased
+func pascal(in string, is Initialisms) string {
 	ss := splitter.Split(in, -1)
 	for i := range ss {
-		ss[i] = strings.Title(ss[i])
+		if u := strings.ToUpper(ss[i]); is[u] {
+			ss[i] = u
+		} else {
+			ss[i] = title(ss[i])
+		}
 	}
 
 	return strings.Join(ss, "")
 }
+
+// title returns s with its first rune upper-cased
+func title(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestShortenName(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  string
+	}{
+		{
+			name:  "short",
+			limit: 64,
+			want:  "short",
+		},
+		{
+			name:  "exactly_the_limit",
+			limit: 17,
+			want:  "exactly_the_limit",
+		},
+		{
+			name:  "reference_a_table_with_a_rather_long_descriptive_name_another_table_with_a_long_name_id",
+			limit: 64,
+			want:  "reference_a_table_with_a_rather_long_descriptive_name_a_31257cc0",
+		},
+		{
+			name:  "reference_a_table_with_a_rather_long_descriptive_name_another_table_with_a_long_name_id2",
+			limit: 64,
+			want:  "reference_a_table_with_a_rather_long_descriptive_name_a_5003b0f6",
+		},
+		{
+			name:  "exactly_the_limit_ünïcødé",
+			limit: 25,
+			want:  "exactly_the_limit_ünïcødé",
+		},
+		{
+			name:  "référence_à_une_table_au_nom_très_long",
+			limit: 32,
+			want:  "référence_à_une_table_a_1b9e6bd4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ShortenName(tt.name, tt.limit)
+			if got != tt.want {
+				t.Errorf("ShortenName() = %s, want %s", got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > tt.limit || !utf8.ValidString(got) {
+				t.Errorf("ShortenName() is %d characters, want at most %d valid UTF-8 characters", n, tt.limit)
+			}
+		})
+	}
+}
+
+func Test_title(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "name", want: "Name"},
+		{in: "someBinary", want: "SomeBinary"},
+		{in: "2fa", want: "2fa"},
+		{in: "éclair", want: "Éclair"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := title(tt.in); got != tt.want {
+				t.Errorf("title() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
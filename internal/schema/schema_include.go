@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadIncludes merges the tables and views of every file matching the Database's Include patterns into it, in the
+// order of the patterns and then of the file names, and finishes the database like UnmarshalYAML does for a database
+// without includes. Patterns are matched against fsys, which should be rooted at the directory of yoyo.yml. Problems
+// with the schema are returned as ValidationErrors, including those found in yoyo.yml itself.
+func (db *Database) LoadIncludes(fsys fs.FS) error {
+	for _, pattern := range db.Include {
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return fmt.Errorf("invalid include pattern `%s`: %w", pattern, err)
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("include pattern `%s` does not match any files", pattern)
+		}
+
+		for _, file := range files {
+			if err = db.loadInclude(fsys, file, &problems{file: file}); err != nil {
+				return fmt.Errorf("unable to include schema file: %w", err)
+			}
+		}
+	}
+
+	return db.finish()
+}
+
+// loadInclude merges the tables and views of a single included file into the Database, and adds their problems to
+// db.pending. It only returns an error if the file can't be read at all.
+func (db *Database) loadInclude(fsys fs.FS, file string, p *problems) error {
+	b, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		// an empty file contributes nothing
+		return nil
+	}
+
+	value := doc.Content[0]
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s:%d: must be a mapping of tables and views", file, value.Line)
+	}
+
+	for i := 0; i < len(value.Content); i += 2 {
+		n := value.Content[i]
+		switch n.Value {
+		case "tables":
+			db.unmarshalTables(value.Content[i+1], p)
+		case "views":
+			db.unmarshalViews(value.Content[i+1], p)
+		default:
+			p.add(n, fmt.Errorf("`%s` can't be set in included files, only tables and views", n.Value))
+			continue
+		}
+
+		if db.Strict {
+			p.checkKeys(value.Content[i+1], IncludeJSONSchema().Properties[n.Value])
+		}
+	}
+
+	db.pending = append(db.pending, p.errs...)
+	return nil
+}
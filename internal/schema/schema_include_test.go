@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDatabase_LoadIncludes(t *testing.T) {
+	const person = `
+tables:
+  person:
+    columns:
+      id:
+        type: int
+        primary_key: true
+    references:
+      city:
+        has_one: true`
+	const city = `
+tables:
+  city:
+    columns:
+      id:
+        type: int
+        primary_key: true`
+
+	tests := []struct {
+		name       string
+		yml        string
+		files      fstest.MapFS
+		wantTables []string
+		wantErr    string
+	}{
+		{
+			name: "references between files",
+			yml: `
+dialect: mysql
+include:
+  - schema/*.yml`,
+			files: fstest.MapFS{
+				"schema/people.yml": {Data: []byte(person)},
+				"schema/places.yml": {Data: []byte(city)},
+			},
+			wantTables: []string{"person", "city"},
+		},
+		{
+			name: "tables of yoyo.yml come first",
+			yml: `
+dialect: mysql
+include:
+  - people.yml
+tables:
+  city:
+    columns:
+      id:
+        type: int
+        primary_key: true`,
+			files: fstest.MapFS{
+				"people.yml": {Data: []byte(person)},
+			},
+			wantTables: []string{"city", "person"},
+		},
+		{
+			name: "empty file",
+			yml: `
+dialect: mysql
+include:
+  - empty.yml`,
+			files: fstest.MapFS{
+				"empty.yml": {Data: []byte("")},
+			},
+		},
+		{
+			name: "no matching files",
+			yml: `
+dialect: mysql
+include:
+  - schema/*.yml`,
+			files:   fstest.MapFS{},
+			wantErr: "include pattern `schema/*.yml` does not match any files",
+		},
+		{
+			name: "missing reference",
+			yml: `
+dialect: mysql
+include:
+  - people.yml`,
+			files: fstest.MapFS{
+				"people.yml": {Data: []byte(person)},
+			},
+			wantErr: "people.yml:3:3: reference table `city` does not exist in schema yaml",
+		},
+		{
+			name: "invalid table",
+			yml: `
+dialect: mysql
+include:
+  - people.yml`,
+			files: fstest.MapFS{
+				"people.yml": {Data: []byte("tables:\n  person:\n    columns:\n      id:\n        type: nope")},
+			},
+			wantErr: "people.yml:4:7: column 'id' validation error",
+		},
+		{
+			name: "duplicate table",
+			yml: `
+dialect: mysql
+include:
+  - a.yml
+  - b.yml`,
+			files: fstest.MapFS{
+				"a.yml": {Data: []byte(city)},
+				"b.yml": {Data: []byte(city)},
+			},
+			wantErr: "b.yml:3:3: duplicate table name 'city'",
+		},
+		{
+			name: "database options",
+			yml: `
+dialect: mysql
+include:
+  - a.yml`,
+			files: fstest.MapFS{
+				"a.yml": {Data: []byte("dialect: postgresql")},
+			},
+			wantErr: "a.yml:1:1: `dialect` can't be set in included files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := Database{}
+			if err := yaml.Unmarshal([]byte(tt.yml), &db); err != nil {
+				t.Fatalf("unexpected error unmarshalling: %s", err)
+			}
+
+			err := db.LoadIncludes(tt.files)
+			if (err != nil) != (tt.wantErr != "") || err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadIncludes() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotTables []string
+			for _, table := range db.Tables {
+				gotTables = append(gotTables, table.Name)
+			}
+			if !reflect.DeepEqual(gotTables, tt.wantTables) {
+				t.Errorf("LoadIncludes() tables = %v, want %v", gotTables, tt.wantTables)
+			}
+		})
+	}
+}
+
+func TestDatabase_UnmarshalYAML_include(t *testing.T) {
+	// a database with includes isn't validated until they're loaded, so this reference doesn't fail yet
+	yml := `
+dialect: mysql
+include:
+  - schema/*.yml
+tables:
+  person:
+    columns:
+      id:
+        type: int
+    references:
+      city:
+        has_one: true`
+
+	db := Database{}
+	if err := yaml.Unmarshal([]byte(yml), &db); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := Database{
+		Dialect: "mysql",
+		Include: []string{"schema/*.yml"},
+		Tables: []Table{{
+			Name:       "person",
+			Columns:    []Column{{Name: "id", Datatype: datatype.Integer, Position: Position{Line: 8, Column: 7}}},
+			References: []Reference{{TableName: "city", HasOne: true}},
+			Position:   Position{Line: 6, Column: 3},
+		}},
+	}
+	// positions are kept until the includes are loaded
+	db.positions = nil
+	if !reflect.DeepEqual(db, want) {
+		t.Errorf("\nWant %#v,\n got %#v", want, db)
+	}
+}
@@ -0,0 +1,280 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
+	"gopkg.in/yaml.v3"
+)
+
+// JSONSchema is the subset of JSON Schema needed to describe yoyo.yml, so that editors can autocomplete and validate it.
+// The same schemas are used by strict mode to find unknown keys.
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Enum        []string               `json:"enum,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	// AdditionalProperties is false for objects with a fixed set of keys, or the *JSONSchema of the values of maps like
+	// tables, which are keyed by name
+	AdditionalProperties any           `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema   `json:"items,omitempty"`
+	AnyOf                []*JSONSchema `json:"anyOf,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+}
+
+// DatabaseJSONSchema returns the JSON Schema of the `schema` section of yoyo.yml, with every key accepted by
+// Database.UnmarshalYAML and the unmarshallers it calls
+func DatabaseJSONSchema() *JSONSchema {
+	return object("The database schema", map[string]*JSONSchema{
+		"dialect":      enum("The SQL dialect of the database", dialect.MySQL, dialect.PostgreSQL, dialect.SQLite),
+		"strict":       boolean("Reject keys which yoyo doesn't know, instead of ignoring them"),
+		"include":      arrayOf("Glob patterns, relative to yoyo.yml, of files with more tables and views", str("")),
+		"go_types":     mapOf("Custom Go types for all columns of a datatype, optionally with params like `binary(16)`", str("")),
+		"uuid_storage": enum("How UUID columns are stored", UUIDStorageNative, UUIDStorageBinary, UUIDStorageBinarySwapped, UUIDStorageChar),
+		"tables":       mapOf("The tables of the database, keyed by name", tableJSONSchema()),
+		"views":        mapOf("The views of the database, keyed by name", viewJSONSchema()),
+		"naming":       namingJSONSchema(),
+	})
+}
+
+// IncludeJSONSchema returns the JSON Schema of a file included by the `include` patterns of the schema
+func IncludeJSONSchema() *JSONSchema {
+	db := DatabaseJSONSchema()
+	return object("Tables and views included into the schema of yoyo.yml", map[string]*JSONSchema{
+		"tables": db.Properties["tables"],
+		"views":  db.Properties["views"],
+	})
+}
+
+func namingJSONSchema() *JSONSchema {
+	return object("The conventions for generated names, as templates with placeholders in braces", map[string]*JSONSchema{
+		"foreign_key": str(fmt.Sprintf("The name of foreign key columns, with {table} and {column}. Defaults to `%s`", DefaultForeignKeyNaming)),
+		"index":       str(fmt.Sprintf("The name of indices, with {table} and {columns}. Defaults to `%s`", DefaultIndexNaming)),
+		"unique":      str(fmt.Sprintf("The name of unique constraints, with {table} and {columns}. Defaults to `%s`", DefaultUniqueNaming)),
+		"check":       str(fmt.Sprintf("The name of CHECK constraints, with {table} and {number}. Defaults to `%s`", DefaultCheckNaming)),
+		"reference":   str(fmt.Sprintf("The name of foreign key constraints, with {table}, {foreign_table} and {columns}. Defaults to `%s`", DefaultReferenceNaming)),
+		"initialisms": arrayOf("Words, like ID and URL, which are upper-cased in Go names", str("")),
+	})
+}
+
+func tableJSONSchema() *JSONSchema {
+	indices := arrayOf("The indices of the table", object("", map[string]*JSONSchema{
+		"name":    str("The name of the index, generated from the table and columns if it's not set"),
+		"columns": arrayOf("The columns of the index", str("")),
+		"unique":  boolean("Only allow one row for each value of the columns"),
+		"type":    str(fmt.Sprintf("The kind of index, `%s` or the dialect's default if it's not set", IndexTypeSpatial)),
+	}, "columns"))
+	uniques := arrayOf("The unique constraints of the table", object("", map[string]*JSONSchema{
+		"name":    str("The name of the constraint, generated from the table and columns if it's not set"),
+		"columns": arrayOf("The columns which must be unique together", str("")),
+	}, "columns"))
+
+	return object("A table", map[string]*JSONSchema{
+		"columns": mapOf("The columns of the table, keyed by name", columnJSONSchema()),
+		"indices": indices,
+		"indexes": alias("indices", indices),
+		"checks": arrayOf("The CHECK constraints of the table", object("", map[string]*JSONSchema{
+			"name": str("The name of the constraint, generated from the table if it's not set"),
+			"expr": str("The SQL expression every row must satisfy"),
+		}, "expr")),
+		"uniques":        uniques,
+		"unique":         alias("uniques", uniques),
+		"references":     mapOf("The tables this table references, keyed by their names", referenceJSONSchema()),
+		"go_name":        str("The name of the table in generated Go code"),
+		"version_column": str("An integer column which is incremented on every update, for optimistic locking"),
+		"soft_delete": anyOf("Mark rows as deleted instead of deleting them, with `true` for a `deleted_at` column or the name of the column",
+			boolean(""), str("")),
+		"timestamps": boolean("Add `created_at` and `updated_at` columns which are set automatically"),
+		"engine":     str("The storage engine of the table (MySQL)"),
+		"charset":    str("The default character set of the table (MySQL)"),
+		"collation":  str("The default collation of the table (MySQL)"),
+		"row_format": str("The row format of the table (MySQL)"),
+		"comment":    str("The comment of the table, used as the doc comment of its entity"),
+		"tablespace": str("The tablespace of the table"),
+		"unlogged":   boolean("Don't write the table to the write-ahead log (PostgreSQL)"),
+	}, "columns")
+}
+
+func viewJSONSchema() *JSONSchema {
+	return object("A read-only view", map[string]*JSONSchema{
+		"definition": str("The SELECT statement of the view"),
+		"columns":    mapOf("The columns the view selects, keyed by name", columnJSONSchema()),
+		"go_name":    str("The name of the view in generated Go code"),
+		"comment":    str("The comment of the view, used as the doc comment of its entity"),
+	}, "definition", "columns")
+}
+
+func columnJSONSchema() *JSONSchema {
+	dt := str("The datatype, with params like `varchar(32)` or `decimal(10,2)`, and `[]` for PostgreSQL arrays")
+	primaryKey := boolean("Make the column part of the primary key")
+
+	return object("A column", map[string]*JSONSchema{
+		"type":           dt,
+		"datatype":       alias("type", dt),
+		"unsigned":       boolean("Only allow positive numbers"),
+		"nullable":       boolean("Allow NULL values"),
+		"default":        anyOf("The default value", str(""), &JSONSchema{Type: "number"}, boolean("")),
+		"default_expr":   str("An SQL expression for the default value, like `CURRENT_TIMESTAMP`"),
+		"charset":        str("The character set of the column"),
+		"collation":      str("The collation of the column"),
+		"primary_key":    primaryKey,
+		"primary":        alias("primary_key", primaryKey),
+		"auto_increment": boolean("Generate values for the column from a sequence"),
+		"go_name":        str("The name of the column in generated Go code"),
+		"json_name":      str("The name of the column in generated json and yaml tags"),
+		"omit":           boolean("Leave the column out of generated entities"),
+		"go_type":        str("A custom Go type, like `github.com/google/uuid.UUID`, which implements sql.Scanner and driver.Valuer"),
+		"auto_now_add":   boolean("Set the column to the current time when a row is inserted"),
+		"auto_now":       boolean("Set the column to the current time whenever a row is inserted or updated"),
+		"comment":        str("The comment of the column, used as the doc comment of its field"),
+		"generated": object("Make the column computed from an expression", map[string]*JSONSchema{
+			"expr":   str("The SQL expression the column is computed from"),
+			"stored": boolean("Compute the column on write and store it, instead of computing it on read"),
+		}, "expr"),
+	})
+}
+
+func referenceJSONSchema() *JSONSchema {
+	columnNames := arrayOf("The names of the foreign key columns, generated from the referenced table if it's not set", str(""))
+	action := str(fmt.Sprintf("The referential action, one of %s, %s, %s, %s or %s", ActionCascade, ActionSetNull, ActionSetDefault, ActionRestrict, ActionNoAction))
+
+	return object("A reference to another table, which adds foreign keys and constraints", map[string]*JSONSchema{
+		"has_one":      boolean("This table has foreign key columns which reference one row of the other table"),
+		"has_many":     boolean("The other table has foreign key columns which reference rows of this table"),
+		"required":     boolean("The foreign key columns can't be NULL"),
+		"column_names": columnNames,
+		"columns":      alias("column_names", columnNames),
+		"on_delete":    action,
+		"on_update":    action,
+		"go_name":      str("The name of the reference in generated Go code"),
+	})
+}
+
+func object(description string, properties map[string]*JSONSchema, required ...string) *JSONSchema {
+	return &JSONSchema{Description: description, Type: "object", Properties: properties, AdditionalProperties: false, Required: required}
+}
+
+func mapOf(description string, values *JSONSchema) *JSONSchema {
+	return &JSONSchema{Description: description, Type: "object", AdditionalProperties: values}
+}
+
+func arrayOf(description string, items *JSONSchema) *JSONSchema {
+	return &JSONSchema{Description: description, Type: "array", Items: items}
+}
+
+func anyOf(description string, schemas ...*JSONSchema) *JSONSchema {
+	return &JSONSchema{Description: description, AnyOf: schemas}
+}
+
+func str(description string) *JSONSchema {
+	return &JSONSchema{Description: description, Type: "string"}
+}
+
+func boolean(description string) *JSONSchema {
+	return &JSONSchema{Description: description, Type: "boolean"}
+}
+
+func enum(description string, values ...string) *JSONSchema {
+	return &JSONSchema{Description: description, Type: "string", Enum: values}
+}
+
+// alias returns a copy of s which is described as an alias of the key name
+func alias(name string, s *JSONSchema) *JSONSchema {
+	a := *s
+	a.Description = fmt.Sprintf("Alias of `%s`", name)
+	return &a
+}
+
+// checkKeys adds a problem for every key of n, and of the nodes in it, which s doesn't allow
+func (p *problems) checkKeys(n *yaml.Node, s *JSONSchema) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	for _, alt := range s.AnyOf {
+		if alt.accepts(n) {
+			p.checkKeys(n, alt)
+			return
+		}
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if ps, ok := s.Properties[k.Value]; ok {
+				p.checkKeys(v, ps)
+				continue
+			}
+			switch ap := s.AdditionalProperties.(type) {
+			case *JSONSchema:
+				p.checkKeys(v, ap)
+			case bool:
+				if !ap {
+					p.add(k, s.unknownKey(k.Value))
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for _, item := range n.Content {
+				p.checkKeys(item, s.Items)
+			}
+		}
+	}
+}
+
+// accepts returns true if the kind of n matches the type of s
+func (s *JSONSchema) accepts(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return s.Type == "object"
+	case yaml.SequenceNode:
+		return s.Type == "array"
+	default:
+		return s.Type != "object" && s.Type != "array"
+	}
+}
+
+// unknownKey returns an error for a key which s doesn't allow, suggesting the closest key it does allow
+func (s *JSONSchema) unknownKey(key string) error {
+	var (
+		closest string
+		best    = 3 // only suggest keys with a few typos
+	)
+	for name := range s.Properties {
+		if d := distance(key, name); d < best || d == best && name < closest {
+			closest, best = name, d
+		}
+	}
+
+	if closest == "" {
+		return fmt.Errorf("unknown key `%s`", key)
+	}
+	return fmt.Errorf("unknown key `%s`, did you mean `%s`?", key, closest)
+}
+
+// distance is the Levenshtein distance between a and b
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
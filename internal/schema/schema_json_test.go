@@ -0,0 +1,316 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDatabase_UnmarshalYAML_strict(t *testing.T) {
+	tests := []struct {
+		name  string
+		yml   string
+		files fstest.MapFS
+		want  []string
+	}{
+		{
+			name: "every known key",
+			yml: `
+dialect: mysql
+strict: true
+uuid_storage: binary
+go_types:
+  int: int64
+tables:
+  city:
+    columns:
+      id:
+        datatype: int
+        primary: true
+        auto_increment: true
+      name:
+        type: varchar(64)
+        nullable: true
+        default: unknown
+        charset: utf8mb4
+        collation: utf8mb4_bin
+        comment: The name
+      area:
+        type: decimal(10,2)
+        generated:
+          expr: 1 + 1
+          stored: true
+    indexes:
+      - name: idx_name
+        columns: [name]
+        unique: true
+    uniques:
+      - columns: [name, area]
+    checks:
+      - expr: area > 0
+    soft_delete: true
+    timestamps: true
+    engine: InnoDB
+  person:
+    columns:
+      id:
+        type: int
+        primary_key: true
+    references:
+      city:
+        has_one: true
+        columns: [fk_city]
+        on_delete: cascade
+views:
+  big_city:
+    definition: SELECT id FROM city
+    columns:
+      id:
+        type: int`,
+		},
+		{
+			name: "unknown keys",
+			yml: `
+dialect: mysql
+strict: true
+tables:
+  person:
+    columns:
+      id:
+        type: int
+        primary_key: true
+        nulable: true
+    indices:
+      - columns: [id]
+        uniq: true
+    references:
+      person:
+        has_one: true
+        on_delet: cascade
+    colour: blue
+foo: bar`,
+			want: []string{
+				"yoyo.yml:10:9: unknown key `nulable`, did you mean `nullable`?",
+				"yoyo.yml:13:9: unknown key `uniq`, did you mean `unique`?",
+				"yoyo.yml:17:9: unknown key `on_delet`, did you mean `on_delete`?",
+				"yoyo.yml:18:5: unknown key `colour`",
+				"yoyo.yml:19:1: unknown key `foo`",
+			},
+		},
+		{
+			name: "unknown keys without strict",
+			yml: `
+dialect: mysql
+tables:
+  person:
+    columns:
+      id:
+        type: int
+        nulable: true`,
+		},
+		{
+			name: "unknown keys in included files",
+			yml: `
+dialect: mysql
+strict: true
+include:
+  - people.yml`,
+			files: fstest.MapFS{
+				"people.yml": {Data: []byte("tables:\n  person:\n    columns:\n      id:\n        type: int\n        nulable: true")},
+			},
+			want: []string{"people.yml:6:9: unknown key `nulable`, did you mean `nullable`?"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := Database{}
+			err := yaml.Unmarshal([]byte(tt.yml), &db)
+			if err == nil && len(db.Include) > 0 {
+				err = db.LoadIncludes(tt.files)
+			}
+
+			var got []string
+			var es ValidationErrors
+			if errors.As(err, &es) {
+				for _, e := range es {
+					got = append(got, e.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("UnmarshalYAML() error = %v, want ValidationErrors", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalYAML() errors =\n%q,\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestJSONSchema_properties checks that the unmarshallers read every property of the JSON schemas, by unmarshalling
+// each property into its type with and without the property set and expecting a difference. The properties of objects
+// which don't have a type of their own, like indices, are checked within the type they're part of.
+func TestJSONSchema_properties(t *testing.T) {
+	targets := []struct {
+		name   string
+		schema *JSONSchema
+		base   map[string]string
+		new    func() any
+	}{
+		{name: "database", schema: DatabaseJSONSchema(), base: map[string]string{"dialect": "mysql"}, new: func() any { return &Database{} }},
+		{name: "table", schema: tableJSONSchema(), base: map[string]string{"columns": "{id: {type: int}}"}, new: func() any { return &Table{} }},
+		{name: "view", schema: viewJSONSchema(), base: map[string]string{"definition": "SELECT 1", "columns": "{id: {type: int}}"}, new: func() any { return &View{} }},
+		// columns have no base type, so that setting the `datatype` alias of `type` changes it
+		{name: "column", schema: columnJSONSchema(), base: map[string]string{}, new: func() any { return &Column{} }},
+		{name: "reference", schema: referenceJSONSchema(), base: map[string]string{"has_one": "true"}, new: func() any { return &Reference{} }},
+	}
+	for _, target := range targets {
+		unmarshal := func(doc string) any {
+			var n yaml.Node
+			if err := yaml.Unmarshal([]byte(doc), &n); err != nil {
+				t.Fatalf("invalid document %s: %s", doc, err)
+			}
+			v := target.new()
+			// some samples are invalid, like indices of missing columns, but they're still unmarshalled
+			_ = n.Content[0].Decode(v)
+			return v
+		}
+		checkProperties(t, target.name, target.schema, target.base, func(inner string) string { return inner }, unmarshal)
+	}
+}
+
+// propertySamples are the values of properties whose generic sample wouldn't set anything
+var propertySamples = map[string]string{
+	"database.tables":  "{x: {columns: {id: {type: int}}}}",
+	"database.views":   "{x: {definition: SELECT 1, columns: {id: {type: int}}}}",
+	"table.columns":    "{x: {type: int}}",
+	"view.columns":     "{x: {type: int}}",
+	"table.references": "{x: {has_one: true}}",
+}
+
+// checkProperties checks every property of s, an object within a document of a target. base are the properties the
+// object always has, and wrap returns the document with the object as its innermost mapping.
+func checkProperties(t *testing.T, path string, s *JSONSchema, base map[string]string, wrap func(string) string, unmarshal func(string) any) {
+	for key, ps := range s.Properties {
+		name := path + "." + key
+		sample, ok := propertySamples[name]
+		if !ok {
+			sample = sampleOf(ps)
+		}
+
+		// objects and arrays of objects without a type of their own are checked as part of this one, and objects are only
+		// checked by their properties
+		inner := ps
+		if ps.Type == "array" && ps.Items != nil {
+			inner = ps.Items
+		}
+		if inner != ps || inner.Properties == nil {
+			without, with := copyMap(base), copyMap(base)
+			delete(without, key)
+			with[key] = sample
+			if reflect.DeepEqual(unmarshal(wrap(flowMapping(without))), unmarshal(wrap(flowMapping(with)))) {
+				t.Errorf("%s is in the JSON schema but setting it to %s doesn't change anything", name, sample)
+			}
+		}
+		if inner.Type != "object" || inner.Properties == nil {
+			continue
+		}
+		innerBase := make(map[string]string)
+		for _, r := range inner.Required {
+			innerBase[r] = sampleOf(inner.Properties[r])
+		}
+		innerWrap := func(m string) string {
+			outer := copyMap(base)
+			if inner == ps {
+				outer[key] = m
+			} else {
+				outer[key] = "[" + m + "]"
+			}
+			return wrap(flowMapping(outer))
+		}
+		checkProperties(t, name, inner, innerBase, innerWrap, unmarshal)
+	}
+}
+
+// sampleOf returns a flow style YAML value which s accepts
+func sampleOf(s *JSONSchema) string {
+	if len(s.AnyOf) > 0 {
+		return sampleOf(s.AnyOf[0])
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[len(s.Enum)-1]
+	}
+
+	switch s.Type {
+	case "boolean":
+		return "true"
+	case "number":
+		return "1"
+	case "array":
+		return "[" + sampleOf(s.Items) + "]"
+	case "object":
+		if values, ok := s.AdditionalProperties.(*JSONSchema); ok {
+			return "{x: " + sampleOf(values) + "}"
+		}
+		m := make(map[string]string)
+		for _, r := range s.Required {
+			m[r] = sampleOf(s.Properties[r])
+		}
+		return flowMapping(m)
+	default:
+		// a valid datatype, so that it's a sample for column types too
+		return "int"
+	}
+}
+
+// flowMapping returns m as a flow style YAML mapping, in the order of its keys
+func flowMapping(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(k + ": " + m[k])
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func Test_distance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"nullable", "nullable", 0},
+		{"nulable", "nullable", 1},
+		{"uniq", "unique", 2},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("distance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
+)
+
+// Naming are the conventions for the names yoyo generates. Each is a template with placeholders in braces, like
+// `{table}`, and the default convention is used for any template which isn't set.
+type Naming struct {
+	// ForeignKey is the name of foreign key columns which a reference doesn't name, with {table} and {column} for the
+	// referenced table and primary key column
+	ForeignKey string `yaml:"foreign_key"`
+	// Index is the name of indices which don't set their own, with {table} and {columns}, joined by dashes
+	Index string
+	// Unique is the name of unique constraints which don't set their own, with {table} and {columns}, joined by dashes
+	Unique string
+	// Check is the name of checks which don't set their own, with {table} and {number}, the position of the check
+	Check string
+	// Reference is the name of foreign key constraints, with {table}, {foreign_table} and {columns}, the primary key
+	// columns of the foreign table joined by underscores
+	Reference string
+	// Initialisms are words, like ID, URL and HTTP, which are upper-cased in Go names instead of title-cased
+	Initialisms []string
+}
+
+// These are the default naming conventions
+const (
+	DefaultForeignKeyNaming = "fk_{table}_{column}"
+	DefaultIndexNaming      = "{table}_i_{columns}"
+	DefaultUniqueNaming     = "{table}_u_{columns}"
+	DefaultCheckNaming      = "{table}_c_{number}"
+	DefaultReferenceNaming  = "reference_{table}_{foreign_table}_{columns}"
+)
+
+// defaultNaming has every default convention
+var defaultNaming = Naming{}
+
+var placeholder = regexp.MustCompile(`{[^{}]*}`)
+
+// Initialisms is the set of upper-cased Naming.Initialisms, which the Go name methods of tables, columns and references
+// upper-case instead of title-casing. A nil Initialisms has none.
+type Initialisms map[string]bool
+
+func (n Naming) foreignKeyName(table, column string) string {
+	return render(n.ForeignKey, DefaultForeignKeyNaming, "{table}", table, "{column}", column)
+}
+
+func (n Naming) indexName(table string, columns []string) string {
+	return render(n.Index, DefaultIndexNaming, "{table}", table, "{columns}", strings.Join(columns, "-"))
+}
+
+func (n Naming) uniqueName(table string, columns []string) string {
+	return render(n.Unique, DefaultUniqueNaming, "{table}", table, "{columns}", strings.Join(columns, "-"))
+}
+
+// checkName returns the name of the check at index i of a table's checks
+func (n Naming) checkName(table string, i int) string {
+	return render(n.Check, DefaultCheckNaming, "{table}", table, "{number}", strconv.Itoa(i+1))
+}
+
+func (n Naming) referenceName(table, foreignTable string, columns []string) string {
+	return render(n.Reference, DefaultReferenceNaming, "{table}", table, "{foreign_table}", foreignTable, "{columns}", strings.Join(columns, "_"))
+}
+
+// render replaces the placeholders of template, or of def if template isn't set, with their values
+func render(template, def string, placeholdersAndValues ...string) string {
+	if template == "" {
+		template = def
+	}
+	return strings.NewReplacer(placeholdersAndValues...).Replace(template)
+}
+
+// initialisms returns the set of the Naming's initialisms, or nil if it has none
+func (n Naming) initialisms() Initialisms {
+	if len(n.Initialisms) == 0 {
+		return nil
+	}
+
+	is := make(Initialisms, len(n.Initialisms))
+	for _, i := range n.Initialisms {
+		is[strings.ToUpper(i)] = true
+	}
+	return is
+}
+
+// validate returns an error if a template has a placeholder it doesn't support
+func (n Naming) validate() error {
+	for _, t := range []struct {
+		key, template string
+		placeholders  []string
+	}{
+		{"foreign_key", n.ForeignKey, []string{"{table}", "{column}"}},
+		{"index", n.Index, []string{"{table}", "{columns}"}},
+		{"unique", n.Unique, []string{"{table}", "{columns}"}},
+		{"check", n.Check, []string{"{table}", "{number}"}},
+		{"reference", n.Reference, []string{"{table}", "{foreign_table}", "{columns}"}},
+	} {
+	next:
+		for _, p := range placeholder.FindAllString(t.template, -1) {
+			for _, allowed := range t.placeholders {
+				if p == allowed {
+					continue next
+				}
+			}
+			return fmt.Errorf("unknown placeholder %s in naming of %s, must be one of %s", p, t.key, strings.Join(t.placeholders, ", "))
+		}
+	}
+
+	return nil
+}
+
+// applyNaming names the indices, uniques, checks, foreign key columns and foreign key constraints of every table which
+// don't have names of their own by the Database's Naming, then shortens those names to what the dialect allows.
+func (db *Database) applyNaming() {
+	n := db.Naming
+	for ti := range db.Tables {
+		t := &db.Tables[ti]
+		for i := range t.Indices {
+			if idx := &t.Indices[i]; idx.unnamed {
+				idx.Name = db.shortenName(n.indexName(t.Name, idx.Columns))
+			}
+		}
+		for i := range t.Checks {
+			if c := &t.Checks[i]; c.unnamed {
+				c.Name = db.shortenName(n.checkName(t.Name, i))
+			}
+		}
+		for i := range t.Uniques {
+			if u := &t.Uniques[i]; u.unnamed {
+				u.Name = db.shortenName(n.uniqueName(t.Name, u.Columns))
+			}
+		}
+		for i := range t.References {
+			db.nameReference(t, &t.References[i])
+		}
+	}
+}
+
+// nameReference sets the foreign key column and constraint names of a reference of t which doesn't name its own columns,
+// if the Database's Naming has conventions for them. Without conventions, they're left to Reference.ColNames and the
+// dialect.
+func (db *Database) nameReference(t *Table, r *Reference) {
+	ft, ok := db.GetTable(r.TableName)
+	if !ok {
+		// missing tables are reported by validation
+		return
+	}
+
+	// the foreign key columns of a HasMany reference are on the other table
+	local, foreign := *t, ft
+	if r.HasMany {
+		local, foreign = ft, *t
+	}
+
+	if db.Naming.ForeignKey != "" && len(r.ColumnNames) == 0 {
+		for _, pk := range foreign.PKColNames() {
+			r.ColumnNames = append(r.ColumnNames, db.Naming.foreignKeyName(foreign.Name, pk))
+		}
+	}
+
+	if db.Naming.Reference != "" {
+		r.ConstraintName = db.shortenName(db.Naming.referenceName(local.Name, foreign.Name, foreign.PKColNames()))
+	}
+}
+
+// shortenName returns the name shortened to the identifier limit of the Database's dialect, if it has one
+func (db *Database) shortenName(name string) string {
+	limit := dialect.IdentifierLimit(db.Dialect)
+	if limit == 0 {
+		return name
+	}
+	return ShortenName(name, limit)
+}
@@ -0,0 +1,196 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDatabase_UnmarshalYAML_naming(t *testing.T) {
+	yml := `
+dialect: mysql
+naming:
+  foreign_key: "{table}_{column}"
+  index: "idx_{table}_{columns}"
+  unique: "uq_{table}_{columns}"
+  check: "chk_{table}_{number}"
+  reference: "fk_{table}_{foreign_table}"
+  initialisms: [id, url]
+tables:
+  user:
+    columns:
+      id:
+        type: int
+        primary_key: true
+      profile_url:
+        type: varchar(255)
+    indices:
+      - columns: [profile_url]
+      - name: named_index
+        columns: [id]
+      - name: user_i_id
+        columns: [id]
+    uniques:
+      - columns: [profile_url]
+      - name: user_u_id
+        columns: [id]
+    checks:
+      - expr: id > 0
+      - name: user_c_2
+        expr: id < 100
+  post:
+    columns:
+      id:
+        type: int
+        primary_key: true
+    references:
+      user:
+        has_one: true
+      comment:
+        has_many: true
+  comment:
+    columns:
+      id:
+        type: int
+        primary_key: true`
+
+	var db Database
+	if err := yaml.Unmarshal([]byte(yml), &db); err != nil {
+		t.Fatalf("UnmarshalYAML() error = %v", err)
+	}
+
+	user, _ := db.GetTable("user")
+	// names which happen to match the default convention are kept, because they're set in the schema
+	if got, want := []string{user.Indices[0].Name, user.Indices[1].Name, user.Indices[2].Name}, []string{"idx_user_profile_url", "named_index", "user_i_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("index names = %v, want %v", got, want)
+	}
+	if got, want := []string{user.Uniques[0].Name, user.Uniques[1].Name}, []string{"uq_user_profile_url", "user_u_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unique names = %v, want %v", got, want)
+	}
+	if got, want := []string{user.Checks[0].Name, user.Checks[1].Name}, []string{"chk_user_1", "user_c_2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("check names = %v, want %v", got, want)
+	}
+
+	post, _ := db.GetTable("post")
+	hasOne, hasMany := post.References[0], post.References[1]
+	if hasOne.TableName != "user" {
+		hasOne, hasMany = hasMany, hasOne
+	}
+	if got, want := hasOne.ColumnNames, []string{"user_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("has_one column names = %v, want %v", got, want)
+	}
+	if got, want := hasOne.ConstraintName, "fk_post_user"; got != want {
+		t.Errorf("has_one constraint name = %s, want %s", got, want)
+	}
+	if got, want := hasMany.ColumnNames, []string{"post_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("has_many column names = %v, want %v", got, want)
+	}
+	if got, want := hasMany.ConstraintName, "fk_comment_post"; got != want {
+		t.Errorf("has_many constraint name = %s, want %s", got, want)
+	}
+
+	is := db.Initialisms()
+	col, _ := user.GetColumn("profile_url")
+	if got, want := col.ExportedGoName(is), "ProfileURL"; got != want {
+		t.Errorf("Column.ExportedGoName() = %s, want %s", got, want)
+	}
+	// columns which weren't unmarshalled, like those yoyo adds, get the same Go names
+	built := Column{Name: "profile_url"}
+	if got, want := built.ExportedGoName(is), "ProfileURL"; got != want {
+		t.Errorf("Column.ExportedGoName() of a built column = %s, want %s", got, want)
+	}
+	if got, want := hasOne.ExportedGoName(is), "User"; got != want {
+		t.Errorf("Reference.ExportedGoName() = %s, want %s", got, want)
+	}
+}
+
+func TestDatabase_UnmarshalYAML_namingLongNames(t *testing.T) {
+	yml := `
+dialect: mysql
+naming:
+  index: "index_of_{table}_on_{columns}"
+tables:
+  a_table_with_a_long_descriptive_name:
+    columns:
+      a_rather_long_column_name:
+        type: int
+    indices:
+      - columns: [a_rather_long_column_name]`
+
+	var db Database
+	if err := yaml.Unmarshal([]byte(yml), &db); err != nil {
+		t.Fatalf("UnmarshalYAML() error = %v", err)
+	}
+
+	got := db.Tables[0].Indices[0].Name
+	if len(got) > 64 || !strings.HasPrefix(got, "index_of_a_table_with_a_long_descriptive_name_on_") {
+		t.Errorf("index name = %s, want the naming convention shortened to 64 characters", got)
+	}
+}
+
+func TestNaming_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  Naming
+		wantErr string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "every placeholder",
+			naming: Naming{
+				ForeignKey: "{table}_{column}",
+				Index:      "{table}_{columns}_idx",
+				Unique:     "{table}_{columns}_key",
+				Check:      "{table}_check{number}",
+				Reference:  "{table}_{foreign_table}_{columns}_fkey",
+			},
+		},
+		{
+			name:    "unknown placeholder",
+			naming:  Naming{Index: "{table}_{column}_idx"},
+			wantErr: "unknown placeholder {column} in naming of index, must be one of {table}, {columns}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.naming.validate()
+			if (err != nil) != (tt.wantErr != "") || err != nil && err.Error() != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_pascal(t *testing.T) {
+	tests := []struct {
+		in   string
+		is   Initialisms
+		want string
+	}{
+		{in: "user_id", want: "UserId"},
+		{in: "user_id", is: Initialisms{"ID": true}, want: "UserID"},
+		{in: "api-url", is: Initialisms{"API": true, "URL": true}, want: "APIURL"},
+		{in: "identity", is: Initialisms{"ID": true}, want: "Identity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := pascal(tt.in, tt.is); got != tt.want {
+				t.Errorf("pascal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_QueryPackageName_initialisms(t *testing.T) {
+	table := Table{Name: "user_id"}
+	if got, want := table.ExportedGoName(Initialisms{"ID": true}), "UserID"; got != want {
+		t.Errorf("ExportedGoName() = %s, want %s", got, want)
+	}
+	if got, want := table.QueryPackageName(), "user_id"; got != want {
+		t.Errorf("QueryPackageName() = %s, want %s", got, want)
+	}
+}
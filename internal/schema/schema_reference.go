@@ -1,13 +1,5 @@
 package schema
 
-import (
-	"fmt"
-)
-
-const (
-	foreignKeyPrefix = "fk_"
-)
-
 // ColNames returns a list foreign key column names for the given reference. The method assumes that the `fTable` value
 // is correct (for example, the "many" side in a one-to-many reference) and not necessarily the target table of the
 // Reference itself. So make sure the Yoyo-to-RDB reference translation has already happened before calling ColNames.
@@ -23,7 +15,7 @@ func (r *Reference) ColNames(ft Table) []string {
 		case len(refColNames) > 0:
 			fkname, refColNames = refColNames[0], refColNames[1:]
 		default:
-			fkname = fmt.Sprintf("%s%s_%s", foreignKeyPrefix, ft.Name, fcName)
+			fkname = defaultNaming.foreignKeyName(ft.Name, fcName)
 		}
 
 		fknames = append(fknames, fkname)
@@ -33,10 +25,11 @@ func (r *Reference) ColNames(ft Table) []string {
 }
 
 // ExportedGoName returns the string that will be used for naming Exported types, functions, etc in generated Go code
-func (r *Reference) ExportedGoName() string {
+// with the words which are in is upper-cased
+func (r *Reference) ExportedGoName(is Initialisms) string {
 	if r.GoName != "" {
-		return pascal(r.GoName)
+		return pascal(r.GoName, is)
 	}
 
-	return pascal(r.TableName)
+	return pascal(r.TableName, is)
 }
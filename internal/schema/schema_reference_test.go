@@ -111,7 +111,7 @@ func TestReference_ExportedGoName(t *testing.T) {
 				GoName:    tt.fields.GoName,
 				TableName: tt.fields.TableName,
 			}
-			if got := r.ExportedGoName(); got != tt.want {
+			if got := r.ExportedGoName(nil); got != tt.want {
 				t.Errorf("ExportedGoName() = %v, want %v", got, tt.want)
 			}
 		})
@@ -16,13 +16,18 @@ func camelToSnake(in string) (out string) {
 
 // ExportedGoName returns a string with a name to use to represent the table in Exported go types or variables.
 // If a GoName is explicitly set already, the returned value will be that forced into PascalCase. If not, it will be
-// the default name forced into PascalCase.
-func (t *Table) ExportedGoName() string {
+// the default name forced into PascalCase. Words which are in is are upper-cased.
+func (t *Table) ExportedGoName(is Initialisms) string {
+	return pascal(t.goName(), is)
+}
+
+// goName returns the GoName of the table if it's set, or its Name otherwise
+func (t *Table) goName() string {
 	if t.GoName != "" {
-		return pascal(t.GoName)
+		return t.GoName
 	}
 
-	return pascal(t.Name)
+	return t.Name
 }
 
 // GetColumn returns a column matching the given name if present. If a matching column is found, the returned bool is true.
@@ -38,7 +43,8 @@ func (t *Table) GetColumn(name string) (Column, bool) {
 
 // QueryPackageName returns a string to use for this table's query package
 func (t *Table) QueryPackageName() (name string) {
-	name = t.ExportedGoName()
+	// initialisms are left out, so that `user_id` isn't `user_i_d`
+	name = pascal(t.goName(), nil)
 	name = string(append([]byte{byte(unicode.ToLower(rune(name[0])))}, name[1:]...))
 	return camelToSnake(name)
 }
@@ -68,3 +74,26 @@ func (t *Table) PKColumns() (cols []Column) {
 
 	return cols
 }
+
+// IsKeyColumn returns true if the named column is part of the table's Primary Key, one of its indices, or one of its
+// unique constraints
+func (t *Table) IsKeyColumn(name string) bool {
+	if c, ok := t.GetColumn(name); ok && c.PrimaryKey {
+		return true
+	}
+	for _, i := range t.Indices {
+		for _, c := range i.Columns {
+			if c == name {
+				return true
+			}
+		}
+	}
+	for _, u := range t.Uniques {
+		for _, c := range u.Columns {
+			if c == name {
+				return true
+			}
+		}
+	}
+	return false
+}
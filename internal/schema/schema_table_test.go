@@ -81,7 +81,7 @@ func TestTable_ExportedGoName(t1 *testing.T) {
 				Name:   tt.fields.Name,
 				GoName: tt.fields.GoName,
 			}
-			if got := t.ExportedGoName(); got != tt.want {
+			if got := t.ExportedGoName(nil); got != tt.want {
 				t1.Errorf("ExportedGoName()\nwant %#v\n got %#v", tt.want, got)
 			}
 		})
@@ -255,3 +255,30 @@ func TestTable_PKColumns(t1 *testing.T) {
 		})
 	}
 }
+
+func TestTable_IsKeyColumn(t1 *testing.T) {
+	t := &Table{
+		Columns: []Column{
+			{Name: "id", PrimaryKey: true},
+			{Name: "indexed"},
+			{Name: "unique"},
+			{Name: "plain"},
+		},
+		Indices: []Index{{Name: "i", Columns: []string{"indexed"}}},
+		Uniques: []Unique{{Name: "u", Columns: []string{"unique"}}},
+	}
+	tests := map[string]bool{
+		"id":      true,
+		"indexed": true,
+		"unique":  true,
+		"plain":   false,
+		"missing": false,
+	}
+	for name, want := range tests {
+		t1.Run(name, func(t1 *testing.T) {
+			if got := t.IsKeyColumn(name); got != want {
+				t1.Errorf("IsKeyColumn() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -5,90 +5,408 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/yoyo-project/yoyo/internal/datatype"
 	"gopkg.in/yaml.v3"
 )
 
 var disallowedNameChars = regexp.MustCompile("[^a-zA-Z\\d_-]")
 var paramIsolator = regexp.MustCompile("(^.*?(\\(|$)|[\\)\\s])")
 
-func (db *Database) UnmarshalYAML(value *yaml.Node) (err error) {
+// UnmarshalYAML reads a database, collecting every problem with its tables and views as ValidationErrors. If it includes
+// other files, it isn't finished or validated until they're merged into it by LoadIncludes, so that references between
+// files resolve.
+func (db *Database) UnmarshalYAML(value *yaml.Node) error {
+	var p problems
 	for i, n := range value.Content {
+		var err error
 		switch n.Value {
 		case "dialect":
 			err = value.Content[i+1].Decode(&db.Dialect)
+		case "go_types":
+			db.positionsOf().options["go_types"] = position("", value.Content[i+1])
+			err = value.Content[i+1].Decode(&db.GoTypes)
+		case "uuid_storage":
+			db.positionsOf().options["uuid_storage"] = position("", value.Content[i+1])
+			err = value.Content[i+1].Decode(&db.UUIDStorage)
+		case "include":
+			err = value.Content[i+1].Decode(&db.Include)
+		case "strict":
+			err = value.Content[i+1].Decode(&db.Strict)
+		case "naming":
+			db.positionsOf().options["naming"] = position("", value.Content[i+1])
+			err = value.Content[i+1].Decode(&db.Naming)
 		case "tables":
-			tabsNode := value.Content[i+1]
-			for ti, tn := range tabsNode.Content {
-				if tn.Tag == "!!str" {
-					t := Table{}
-					t.Name = tn.Value
-					err = tabsNode.Content[ti+1].Decode(&t)
-					if err != nil {
-						return fmt.Errorf("unable to unmarshal database: %w", err)
-					}
-					db.Tables = append(db.Tables, t)
-				}
+			db.unmarshalTables(value.Content[i+1], &p)
+		case "views":
+			db.unmarshalViews(value.Content[i+1], &p)
+		}
+
+		if err != nil {
+			p.add(value.Content[i+1], err)
+		}
+	}
+
+	if db.Strict {
+		p.checkKeys(value, DatabaseJSONSchema())
+	}
+
+	if len(db.Include) > 0 {
+		db.pending = p.errs
+		return nil
+	}
+
+	p.add(value, db.finish())
+	return p.err()
+}
+
+// finish applies the database-wide options to the columns of every table and view, then validates the database. It
+// returns any problems pending from before includes were loaded along with its own.
+func (db *Database) finish() error {
+	p := problems{errs: db.pending}
+
+	if err := db.applyGoTypes(); err != nil {
+		p.addAt(db.positionsOf().options["go_types"], err)
+	}
+	db.applyUUIDStorage()
+	if err := db.Naming.validate(); err != nil {
+		p.addAt(db.positionsOf().options["naming"], err)
+	} else {
+		db.applyNaming()
+	}
+
+	p.add(nil, db.validate())
+
+	db.positions, db.pending = nil, nil
+	return p.err()
+}
+
+// unmarshalTables appends the valid tables of a `tables` node to the Database, adding the problems of the others to p
+func (db *Database) unmarshalTables(tabsNode *yaml.Node, p *problems) {
+	ps := db.positionsOf()
+	for ti := 0; ti+1 < len(tabsNode.Content); ti += 2 {
+		if tn := tabsNode.Content[ti]; tn.Tag == "!!str" {
+			t := Table{}
+			t.Name = tn.Value
+			if err := tabsNode.Content[ti+1].Decode(&t); err != nil {
+				p.add(tn, err)
+				ps.invalid[t.Name] = true
+				continue
+			}
+			ps.tables = append(ps.tables, position(p.file, tn))
+			t.setPositions(p.file, tn, tabsNode.Content[ti+1])
+			db.Tables = append(db.Tables, t)
+		}
+	}
+}
+
+// unmarshalViews appends the valid views of a `views` node to the Database, adding the problems of the others to p
+func (db *Database) unmarshalViews(viewsNode *yaml.Node, p *problems) {
+	ps := db.positionsOf()
+	for vi := 0; vi+1 < len(viewsNode.Content); vi += 2 {
+		if vn := viewsNode.Content[vi]; vn.Tag == "!!str" {
+			v := View{}
+			v.Name = vn.Value
+			if err := viewsNode.Content[vi+1].Decode(&v); err != nil {
+				p.add(vn, err)
+				ps.invalid[v.Name] = true
+				continue
 			}
+			ps.views = append(ps.views, position(p.file, vn))
+			v.setPositions(p.file, vn, viewsNode.Content[vi+1])
+			db.Views = append(db.Views, v)
 		}
+	}
+}
 
+// setPositions sets the Position of the table or view declared at key, whose definition is n, and of its columns. It's
+// set here rather than by Table.UnmarshalYAML, which doesn't know the file it's reading.
+func (t *Table) setPositions(file string, key, n *yaml.Node) {
+	t.Position = position(file, key)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != "columns" {
+			continue
+		}
+		cols := n.Content[i+1]
+		for ci := 0; ci+1 < len(cols.Content); ci += 2 {
+			if c := t.columnPointer(cols.Content[ci].Value); c != nil {
+				c.Position = position(file, cols.Content[ci])
+			}
+		}
+	}
+}
+
+// applyGoTypes sets the GoType of every column matching a key of db.GoTypes, unless the column has its own GoType
+func (db *Database) applyGoTypes() error {
+	for key, goType := range db.GoTypes {
+		dt, err := datatype.FromString(key)
 		if err != nil {
-			return fmt.Errorf("unable to unmarshal database: %w", err)
+			return fmt.Errorf("invalid datatype `%s` in go_types: %w", key, err)
+		}
+		var params []string
+		if ps := paramIsolator.ReplaceAllString(key, ""); len(ps) > 0 {
+			params = strings.Split(ps, ",")
+		}
+
+		for _, t := range db.allTables() {
+			for ci := range t.Columns {
+				c := &t.Columns[ci]
+				if c.GoType != "" || c.Datatype != dt {
+					continue
+				}
+				if params != nil && strings.Join(params, ",") != strings.Join(c.Params, ",") {
+					continue
+				}
+				c.GoType = goType
+			}
 		}
 	}
 
-	return db.validate()
+	return nil
+}
+
+// applyUUIDStorage sets the UUIDStorage of every UUID column to the Database's EffectiveUUIDStorage
+func (db *Database) applyUUIDStorage() {
+	storage := db.EffectiveUUIDStorage()
+	for _, t := range db.allTables() {
+		for ci := range t.Columns {
+			if c := &t.Columns[ci]; c.Datatype == datatype.UUID {
+				c.UUIDStorage = storage
+			}
+		}
+	}
 }
 
-func (t *Table) UnmarshalYAML(value *yaml.Node) (err error) {
+// UnmarshalYAML reads a table, collecting every problem with it and its columns, indices and references as
+// ValidationErrors
+func (t *Table) UnmarshalYAML(value *yaml.Node) error {
+	var (
+		timestamps bool
+		p          problems
+		ps         = newParts(value)
+	)
 	for i, n := range value.Content {
+		var err error
 		switch n.Value {
 		case "columns":
-			colsNode := value.Content[i+1]
-			for ci, cn := range colsNode.Content {
-				if cn.Tag == "!!str" {
-					c := Column{}
-					c.Name = cn.Value
-					err = colsNode.Content[ci+1].Decode(&c)
-					if err != nil {
-						return fmt.Errorf("unable to unmarshal table: %w", err)
-					}
-					t.Columns = append(t.Columns, c)
-				}
-			}
+			t.Columns, err = unmarshalColumns(value.Content[i+1], ps)
 		case "indices", "indexes":
 			indsNode := value.Content[i+1]
 
 			for _, in := range indsNode.Content {
 				index := unmarshalIndex(in)
 				if index.Name == "" {
-					index.Name = fmt.Sprintf("%s_i_%s", t.Name, strings.Join(index.Columns, "-"))
+					// named by the default convention until the Database's Naming is applied
+					index.Name, index.unnamed = defaultNaming.indexName(t.Name, index.Columns), true
 				}
 
+				ps.nodes["index:"+index.Name] = in
 				t.Indices = append(t.Indices, index)
 			}
+		case "checks":
+			for ci, cn := range value.Content[i+1].Content {
+				check := unmarshalCheck(cn)
+				if check.Name == "" {
+					check.Name, check.unnamed = defaultNaming.checkName(t.Name, ci), true
+				}
+
+				ps.nodes["check:"+check.Name] = cn
+				t.Checks = append(t.Checks, check)
+			}
+		case "unique", "uniques":
+			for _, un := range value.Content[i+1].Content {
+				unique := unmarshalUnique(un)
+				if unique.Name == "" {
+					unique.Name, unique.unnamed = defaultNaming.uniqueName(t.Name, unique.Columns), true
+				}
+
+				ps.nodes["unique:"+unique.Name] = un
+				t.Uniques = append(t.Uniques, unique)
+			}
 		case "references":
 			refsNode := value.Content[i+1]
-			for ri, rn := range refsNode.Content {
-				if rn.Tag == "!!str" {
+			for ri := 0; ri+1 < len(refsNode.Content); ri += 2 {
+				if rn := refsNode.Content[ri]; rn.Tag == "!!str" {
 					r := Reference{}
 					r.TableName = rn.Value
-					err = refsNode.Content[ri+1].Decode(&r)
-					if err != nil {
-						return fmt.Errorf("unable to unmarshal table: %w", err)
+					if err := refsNode.Content[ri+1].Decode(&r); err != nil {
+						p.add(rn, fmt.Errorf("%w on reference for table %s", err, r.TableName))
 					}
 					t.References = append(t.References, r)
 				}
 			}
 		case "go_name":
 			err = value.Content[i+1].Decode(&t.GoName)
+		case "version_column":
+			ps.nodes["version_column"] = value.Content[i+1]
+			err = value.Content[i+1].Decode(&t.VersionColumn)
+		case "soft_delete":
+			ps.nodes["soft_delete"] = value.Content[i+1]
+			err = t.unmarshalSoftDelete(value.Content[i+1])
+		case "timestamps":
+			err = value.Content[i+1].Decode(&timestamps)
+		case "engine":
+			err = value.Content[i+1].Decode(&t.Options.Engine)
+		case "charset":
+			err = value.Content[i+1].Decode(&t.Options.Charset)
+		case "collation":
+			err = value.Content[i+1].Decode(&t.Options.Collation)
+		case "row_format":
+			err = value.Content[i+1].Decode(&t.Options.RowFormat)
+		case "comment":
+			err = value.Content[i+1].Decode(&t.Options.Comment)
+		case "tablespace":
+			err = value.Content[i+1].Decode(&t.Options.Tablespace)
+		case "unlogged":
+			err = value.Content[i+1].Decode(&t.Options.Unlogged)
+		}
+
+		if err != nil {
+			p.add(value.Content[i+1], fmt.Errorf("unable to unmarshal table: %w", err))
+		}
+	}
+
+	t.addVersionColumn()
+	t.addSoftDeleteColumn()
+	if timestamps {
+		t.addTimestampColumns()
+		// declared columns only get their auto_now flags now, so they're validated again
+		for _, name := range []string{DefaultCreatedAtColumn, DefaultUpdatedAtColumn} {
+			if err := t.columnPointer(name).validate(); err != nil {
+				p.add(ps.at("column:"+name), fmt.Errorf("column '%s' validation error: %w", name, err))
+			}
+		}
+	}
+
+	p.add(value, t.validate(ps))
+	return p.err()
+}
+
+// UnmarshalYAML reads a view, which only has a definition, columns, go_name and comment, collecting every problem with
+// it as ValidationErrors. A trailing semicolon is removed from the definition.
+func (v *View) UnmarshalYAML(value *yaml.Node) error {
+	var (
+		p  problems
+		ps = newParts(value)
+	)
+	for i, n := range value.Content {
+		var err error
+		switch n.Value {
+		case "definition":
+			err = value.Content[i+1].Decode(&v.Definition)
+		case "columns":
+			v.Columns, err = unmarshalColumns(value.Content[i+1], ps)
+		case "go_name":
+			err = value.Content[i+1].Decode(&v.GoName)
+		case "comment":
+			err = value.Content[i+1].Decode(&v.Options.Comment)
 		}
 
 		if err != nil {
-			return fmt.Errorf("unable to unmarshal table: %w", err)
+			p.add(value.Content[i+1], fmt.Errorf("unable to unmarshal view: %w", err))
 		}
 	}
 
-	return t.validate()
+	// The definition is written into CREATE VIEW statements, so it can't end the statement early
+	v.Definition = strings.TrimRight(strings.TrimSpace(v.Definition), "; \t\n")
+
+	p.add(value, v.validate(ps))
+	return p.err()
+}
+
+// unmarshalColumns reads the columns of a table or view, which are keyed by their names, and records their nodes in
+// ps. Invalid columns are still returned, so that they don't cause more errors in the indices which use them.
+func unmarshalColumns(colsNode *yaml.Node, ps parts) (cols []Column, err error) {
+	var p problems
+	for ci := 0; ci+1 < len(colsNode.Content); ci += 2 {
+		if cn := colsNode.Content[ci]; cn.Tag == "!!str" {
+			c := Column{}
+			c.Name = cn.Value
+			if err = colsNode.Content[ci+1].Decode(&c); err != nil {
+				p.add(cn, fmt.Errorf("column '%s' validation error: %w", c.Name, err))
+			}
+			ps.nodes["column:"+c.Name] = cn
+			cols = append(cols, c)
+		}
+	}
+	return cols, p.err()
+}
+
+// addTimestampColumns adds auto_now_add and auto_now TIMESTAMP columns for the creation and last update times of rows.
+// If the table already declares either column, its flag is set instead.
+func (t *Table) addTimestampColumns() {
+	for _, tc := range []struct {
+		name       string
+		autoNowAdd bool
+	}{
+		{DefaultCreatedAtColumn, true},
+		{DefaultUpdatedAtColumn, false},
+	} {
+		c := t.columnPointer(tc.name)
+		if c == nil {
+			t.Columns = append(t.Columns, Column{Name: tc.name, Datatype: datatype.Timestamp})
+			c = &t.Columns[len(t.Columns)-1]
+		}
+		c.AutoNowAdd = tc.autoNowAdd
+		c.AutoNow = !tc.autoNowAdd
+	}
+}
+
+// columnPointer returns a pointer to the column with the given name, or nil if there is none
+func (t *Table) columnPointer(name string) *Column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// unmarshalSoftDelete accepts either `soft_delete: true`, which uses the default `deleted_at` column, or the name of the
+// column to use
+func (t *Table) unmarshalSoftDelete(n *yaml.Node) (err error) {
+	if n.Tag == "!!bool" {
+		var enabled bool
+		if err = n.Decode(&enabled); err == nil && enabled {
+			t.SoftDeleteColumn = DefaultSoftDeleteColumn
+		}
+		return err
+	}
+
+	return n.Decode(&t.SoftDeleteColumn)
+}
+
+// addSoftDeleteColumn adds a column for t.SoftDeleteColumn if it's set and the table doesn't already declare it
+func (t *Table) addSoftDeleteColumn() {
+	if t.SoftDeleteColumn == "" {
+		return
+	}
+	if _, ok := t.GetColumn(t.SoftDeleteColumn); ok {
+		return
+	}
+
+	t.Columns = append(t.Columns, Column{
+		Name:     t.SoftDeleteColumn,
+		Datatype: datatype.Timestamp,
+		Nullable: true,
+	})
+}
+
+// addVersionColumn adds a column for t.VersionColumn if it's set and the table doesn't already declare it
+func (t *Table) addVersionColumn() {
+	if t.VersionColumn == "" {
+		return
+	}
+	if _, ok := t.GetColumn(t.VersionColumn); ok {
+		return
+	}
+
+	def := "0"
+	t.Columns = append(t.Columns, Column{
+		Name:     t.VersionColumn,
+		Datatype: datatype.Integer,
+		Unsigned: true,
+		Default:  &def,
+	})
 }
 
 func (c *Column) UnmarshalYAML(value *yaml.Node) (err error) {
@@ -104,7 +422,7 @@ func (c *Column) UnmarshalYAML(value *yaml.Node) (err error) {
 			if err != nil {
 				return fmt.Errorf("unable to unmarshal column: %w", err)
 			}
-			ps := paramIsolator.ReplaceAllString(str, "")
+			ps := paramIsolator.ReplaceAllString(strings.TrimSuffix(strings.TrimSpace(str), "[]"), "")
 			if len(ps) > 0 {
 				c.Params = strings.Split(ps, ",")
 			}
@@ -114,6 +432,11 @@ func (c *Column) UnmarshalYAML(value *yaml.Node) (err error) {
 			err = value.Content[i+1].Decode(&c.Nullable)
 		case "default":
 			err = value.Content[i+1].Decode(&c.Default)
+		case "default_expr":
+			err = value.Content[i+1].Decode(&c.DefaultExpr)
+		case "generated":
+			c.Generated = &Generated{}
+			err = value.Content[i+1].Decode(c.Generated)
 		case "charset":
 			err = value.Content[i+1].Decode(&c.Charset)
 		case "collation":
@@ -124,6 +447,18 @@ func (c *Column) UnmarshalYAML(value *yaml.Node) (err error) {
 			err = value.Content[i+1].Decode(&c.AutoIncrement)
 		case "go_name":
 			err = value.Content[i+1].Decode(&c.GoName)
+		case "json_name":
+			err = value.Content[i+1].Decode(&c.JSONName)
+		case "omit":
+			err = value.Content[i+1].Decode(&c.Omit)
+		case "go_type":
+			err = value.Content[i+1].Decode(&c.GoType)
+		case "auto_now_add":
+			err = value.Content[i+1].Decode(&c.AutoNowAdd)
+		case "auto_now":
+			err = value.Content[i+1].Decode(&c.AutoNow)
+		case "comment":
+			err = value.Content[i+1].Decode(&c.Comment)
 		}
 
 		if err != nil {
@@ -147,8 +482,10 @@ func (r *Reference) UnmarshalYAML(value *yaml.Node) (err error) {
 			err = value.Content[i+1].Decode(&r.ColumnNames)
 		case "on_delete":
 			err = value.Content[i+1].Decode(&r.OnDelete)
+			r.OnDelete = normalizeAction(r.OnDelete)
 		case "on_update":
 			err = value.Content[i+1].Decode(&r.OnUpdate)
+			r.OnUpdate = normalizeAction(r.OnUpdate)
 		case "go_name":
 			err = value.Content[i+1].Decode(&r.GoName)
 		}
@@ -161,6 +498,11 @@ func (r *Reference) UnmarshalYAML(value *yaml.Node) (err error) {
 	return r.validate()
 }
 
+// normalizeAction upper-cases a referential action and collapses its whitespace, so `set  null` becomes `SET NULL`
+func normalizeAction(action string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(action), " "))
+}
+
 func unmarshalIndex(node *yaml.Node) Index {
 	index := Index{}
 
@@ -177,8 +519,47 @@ func unmarshalIndex(node *yaml.Node) Index {
 		case "unique":
 			i++
 			index.Unique = node.Content[i].Value == "true"
+		case "type":
+			i++
+			index.Type = strings.ToLower(node.Content[i].Value)
 		}
 	}
 
 	return index
-}
\ No newline at end of file
+}
+
+func unmarshalCheck(node *yaml.Node) Check {
+	check := Check{}
+
+	for i := 0; i < len(node.Content); i++ {
+		switch node.Content[i].Value {
+		case "name":
+			i++
+			check.Name = node.Content[i].Value
+		case "expr":
+			i++
+			check.Expr = node.Content[i].Value
+		}
+	}
+
+	return check
+}
+
+func unmarshalUnique(node *yaml.Node) Unique {
+	unique := Unique{}
+
+	for i := 0; i < len(node.Content); i++ {
+		switch node.Content[i].Value {
+		case "name":
+			i++
+			unique.Name = node.Content[i].Value
+		case "columns":
+			i++
+			for j := 0; j < len(node.Content[i].Content); j++ {
+				unique.Columns = append(unique.Columns, node.Content[i].Content[j].Value)
+			}
+		}
+	}
+
+	return unique
+}
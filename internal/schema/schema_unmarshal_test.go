@@ -33,7 +33,203 @@ tables:
         type: int`,
 			wantDB: Database{
 				Dialect: "mysql",
-				Tables:  []Table{{Name: "primary", Columns: []Column{{Name: "id", Datatype: datatype.Integer}}}},
+				Tables: []Table{{
+					Name:     "primary",
+					Columns:  []Column{{Name: "id", Datatype: datatype.Integer, Position: Position{Line: 6, Column: 7}}},
+					Position: Position{Line: 4, Column: 3},
+				}},
+			},
+		},
+		{
+			name: "with go_types",
+			yml: `
+dialect: mysql
+go_types:
+  binary(16): github.com/google/uuid.UUID
+tables:
+  primary:
+    columns:
+      id:
+        type: binary(16)
+      hash:
+        type: binary(32)
+      other:
+        type: binary(16)
+        go_type: example.com/other.ID`,
+			wantDB: Database{
+				Dialect: "mysql",
+				GoTypes: map[string]string{"binary(16)": "github.com/google/uuid.UUID"},
+				Tables: []Table{{Name: "primary", Position: Position{Line: 6, Column: 3}, Columns: []Column{
+					{Name: "id", Datatype: datatype.Binary, Params: []string{"16"}, GoType: "github.com/google/uuid.UUID", Position: Position{Line: 8, Column: 7}},
+					{Name: "hash", Datatype: datatype.Binary, Params: []string{"32"}, Position: Position{Line: 10, Column: 7}},
+					{Name: "other", Datatype: datatype.Binary, Params: []string{"16"}, GoType: "example.com/other.ID", Position: Position{Line: 12, Column: 7}},
+				}}},
+			},
+		},
+		{
+			name: "with uuid columns",
+			yml: `
+dialect: postgresql
+tables:
+  primary:
+    columns:
+      id:
+        type: uuid
+        primary_key: true`,
+			wantDB: Database{
+				Dialect: "postgresql",
+				Tables: []Table{{Name: "primary", Position: Position{Line: 4, Column: 3}, Columns: []Column{
+					{Name: "id", Datatype: datatype.UUID, PrimaryKey: true, UUIDStorage: UUIDStorageNative, Position: Position{Line: 6, Column: 7}},
+				}}},
+			},
+		},
+		{
+			name: "with uuid_storage",
+			yml: `
+dialect: mysql
+uuid_storage: char
+tables:
+  primary:
+    columns:
+      id:
+        type: uuid`,
+			wantDB: Database{
+				Dialect:     "mysql",
+				UUIDStorage: UUIDStorageChar,
+				Tables: []Table{{Name: "primary", Position: Position{Line: 5, Column: 3}, Columns: []Column{
+					{Name: "id", Datatype: datatype.UUID, UUIDStorage: UUIDStorageChar, Position: Position{Line: 7, Column: 7}},
+				}}},
+			},
+		},
+		{
+			name: "with unknown uuid_storage",
+			yml: `
+dialect: mysql
+uuid_storage: hex`,
+			wantDB: Database{
+				Dialect:     "mysql",
+				UUIDStorage: "hex",
+			},
+			wantErr: true,
+		},
+		{
+			name: "with view",
+			yml: `
+dialect: mysql
+tables:
+  primary:
+    columns:
+      id:
+        type: int
+views:
+  primary_ids:
+    comment: Every id
+    definition: |
+      SELECT id FROM primary;
+    columns:
+      id:
+        type: int`,
+			wantDB: Database{
+				Dialect: "mysql",
+				Tables: []Table{{
+					Name:     "primary",
+					Columns:  []Column{{Name: "id", Datatype: datatype.Integer, Position: Position{Line: 6, Column: 7}}},
+					Position: Position{Line: 4, Column: 3},
+				}},
+				Views: []View{{
+					Table: Table{
+						Name:     "primary_ids",
+						Columns:  []Column{{Name: "id", Datatype: datatype.Integer, Position: Position{Line: 14, Column: 7}}},
+						Options:  TableOptions{Comment: "Every id"},
+						Position: Position{Line: 9, Column: 3},
+					},
+					Definition: "SELECT id FROM primary",
+				}},
+			},
+		},
+		{
+			name: "with view missing definition",
+			yml: `
+dialect: mysql
+views:
+  primary_ids:
+    columns:
+      id:
+        type: int`,
+			wantDB: Database{
+				Dialect: "mysql",
+			},
+			wantErr: true,
+		},
+		{
+			name: "with view named like a table",
+			yml: `
+dialect: mysql
+tables:
+  primary:
+    columns:
+      id:
+        type: int
+views:
+  primary:
+    definition: SELECT 1 AS id
+    columns:
+      id:
+        type: int`,
+			wantDB: Database{
+				Dialect: "mysql",
+				Tables: []Table{{
+					Name:     "primary",
+					Columns:  []Column{{Name: "id", Datatype: datatype.Integer, Position: Position{Line: 6, Column: 7}}},
+					Position: Position{Line: 4, Column: 3},
+				}},
+				Views: []View{{
+					Table: Table{
+						Name:     "primary",
+						Columns:  []Column{{Name: "id", Datatype: datatype.Integer, Position: Position{Line: 12, Column: 7}}},
+						Position: Position{Line: 9, Column: 3},
+					},
+					Definition: "SELECT 1 AS id",
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "with generated names longer than the dialect allows",
+			yml: `
+dialect: mysql
+tables:
+  a_table_with_a_long_descriptive_name:
+    columns:
+      first_column:
+        type: int
+      second_column:
+        type: int
+      third_column:
+        type: int
+    indices:
+      - columns: [first_column, second_column, third_column]
+      - name: idx_first
+        columns: [first_column]`,
+			wantDB: Database{
+				Dialect: "mysql",
+				Tables: []Table{{
+					Name: "a_table_with_a_long_descriptive_name",
+					Columns: []Column{
+						{Name: "first_column", Datatype: datatype.Integer, Position: Position{Line: 6, Column: 7}},
+						{Name: "second_column", Datatype: datatype.Integer, Position: Position{Line: 8, Column: 7}},
+						{Name: "third_column", Datatype: datatype.Integer, Position: Position{Line: 10, Column: 7}},
+					},
+					Position: Position{Line: 4, Column: 3},
+					Indices: []Index{
+						{
+							Name:    "a_table_with_a_long_descriptive_name_i_first_column-sec_9f04dd25",
+							Columns: []string{"first_column", "second_column", "third_column"},
+							unnamed: true,
+						},
+						{Name: "idx_first", Columns: []string{"first_column"}},
+					},
+				}},
 			},
 		},
 		{
@@ -115,12 +311,57 @@ func TestReference_UnmarshalYAML(t *testing.T) {
 				OnUpdate: "CASCADE",
 			},
 		},
+		{
+			name: "on delete set null normalized",
+			yml:  "has_one: true\non_delete: set  null",
+			wantRef: Reference{
+				HasOne:   true,
+				OnDelete: "SET NULL",
+			},
+		},
+		{
+			name: "on update restrict and on delete no action",
+			yml:  "has_one: true\non_update: RESTRICT\non_delete: no action",
+			wantRef: Reference{
+				HasOne:   true,
+				OnUpdate: "RESTRICT",
+				OnDelete: "NO ACTION",
+			},
+		},
+		{
+			name:    "partial action",
+			yml:     "has_one: true\non_delete: CAS",
+			wantErr: true,
+		},
+		{
+			name:    "set null on required reference",
+			yml:     "has_one: true\nrequired: true\non_delete: SET NULL",
+			wantErr: true,
+		},
+		{
+			name: "set default on required reference",
+			yml:  "has_one: true\nrequired: true\non_update: SET DEFAULT",
+			wantRef: Reference{
+				HasOne:   true,
+				Required: true,
+				OnUpdate: "SET DEFAULT",
+			},
+		},
+		{
+			name:    "set null on update on required reference",
+			yml:     "has_one: true\nrequired: true\non_update: SET NULL",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := Reference{}
-			if err := yaml.Unmarshal([]byte(tt.yml), &r); err != nil {
-				t.Errorf("Got error %s", err)
+			if err := yaml.Unmarshal([]byte(tt.yml), &r); (err != nil) != tt.wantErr {
+				t.Errorf("Got error %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
 			}
 
 			if !reflect.DeepEqual(r, tt.wantRef) {
@@ -247,12 +488,336 @@ references:
 				},
 			},
 		},
+		{
+			name: "checks and uniques",
+			yml: `
+columns:
+  a:
+    type: INT
+  b:
+    type: INT
+checks:
+  - name: positive_a
+    expr: a > 0
+  - expr: b > a
+unique:
+  - name: uq_a
+    columns: [a]
+  - columns: [a, b]`,
+			want: Table{
+				Columns: []Column{
+					{
+						Name:     "a",
+						Datatype: datatype.Integer,
+					},
+					{
+						Name:     "b",
+						Datatype: datatype.Integer,
+					},
+				},
+				Checks: []Check{
+					{Name: "positive_a", Expr: "a > 0"},
+					{Name: "_c_2", Expr: "b > a", unnamed: true},
+				},
+				Uniques: []Unique{
+					{Name: "uq_a", Columns: []string{"a"}},
+					{Name: "_u_a-b", Columns: []string{"a", "b"}, unnamed: true},
+				},
+			},
+		},
+		{
+			name: "unique on missing column",
+			yml: `
+columns:
+  a:
+    type: INT
+unique:
+  - columns: [b]`,
+			wantErr: true,
+		},
+		{
+			name: "check without expr",
+			yml: `
+columns:
+  a:
+    type: INT
+checks:
+  - name: empty`,
+			wantErr: true,
+		},
+		{
+			name: "version column added",
+			yml: `
+version_column: version
+columns:
+  id:
+    type: INT
+    primary_key: true`,
+			want: Table{
+				VersionColumn: "version",
+				Columns: []Column{
+					{
+						Name:       "id",
+						Datatype:   datatype.Integer,
+						PrimaryKey: true,
+					},
+					{
+						Name:     "version",
+						Datatype: datatype.Integer,
+						Unsigned: true,
+						Default:  func() *string { s := "0"; return &s }(),
+					},
+				},
+			},
+		},
+		{
+			name: "version column declared",
+			yml: `
+version_column: rev
+columns:
+  id:
+    type: INT
+    primary_key: true
+  rev:
+    type: BIGINT`,
+			want: Table{
+				VersionColumn: "rev",
+				Columns: []Column{
+					{
+						Name:       "id",
+						Datatype:   datatype.Integer,
+						PrimaryKey: true,
+					},
+					{
+						Name:     "rev",
+						Datatype: datatype.BigInt,
+					},
+				},
+			},
+		},
+		{
+			name: "version column not an integer",
+			yml: `
+version_column: rev
+columns:
+  id:
+    type: INT
+    primary_key: true
+  rev:
+    type: VARCHAR(8)`,
+			wantErr: true,
+		},
+		{
+			name: "soft delete",
+			yml: `
+soft_delete: true
+columns:
+  id:
+    type: INT`,
+			want: Table{
+				SoftDeleteColumn: "deleted_at",
+				Columns: []Column{
+					{
+						Name:     "id",
+						Datatype: datatype.Integer,
+					},
+					{
+						Name:     "deleted_at",
+						Datatype: datatype.Timestamp,
+						Nullable: true,
+					},
+				},
+			},
+		},
+		{
+			name: "table options",
+			yml: `
+engine: InnoDB
+charset: utf8mb4
+collation: utf8mb4_unicode_ci
+row_format: DYNAMIC
+comment: everyone we know
+tablespace: fast
+unlogged: true
+columns:
+  id:
+    type: INT`,
+			want: Table{
+				Columns: []Column{
+					{
+						Name:     "id",
+						Datatype: datatype.Integer,
+					},
+				},
+				Options: TableOptions{
+					Engine:     "InnoDB",
+					Charset:    "utf8mb4",
+					Collation:  "utf8mb4_unicode_ci",
+					RowFormat:  "DYNAMIC",
+					Comment:    "everyone we know",
+					Tablespace: "fast",
+					Unlogged:   true,
+				},
+			},
+		},
+		{
+			name: "soft delete disabled",
+			yml: `
+soft_delete: false
+columns:
+  id:
+    type: INT`,
+			want: Table{
+				Columns: []Column{
+					{
+						Name:     "id",
+						Datatype: datatype.Integer,
+					},
+				},
+			},
+		},
+		{
+			name: "soft delete named column",
+			yml: `
+soft_delete: removed
+columns:
+  id:
+    type: INT
+  removed:
+    type: DATETIME
+    nullable: true`,
+			want: Table{
+				SoftDeleteColumn: "removed",
+				Columns: []Column{
+					{
+						Name:     "id",
+						Datatype: datatype.Integer,
+					},
+					{
+						Name:     "removed",
+						Datatype: datatype.DateTime,
+						Nullable: true,
+					},
+				},
+			},
+		},
+		{
+			name: "soft delete column not nullable",
+			yml: `
+soft_delete: removed
+columns:
+  removed:
+    type: DATETIME`,
+			wantErr: true,
+		},
+		{
+			name: "timestamps",
+			yml: `
+timestamps: true
+columns:
+  id:
+    type: INT
+  created_at:
+    type: DATETIME`,
+			want: Table{
+				Columns: []Column{
+					{
+						Name:     "id",
+						Datatype: datatype.Integer,
+					},
+					{
+						Name:       "created_at",
+						Datatype:   datatype.DateTime,
+						AutoNowAdd: true,
+					},
+					{
+						Name:     "updated_at",
+						Datatype: datatype.Timestamp,
+						AutoNow:  true,
+					},
+				},
+			},
+		},
+		{
+			name: "timestamps on a column which isn't a time",
+			yml: `
+timestamps: true
+columns:
+  created_at:
+    type: INT`,
+			wantErr: true,
+		},
+		{
+			name: "spatial index",
+			yml: `
+columns:
+  location:
+    type: point(4326)
+indices:
+  - name: idx_location
+    columns: [location]
+    type: SPATIAL`,
+			want: Table{
+				Columns: []Column{
+					{
+						Name:     "location",
+						Datatype: datatype.Point,
+						Params:   []string{"4326"},
+					},
+				},
+				Indices: []Index{
+					{
+						Name:    "idx_location",
+						Columns: []string{"location"},
+						Type:    IndexTypeSpatial,
+					},
+				},
+			},
+		},
+		{
+			name: "spatial index on a column which isn't spatial",
+			yml: `
+columns:
+  col:
+    type: INT
+indices:
+  - name: idx_col
+    columns: [col]
+    type: spatial`,
+			wantErr: true,
+		},
+		{
+			name: "unique spatial index",
+			yml: `
+columns:
+  location:
+    type: point
+indices:
+  - name: idx_location
+    columns: [location]
+    type: spatial
+    unique: true`,
+			wantErr: true,
+		},
+		{
+			name: "version column without primary key",
+			yml: `
+version_column: version
+columns:
+  col:
+    type: INT`,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := Table{}
-			if err := yaml.Unmarshal([]byte(tt.yml), &r); err != nil {
-				t.Errorf("Got error %s", err)
+			if err := yaml.Unmarshal([]byte(tt.yml), &r); (err != nil) != tt.wantErr {
+				t.Errorf("Got error %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
 			}
 
 			if !reflect.DeepEqual(r, tt.want) {
@@ -317,6 +882,16 @@ nullable: true`,
 				Nullable: true,
 			},
 		},
+		{
+			name: "comment",
+			yml: `
+type: INT
+comment: Number of seats`,
+			want: Column{
+				Datatype: datatype.Integer,
+				Comment:  "Number of seats",
+			},
+		},
 		{
 			name: "int with goname",
 			yml: `
@@ -351,6 +926,71 @@ auto_increment: true`,
 				AutoIncrement: true,
 			},
 		},
+		{
+			name: "string with json_name and omit",
+			yml: `
+type: text
+json_name: secret
+omit: true`,
+			want: Column{
+				Datatype: datatype.Text,
+				JSONName: "secret",
+				Omit:     true,
+			},
+		},
+		{
+			name: "text array",
+			yml:  `type: varchar(32)[]`,
+			want: Column{
+				Datatype: datatype.ArrayOf(datatype.Varchar),
+				Params:   []string{"32"},
+			},
+		},
+		{
+			name:    "point with invalid srid",
+			yml:     `type: point(wgs84)`,
+			wantErr: true,
+		},
+		{
+			name: "default_expr",
+			yml: `
+type: binary(16)
+default_expr: UUID_TO_BIN(UUID())`,
+			want: Column{
+				Datatype:    datatype.Binary,
+				Params:      []string{"16"},
+				DefaultExpr: "UUID_TO_BIN(UUID())",
+			},
+		},
+		{
+			name: "generated",
+			yml: `
+type: int
+generated:
+  expr: price * quantity
+  stored: true`,
+			want: Column{
+				Datatype:  datatype.Integer,
+				Generated: &Generated{Expr: "price * quantity", Stored: true},
+			},
+		},
+		{
+			name: "json with default",
+			yml: `
+type: json
+default: '{"theme": "dark"}'`,
+			want: Column{
+				Datatype: datatype.JSON,
+				Default:  func() *string { s := `{"theme": "dark"}`; return &s }(),
+			},
+		},
+		{
+			name: "json with invalid default",
+			yml: `
+type: json
+default: '{theme: dark}'`,
+			wantErr: true,
+		},
 		{
 			name: "string with collation and charset",
 			yml: `
@@ -367,8 +1007,12 @@ collation: latin`,
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := Column{}
-			if err := yaml.Unmarshal([]byte(tt.yml), &r); err != nil {
-				t.Errorf("Got error %s", err)
+			if err := yaml.Unmarshal([]byte(tt.yml), &r); (err != nil) != tt.wantErr {
+				t.Errorf("Got error %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
 			}
 
 			if !reflect.DeepEqual(r, tt.want) {
@@ -1,28 +1,38 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
 )
 
+// These are the referential actions allowed for OnDelete and OnUpdate
 const (
-	// Currently, only cascade is supported by Yoyo
-	setCascade = "CASCADE"
-
-	actions = ":" + setCascade + ":"
-
-	nameCharacterLimit = 30
+	ActionCascade    = "CASCADE"
+	ActionSetNull    = "SET NULL"
+	ActionSetDefault = "SET DEFAULT"
+	ActionRestrict   = "RESTRICT"
+	ActionNoAction   = "NO ACTION"
 )
 
+func validateAction(action string) error {
+	switch action {
+	case "", ActionCascade, ActionSetNull, ActionSetDefault, ActionRestrict, ActionNoAction:
+		return nil
+	}
+	return fmt.Errorf("unknown action '%s'", action)
+}
+
+// validateName returns an error if name has characters which aren't allowed in identifiers. How long names can be
+// depends on the dialect, so that's checked by validation.ValidateDatabase.
 func validateName(name string) error {
 	invalid := disallowedNameChars.Match([]byte(name))
 	if invalid {
 		return fmt.Errorf("invalid characters in Name: %s", name)
 	}
-	if len(name) > nameCharacterLimit {
-		return fmt.Errorf("Name '%s' is too long. Must be %d characters or less", name, nameCharacterLimit)
-	}
 	return nil
 }
 
@@ -47,16 +57,68 @@ func (c *Column) validate() error {
 		return fmt.Errorf("auto_increment is set but the column is not primary key")
 	}
 
+	if c.Default != nil && c.DefaultExpr != "" {
+		return fmt.Errorf("only one of default and default_expr may be set")
+	}
+
+	if c.Generated != nil {
+		switch {
+		case c.Generated.Expr == "":
+			return fmt.Errorf("generated column must have an expr")
+		case c.Default != nil, c.DefaultExpr != "":
+			return fmt.Errorf("generated column cannot have a default")
+		case c.AutoIncrement, c.AutoNowAdd, c.AutoNow:
+			return fmt.Errorf("generated column cannot be auto_increment, auto_now_add or auto_now")
+		}
+	}
+
 	if c.Default != nil {
 		if _, err := strconv.ParseFloat(*c.Default, 64); err != nil && c.Datatype.IsNumeric() {
 			return fmt.Errorf("non-numeric default '%s' used for numeric type '%s'", *c.Default, c.Datatype)
 		}
+		if c.Datatype.IsJSON() && !json.Valid([]byte(*c.Default)) {
+			return fmt.Errorf("default '%s' is not valid JSON", *c.Default)
+		}
+	}
+
+	if c.Datatype.IsSpatial() && len(c.Params) > 0 {
+		if _, err := strconv.ParseUint(strings.TrimSpace(c.Params[0]), 10, 32); err != nil || len(c.Params) > 1 {
+			return fmt.Errorf("datatype '%s' only takes an SRID parameter, like point(4326)", c.Datatype)
+		}
 	}
 
 	if c.Datatype.RequiresParams() && len(c.Params) == 0 {
 		return fmt.Errorf("datatype '%s' requires at least one parameter", c.Datatype)
 	}
 
+	if c.AutoNowAdd || c.AutoNow {
+		if c.Datatype != datatype.DateTime && c.Datatype != datatype.Timestamp {
+			return fmt.Errorf("auto_now_add or auto_now is set but '%s' is not a DATETIME or TIMESTAMP", c.Datatype)
+		}
+		if c.GoType != "" {
+			return fmt.Errorf("auto_now_add or auto_now is set but the column has a go_type")
+		}
+	}
+
+	if c.GoType != "" && !goTypeMatcher.MatchString(c.GoType) {
+		return fmt.Errorf("invalid go_type '%s', expected a form like 'github.com/google/uuid.UUID'", c.GoType)
+	}
+
+	if c.IsEnum() {
+		consts := make(map[string]bool)
+		for _, v := range c.EnumValues() {
+			// the initialisms are the same for every value, so they can't make names collide
+			name := c.EnumConstName(nil, v)
+			if name == c.EnumTypeName(nil) {
+				return fmt.Errorf("enum value '%s' cannot be represented as a Go constant", v)
+			}
+			if consts[name] {
+				return fmt.Errorf("enum value '%s' collides with another value as Go constant '%s'", v, name)
+			}
+			consts[name] = true
+		}
+	}
+
 	return nil
 }
 
@@ -69,6 +131,40 @@ func (i *Index) validate() error {
 		return fmt.Errorf("index must have at least one column")
 	}
 
+	switch i.Type {
+	case "":
+	case IndexTypeSpatial:
+		if i.Unique {
+			return fmt.Errorf("spatial index cannot be unique")
+		}
+	default:
+		return fmt.Errorf("unknown index type '%s'", i.Type)
+	}
+
+	return nil
+}
+
+func (c *Check) validate() error {
+	if err := validateName(c.Name); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(c.Expr) == "" {
+		return fmt.Errorf("check must have an expr")
+	}
+
+	return nil
+}
+
+func (u *Unique) validate() error {
+	if err := validateName(u.Name); err != nil {
+		return err
+	}
+
+	if len(u.Columns) == 0 {
+		return fmt.Errorf("unique must have at least one column")
+	}
+
 	return nil
 }
 
@@ -80,111 +176,233 @@ func (r *Reference) validate() error {
 		return fmt.Errorf("reference must be either HasOne or HasMany")
 	}
 
-	if !strings.Contains(actions, r.OnUpdate) {
-		return fmt.Errorf("unknown action for OnUpdate: '%s'", r.OnUpdate)
+	if err := validateAction(r.OnUpdate); err != nil {
+		return fmt.Errorf("%w for on_update", err)
+	}
+	if err := validateAction(r.OnDelete); err != nil {
+		return fmt.Errorf("%w for on_delete", err)
 	}
-	if !strings.Contains(actions, r.OnDelete) {
-		return fmt.Errorf("unknown action for OnUpdate: '%s'", r.OnUpdate)
+
+	// SET NULL nulls out the foreign key columns, which are only nullable if the reference isn't required. SET DEFAULT
+	// sets them to their default, so it's allowed either way.
+	for _, action := range []string{r.OnUpdate, r.OnDelete} {
+		if r.Required && action == ActionSetNull {
+			return fmt.Errorf("action '%s' requires the reference to have `required: false`", action)
+		}
 	}
 
 	return nil
 }
 
-func (t *Table) validate() (err error) {
-	if err = validateName(t.Name); err != nil {
-		return err
+// validate collects every problem of the table at the position of the part it's about. Columns and references are
+// validated as they're unmarshalled, so only their names are checked here.
+func (t *Table) validate(ps parts) error {
+	var p problems
+
+	if err := validateName(t.Name); err != nil {
+		p.add(ps.self, err)
 	}
 
 	if len(t.Columns) == 0 {
-		return fmt.Errorf("must have at least one column")
+		p.add(ps.self, fmt.Errorf("must have at least one column"))
 	}
 
 	cNames := make(map[string]bool)
 	for _, col := range t.Columns {
-		if err = col.validate(); err != nil {
-			return fmt.Errorf("column '%s' validation error: %w", col.Name, err)
-		}
-
-		if _, ok := cNames[col.Name]; ok {
-			return fmt.Errorf("duplicate column name '%s'", col.Name)
-		} else {
-			cNames[col.Name] = true
+		if cNames[col.Name] {
+			p.add(ps.at("column:"+col.Name), fmt.Errorf("duplicate column name '%s'", col.Name))
 		}
+		cNames[col.Name] = true
 	}
 
 	for _, i := range t.Indices {
-		if err = i.validate(); err != nil {
-			return fmt.Errorf("index '%s' validation error: %w", i.Name, err)
+		at := ps.at("index:" + i.Name)
+		if err := i.validate(); err != nil {
+			p.add(at, fmt.Errorf("index '%s' validation error: %w", i.Name, err))
 		}
 		for _, icn := range i.Columns {
-			var colExists bool
-			for _, c := range t.Columns {
-				if icn == c.Name {
-					colExists = true
-					break
-				}
+			c, ok := t.GetColumn(icn)
+			if !ok {
+				p.add(at, fmt.Errorf("index '%s' validation error: column '%s' referenced but doesn't exist in table def", i.Name, icn))
+			} else if i.Type == IndexTypeSpatial && !c.Datatype.IsSpatial() {
+				p.add(at, fmt.Errorf("index '%s' validation error: column '%s' of spatial index is not a spatial type", i.Name, icn))
 			}
-			if !colExists {
-				return fmt.Errorf("index '%s' validation error: column '%s' referenced but doesn't exist in table def", i.Name, icn)
+		}
+	}
+
+	// constraint names share a namespace with indices in most DBMSs
+	constraintNames := make(map[string]bool)
+	for _, i := range t.Indices {
+		constraintNames[i.Name] = true
+	}
+
+	for _, c := range t.Checks {
+		at := ps.at("check:" + c.Name)
+		if err := c.validate(); err != nil {
+			p.add(at, fmt.Errorf("check '%s' validation error: %w", c.Name, err))
+		}
+		if constraintNames[c.Name] {
+			p.add(at, fmt.Errorf("duplicate index or constraint name '%s'", c.Name))
+		}
+		constraintNames[c.Name] = true
+	}
+
+	for _, u := range t.Uniques {
+		at := ps.at("unique:" + u.Name)
+		if err := u.validate(); err != nil {
+			p.add(at, fmt.Errorf("unique '%s' validation error: %w", u.Name, err))
+		}
+		if constraintNames[u.Name] {
+			p.add(at, fmt.Errorf("duplicate index or constraint name '%s'", u.Name))
+		}
+		constraintNames[u.Name] = true
+		for _, ucn := range u.Columns {
+			if _, ok := t.GetColumn(ucn); !ok {
+				p.add(at, fmt.Errorf("unique '%s' validation error: column '%s' referenced but doesn't exist in table def", u.Name, ucn))
 			}
 		}
 	}
 
-	for _, r := range t.References {
-		if err = r.validate(); err != nil {
-			return fmt.Errorf("%w on reference for table %s", err, r.TableName)
+	if t.VersionColumn != "" {
+		if err := t.validateVersionColumn(); err != nil {
+			p.add(ps.at("version_column"), fmt.Errorf("version_column '%s' validation error: %w", t.VersionColumn, err))
 		}
 	}
 
+	if t.SoftDeleteColumn != "" {
+		if err := t.validateSoftDeleteColumn(); err != nil {
+			p.add(ps.at("soft_delete"), fmt.Errorf("soft_delete column '%s' validation error: %w", t.SoftDeleteColumn, err))
+		}
+	}
+
+	return p.err()
+}
+
+func (t *Table) validateSoftDeleteColumn() error {
+	c, ok := t.GetColumn(t.SoftDeleteColumn)
+	switch {
+	case !ok:
+		return fmt.Errorf("column doesn't exist in table def")
+	case c.Datatype != datatype.DateTime && c.Datatype != datatype.Timestamp, c.GoType != "":
+		return fmt.Errorf("must be a DATETIME or TIMESTAMP")
+	case !c.Nullable:
+		return fmt.Errorf("must be nullable")
+	}
 	return nil
 }
 
-func (db *Database) validate() (err error) {
-	tNames := make(map[string]bool)
-	for _, t := range db.Tables {
-		if err = t.validate(); err != nil {
-			return fmt.Errorf("%w for table `%s`", err, t.Name)
+func (t *Table) validateVersionColumn() error {
+	c, ok := t.GetColumn(t.VersionColumn)
+	switch {
+	case !ok:
+		return fmt.Errorf("column doesn't exist in table def")
+	case len(t.PKColumns()) == 0:
+		return fmt.Errorf("table must have a primary key")
+	case !c.Datatype.IsInt() || c.GoType != "":
+		return fmt.Errorf("must be an integer type")
+	case c.Nullable:
+		return fmt.Errorf("must not be nullable")
+	case c.PrimaryKey, c.AutoIncrement:
+		return fmt.Errorf("must not be a primary key or auto_increment")
+	}
+	return nil
+}
+
+// validate collects every problem of the view at the position of the part it's about. Columns are validated as they're
+// unmarshalled, so only their names are checked here.
+func (v *View) validate(ps parts) error {
+	var p problems
+
+	if err := validateName(v.Name); err != nil {
+		p.add(ps.self, err)
+	}
+
+	if strings.TrimSpace(v.Definition) == "" {
+		p.add(ps.self, fmt.Errorf("must have a definition"))
+	}
+
+	if len(v.Columns) == 0 {
+		p.add(ps.self, fmt.Errorf("must have at least one column"))
+	}
+
+	cNames := make(map[string]bool)
+	for _, col := range v.Columns {
+		if cNames[col.Name] {
+			p.add(ps.at("column:"+col.Name), fmt.Errorf("duplicate column name '%s'", col.Name))
 		}
+		cNames[col.Name] = true
+	}
+
+	return p.err()
+}
+
+// validate collects the problems between the tables and views of the database, like references to missing tables.
+// Each table and view is validated on its own as it's unmarshalled.
+func (db *Database) validate() error {
+	var (
+		p   problems
+		pos = db.positionsOf()
+	)
+
+	switch db.UUIDStorage {
+	case "", UUIDStorageNative, UUIDStorageBinary, UUIDStorageBinarySwapped, UUIDStorageChar:
+	default:
+		p.addAt(pos.options["uuid_storage"], fmt.Errorf("unknown uuid_storage '%s'", db.UUIDStorage))
+	}
 
-		if _, ok := tNames[t.Name]; ok {
-			return fmt.Errorf("duplicate table name '%s'", t.Name)
-		} else {
-			tNames[t.Name] = true
+	tNames := make(map[string]bool)
+	for ti, t := range db.Tables {
+		var at Position
+		if ti < len(pos.tables) {
+			at = pos.tables[ti]
+		}
+		if tNames[t.Name] {
+			p.addAt(at, fmt.Errorf("duplicate table name '%s'", t.Name))
 		}
+		tNames[t.Name] = true
 
 		for _, r := range t.References {
-			var ft Table
-			rName := r.TableName
-			for _, table := range db.Tables {
-				if table.Name == rName {
-					ft = table
-				}
+			if _, ok := db.GetView(r.TableName); ok {
+				p.addAt(at, fmt.Errorf("table `%s` cannot reference view `%s`", t.Name, r.TableName))
+				continue
 			}
 
-			if ft.Name == "" {
-				return fmt.Errorf("reference table `%s` does not exist in schema yaml", rName)
+			ft, ok := db.GetTable(r.TableName)
+			if !ok {
+				if !pos.invalid[r.TableName] {
+					// invalid tables have their own errors already
+					p.addAt(at, fmt.Errorf("reference table `%s` does not exist in schema yaml", r.TableName))
+				}
+				continue
 			}
 
+			rName := r.TableName
 			if r.HasMany {
 				ft, rName = t, t.Name
 			}
 
-			pkCount := 0
-			for _, c := range ft.Columns {
-				if c.PrimaryKey {
-					pkCount++
-				}
-			}
+			pkCount := len(ft.PKColumns())
 
 			if len(r.ColumnNames) > 0 && len(r.ColumnNames) != pkCount {
-				return fmt.Errorf("cannot add reference from `%s` to `%s`: length of column_names does not match length of primary keys", t.Name, rName)
+				p.addAt(at, fmt.Errorf("cannot add reference from `%s` to `%s`: length of column_names does not match length of primary keys", t.Name, rName))
 			}
 
 			if pkCount == 0 {
-				return fmt.Errorf("table `%s` has no primary key but needs it for a defined reference", rName)
+				p.addAt(at, fmt.Errorf("table `%s` has no primary key but needs it for a defined reference", rName))
 			}
 		}
 	}
 
-	return nil
+	for vi, v := range db.Views {
+		if tNames[v.Name] {
+			var at Position
+			if vi < len(pos.views) {
+				at = pos.views[vi]
+			}
+			p.addAt(at, fmt.Errorf("duplicate table or view name '%s'", v.Name))
+		}
+		tNames[v.Name] = true
+	}
+
+	return p.err()
 }
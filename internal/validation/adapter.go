@@ -18,6 +18,14 @@ type Adapter interface {
 	ValidateTable(col schema.Table) error
 	// SupportsAutoIncrement returns true if the underlying DBMS supports AutoIncrement
 	SupportsAutoIncrement() bool
+	// IdentifierLimit returns the most characters the underlying DBMS allows in an identifier, or 0 if there's no limit
+	IdentifierLimit() int
+	// TruncatesIdentifiers returns true if the underlying DBMS truncates identifiers longer than the IdentifierLimit
+	// instead of rejecting them
+	TruncatesIdentifiers() bool
+	// IsReservedWord returns true if the underlying DBMS doesn't allow word as an unquoted identifier. Generated queries
+	// don't quote identifiers, so reserved words can't be used as names.
+	IsReservedWord(word string) bool
 }
 
 func LoadValidator(name string) (a Adapter, err error) {
@@ -1,29 +1,147 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"unicode/utf8"
 
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
 	"github.com/yoyo-project/yoyo/internal/schema"
 )
 
-func ValidateDatabase(db schema.Database) (err error) {
-	var validator Adapter
-	validator, err = LoadValidator(db.Dialect)
+// ValidateDatabase returns every way in which the database's dialect doesn't support it, joined into one error. Names
+// which the dialect accepts but changes, like identifiers it truncates, are returned as warnings instead. Both are
+// prefixed with the position of their table, view or column if the database was unmarshalled from YAML.
+func ValidateDatabase(db schema.Database) (warnings []string, err error) {
+	validator, err := LoadValidator(db.Dialect)
 	if err != nil {
-		return fmt.Errorf("unable to load database validator: %w", err)
+		return nil, fmt.Errorf("unable to load database validator: %w", err)
 	}
 
+	var (
+		errs []error
+		n    = names{validator: validator, dialect: db.Dialect}
+	)
 	for _, t := range db.Tables {
-		err = validator.ValidateTable(t)
+		at := t.Position
+		if err = validator.ValidateTable(t); err != nil {
+			errs = append(errs, located(at, fmt.Errorf("%s does not support table `%s`: %w", db.Dialect, t.Name, err)))
+		}
+		n.check(at, "table", "", t.Name)
 		for _, c := range t.Columns {
-			if !validator.SupportsDatatype(c.Datatype) {
-				return fmt.Errorf("%s does not support datatype `%s` on `%s`.`%s`", db.Dialect, c.Datatype, t.Name, c.Name)
+			if err = validateColumn(validator, db.Dialect, t.Name, c); err != nil {
+				errs = append(errs, located(columnPosition(t, c), err))
+			}
+			n.check(columnPosition(t, c), "column", t.Name, c.Name)
+		}
+		for _, r := range t.References {
+			ft, ok := db.GetTable(r.TableName)
+			if !ok {
+				continue
 			}
-			if c.AutoIncrement && !validator.SupportsAutoIncrement() {
-				return fmt.Errorf("%s does not support AutoIncrement on `%s`.`%s`", db.Dialect, t.Name, c.Name)
+			// the columns of references are reported at the reference's table, where they're declared
+			if r.HasOne {
+				for _, cn := range r.ColNames(ft) {
+					n.check(at, "column", t.Name, cn)
+				}
+			} else {
+				for _, cn := range r.ColNames(t) {
+					n.check(at, "column", ft.Name, cn)
+				}
 			}
 		}
+		for _, i := range t.Indices {
+			n.checkLength(at, "index", "", i.Name)
+		}
+		for _, c := range t.Checks {
+			n.checkLength(at, "check", "", c.Name)
+		}
+		for _, u := range t.Uniques {
+			n.checkLength(at, "unique", "", u.Name)
+		}
+	}
+
+	for _, v := range db.Views {
+		n.check(v.Position, "view", "", v.Name)
+		for _, c := range v.Columns {
+			if err = validateColumn(validator, db.Dialect, v.Name, c); err != nil {
+				errs = append(errs, located(columnPosition(v.Table, c), err))
+			}
+			n.check(columnPosition(v.Table, c), "column", v.Name, c.Name)
+		}
+	}
+
+	return n.warnings, errors.Join(append(errs, n.errs...)...)
+}
+
+// names checks identifiers against the length limit and reserved words of a dialect
+type names struct {
+	validator Adapter
+	dialect   string
+	warnings  []string
+	errs      []error
+}
+
+// check adds a problem at pos if the name, of a column if table is set, is a reserved word or is too long. Generated
+// queries use the names of tables, views and columns without quoting them.
+func (n *names) check(pos schema.Position, kind, table, name string) {
+	if n.validator.IsReservedWord(name) {
+		n.errs = append(n.errs, located(pos, fmt.Errorf("%s name %s is a reserved word in %s", kind, qualify(table, name), n.dialect)))
+	}
+	n.checkLength(pos, kind, table, name)
+}
+
+// checkLength adds a problem at pos if the name, of a column if table is set, is longer than the dialect allows. It's
+// only a warning if the dialect truncates long names instead of rejecting them.
+func (n *names) checkLength(pos schema.Position, kind, table, name string) {
+	limit := n.validator.IdentifierLimit()
+	if limit == 0 || utf8.RuneCountInString(name) <= limit {
+		return
 	}
 
+	if n.validator.TruncatesIdentifiers() {
+		n.warnings = append(n.warnings, located(pos, fmt.Errorf("%s name %s is longer than %d characters, so %s will truncate it", kind, qualify(table, name), limit, n.dialect)).Error())
+	} else {
+		n.errs = append(n.errs, located(pos, fmt.Errorf("%s name %s is longer than the %d characters %s allows", kind, qualify(table, name), limit, n.dialect)))
+	}
+}
+
+// columnPosition returns the Position of a column, or of its table if yoyo added the column
+func columnPosition(t schema.Table, c schema.Column) schema.Position {
+	if c.Position == (schema.Position{}) {
+		return t.Position
+	}
+	return c.Position
+}
+
+// located returns err as a schema.ValidationError at pos, unless pos is zero because the database wasn't unmarshalled
+// from YAML
+func located(pos schema.Position, err error) error {
+	if pos == (schema.Position{}) {
+		return err
+	}
+	return &schema.ValidationError{Position: pos, Err: err}
+}
+
+// qualify returns the quoted name, prefixed by its table if it's set
+func qualify(table, name string) string {
+	if table == "" {
+		return fmt.Sprintf("`%s`", name)
+	}
+	return fmt.Sprintf("`%s`.`%s`", table, name)
+}
+
+// validateColumn returns an error if the dialect doesn't support the datatype or auto increment of a column of the
+// named table or view
+func validateColumn(validator Adapter, dia, table string, c schema.Column) error {
+	if !validator.SupportsDatatype(c.Datatype) {
+		if (c.Datatype.IsArray() || c.Datatype.IsRange()) && dia != dialect.PostgreSQL {
+			return fmt.Errorf("%s does not support datatype `%s` on `%s`.`%s`, arrays and ranges are only supported by %s", dia, c.Datatype, table, c.Name, dialect.PostgreSQL)
+		}
+		return fmt.Errorf("%s does not support datatype `%s` on `%s`.`%s`", dia, c.Datatype, table, c.Name)
+	}
+	if c.AutoIncrement && !validator.SupportsAutoIncrement() {
+		return fmt.Errorf("%s does not support AutoIncrement on `%s`.`%s`", dia, table, c.Name)
+	}
 	return nil
 }
@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/datatype"
+	"github.com/yoyo-project/yoyo/internal/dbms/dialect"
+	"github.com/yoyo-project/yoyo/internal/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateDatabase(t *testing.T) {
+	long := strings.Repeat("a", 64)
+
+	tests := []struct {
+		name         string
+		db           schema.Database
+		wantWarnings []string
+		wantErrs     []string
+	}{
+		{
+			name: "valid",
+			db: schema.Database{
+				Dialect: dialect.MySQL,
+				Tables:  []schema.Table{{Name: "person", Columns: []schema.Column{{Name: "id", Datatype: datatype.Integer}}}},
+			},
+		},
+		{
+			name: "unknown dialect",
+			db:   schema.Database{Dialect: "nope"},
+			wantErrs: []string{
+				"unable to load database validator: unknown dialect `nope`",
+			},
+		},
+		{
+			name: "every problem with mysql",
+			db: schema.Database{
+				Dialect: dialect.MySQL,
+				Tables: []schema.Table{{
+					Name: "order",
+					Columns: []schema.Column{
+						{Name: "id", Datatype: datatype.Integer},
+						{Name: "flag", Datatype: datatype.Boolean},
+						{Name: long + "a", Datatype: datatype.Integer},
+					},
+					Indices: []schema.Index{{Name: long + "a", Columns: []string{"id"}}},
+				}},
+				Views: []schema.View{{Table: schema.Table{Name: "select", Columns: []schema.Column{{Name: "user", Datatype: datatype.Integer}}}}},
+			},
+			wantErrs: []string{
+				"mysql does not support datatype `BOOLEAN` on `order`.`flag`",
+				"table name `order` is a reserved word in mysql",
+				"column name `order`.`" + long + "a` is longer than the 64 characters mysql allows",
+				"index name `" + long + "a` is longer than the 64 characters mysql allows",
+				"view name `select` is a reserved word in mysql",
+			},
+		},
+		{
+			name: "names counted in characters",
+			db: schema.Database{
+				Dialect: dialect.MySQL,
+				Tables: []schema.Table{{
+					Name:    strings.Repeat("é", 64),
+					Columns: []schema.Column{{Name: strings.Repeat("ü", 65), Datatype: datatype.Integer}},
+				}},
+			},
+			wantErrs: []string{
+				"column name `" + strings.Repeat("é", 64) + "`.`" + strings.Repeat("ü", 65) + "` is longer than the 64 characters mysql allows",
+			},
+		},
+		{
+			name: "names with postgresql",
+			db: schema.Database{
+				Dialect: dialect.PostgreSQL,
+				Tables: []schema.Table{
+					{
+						Name:       "user",
+						Columns:    []schema.Column{{Name: "id", Datatype: datatype.Integer, PrimaryKey: true}},
+						References: []schema.Reference{{TableName: long, HasOne: true}},
+					},
+					{
+						Name:    long,
+						Columns: []schema.Column{{Name: "id", Datatype: datatype.Integer, PrimaryKey: true}},
+					},
+				},
+			},
+			wantWarnings: []string{
+				"column name `user`.`fk_" + long + "_id` is longer than 63 characters, so postgresql will truncate it",
+				"table name `" + long + "` is longer than 63 characters, so postgresql will truncate it",
+			},
+			wantErrs: []string{
+				"table name `user` is a reserved word in postgresql",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := ValidateDatabase(tt.db)
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("ValidateDatabase() warnings =\n%q,\nwant\n%q", warnings, tt.wantWarnings)
+			}
+
+			var errs []string
+			if err != nil {
+				errs = strings.Split(err.Error(), "\n")
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("ValidateDatabase() errors =\n%q,\nwant\n%q", errs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestValidateDatabase_positions(t *testing.T) {
+	long := strings.Repeat("a", 64)
+
+	var db schema.Database
+	err := yaml.Unmarshal([]byte(`
+dialect: postgresql
+tables:
+  user:
+    columns:
+      id:
+        type: int
+        primary_key: true
+      `+long+`:
+        type: int
+    references:
+      city:
+        has_one: true
+        column_names: [`+long+`_city]
+  city:
+    columns:
+      id:
+        type: int
+        primary_key: true`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err := ValidateDatabase(db)
+	wantWarnings := []string{
+		"yoyo.yml:9:7: column name `user`.`" + long + "` is longer than 63 characters, so postgresql will truncate it",
+		"yoyo.yml:4:3: column name `user`.`" + long + "_city` is longer than 63 characters, so postgresql will truncate it",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("ValidateDatabase() warnings =\n%q,\nwant\n%q", warnings, wantWarnings)
+	}
+	if want := "yoyo.yml:4:3: table name `user` is a reserved word in postgresql"; err == nil || err.Error() != want {
+		t.Errorf("ValidateDatabase() error = %v, want %s", err, want)
+	}
+}
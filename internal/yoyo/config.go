@@ -21,6 +21,7 @@ const (
 // LoadConfig searches for a yoyo.yml file, unmarshals it, and returns the unmarshaled struct.
 // It travels toward the filesystem root, searching for yoyo.yml in each directory until
 // it finds the file, or arrives at either / or <DriveLetter>:\
+// Schema files included by yoyo.yml are merged into its schema.
 func LoadConfig() (yml Config, err error) {
 	var (
 		dir string
@@ -48,6 +49,10 @@ func LoadConfig() (yml Config, err error) {
 	}
 
 	err = yaml.Unmarshal(f, &yml)
+	if err == nil && len(yml.Schema.Include) > 0 {
+		// included schema files are relative to yoyo.yml, not the working directory
+		err = yml.Schema.LoadIncludes(os.DirFS(dir))
+	}
 
 	if yml.Paths.Migrations == "" {
 		yml.Paths.Migrations = fmt.Sprintf("%s/%s", dir, defaultMigrationsPath)
@@ -66,8 +71,9 @@ func LoadConfig() (yml Config, err error) {
 
 // Config is a struct which represents the yoyo.yml file
 type Config struct {
-	Paths  Paths
-	Schema schema.Database
+	Paths        Paths
+	Schema       schema.Database
+	Repositories Repositories
 }
 
 // Paths defines the locations that Migrations and generated Repositories code will be created in
@@ -76,3 +82,13 @@ type Paths struct {
 	Repositories string // Soon...
 	Models       string // Soon...
 }
+
+// Repositories defines options that change the shape of generated Repositories code
+type Repositories struct {
+	// GenericNullables switches nullable columns from the fixed nullable wrapper types to the generic nullable.Value[T]
+	GenericNullables bool `yaml:"generic_nullables"`
+	// Tags is the list of struct tags generated on entity fields. Supported tags are json, db and yaml
+	Tags []string
+	// TagNaming is the naming strategy for json and yaml tags: column (default), snake, camel or pascal
+	TagNaming string `yaml:"tag_naming"`
+}
@@ -29,12 +29,15 @@ func cfg(wd string) Config {
 								var s = "0"
 								return &s
 							}(),
+							Position: schema.Position{Line: 8, Column: 9},
 						},
 						{
 							Name:     "secondary_id",
 							Datatype: datatype.Integer,
+							Position: schema.Position{Line: 12, Column: 9},
 						},
 					},
+					Position: schema.Position{Line: 6, Column: 5},
 				},
 				{
 					Name: "secondary",
@@ -42,8 +45,10 @@ func cfg(wd string) Config {
 						{
 							Name:     "id",
 							Datatype: datatype.Integer,
+							Position: schema.Position{Line: 19, Column: 9},
 						},
 					},
+					Position: schema.Position{Line: 17, Column: 5},
 				},
 			},
 		},
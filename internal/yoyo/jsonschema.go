@@ -0,0 +1,68 @@
+package yoyo
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+// jsonSchemaDraft is the version of JSON Schema the schemas are written in, which editors need to interpret them
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns the JSON Schema of yoyo.yml, for editors to autocomplete and validate it with
+func JSONSchema() *schema.JSONSchema {
+	return &schema.JSONSchema{
+		Schema: jsonSchemaDraft,
+		Title:  filename,
+		Type:   "object",
+		Properties: map[string]*schema.JSONSchema{
+			"paths": {
+				Description: "Where generated code is written, relative to yoyo.yml",
+				Type:        "object",
+				Properties: map[string]*schema.JSONSchema{
+					"migrations":   {Description: "The directory migrations are written to, " + defaultMigrationsPath + " by default", Type: "string"},
+					"repositories": {Description: "The directory repositories are written to, " + defaultRepositoryPath + " by default", Type: "string"},
+					"models":       {Description: "Reserved for generated models, which aren't supported yet", Type: "string"},
+				},
+				AdditionalProperties: false,
+			},
+			"schema": schema.DatabaseJSONSchema(),
+			"repositories": {
+				Description: "Options for generated repositories",
+				Type:        "object",
+				Properties: map[string]*schema.JSONSchema{
+					"generic_nullables": {Description: "Use the generic nullable.Value[T] for nullable columns", Type: "boolean"},
+					"tags": {
+						Description: "The struct tags generated on entity fields",
+						Type:        "array",
+						Items:       &schema.JSONSchema{Type: "string", Enum: []string{"json", "db", "yaml"}},
+					},
+					"tag_naming": {
+						Description: "The naming strategy for json and yaml tags, column by default",
+						Type:        "string",
+						Enum:        []string{"column", "snake", "camel", "pascal"},
+					},
+				},
+				AdditionalProperties: false,
+			},
+		},
+		AdditionalProperties: false,
+	}
+}
+
+// IncludeJSONSchema returns the JSON Schema of the files included into the schema of yoyo.yml
+func IncludeJSONSchema() *schema.JSONSchema {
+	s := schema.IncludeJSONSchema()
+	s.Schema = jsonSchemaDraft
+	s.Title = "yoyo.yml include"
+	return s
+}
+
+// WriteJSONSchema writes s as indented JSON, the way the published schemas are formatted
+func WriteJSONSchema(w io.Writer, s *schema.JSONSchema) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(s)
+}
@@ -0,0 +1,44 @@
+package yoyo
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/yoyo-project/yoyo/internal/schema"
+)
+
+func TestWriteJSONSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *schema.JSONSchema
+		file   string
+	}{
+		{
+			name:   "yoyo.yml",
+			schema: JSONSchema(),
+			file:   "../../yoyo.schema.json",
+		},
+		{
+			name:   "included files",
+			schema: IncludeJSONSchema(),
+			file:   "../../yoyo-include.schema.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bytes.Buffer
+			if err := WriteJSONSchema(&got, tt.schema); err != nil {
+				t.Fatalf("WriteJSONSchema() error = %v", err)
+			}
+
+			want, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatalf("unable to read published schema: %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("%s is out of date, regenerate it with `yoyo schema json-schema`", tt.file)
+			}
+		})
+	}
+}